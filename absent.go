@@ -4,7 +4,10 @@
 
 package validation
 
-import "context"
+import (
+	"context"
+	"reflect"
+)
 
 var _ Rule = (*absentRule)(nil)
 
@@ -13,6 +16,8 @@ var (
 	ErrNil = NewError("validation_nil", "must be blank")
 	// ErrEmpty is the error that returns when a not nil value is not empty.
 	ErrEmpty = NewError("validation_empty", "must be blank")
+	// ErrAbsent is the error that returns when a value is set.
+	ErrAbsent = NewError("validation_absent", "must not be set")
 )
 
 // Nil is a validation rule that checks if a value is nil.
@@ -22,26 +27,35 @@ var Nil = absentRule{condition: true, skipNil: false}
 // Empty checks if a not nil value is empty.
 var Empty = absentRule{condition: true, skipNil: true}
 
+// Absent checks if a value is not set, i.e. it is either a nil pointer or a non-pointer zero value.
+// Unlike Empty, a non-nil pointer is considered set even if it points to a zero value, which
+// makes Absent suitable for mutually exclusive fields in API payloads decoded into pointer fields.
+var Absent = absentRule{condition: true, skipNil: true, rejectPtr: true}
+
 type absentRule struct {
 	condition bool
 	err       Error
 	skipNil   bool
+	rejectPtr bool
 }
 
 // Validate checks if the given value is valid or not.
 func (r absentRule) Validate(ctx context.Context, value interface{}) error {
-	if r.condition {
-		value, isNil := indirectWithOptions(value, GetOptions(ctx))
-		if !r.skipNil && !isNil || r.skipNil && !isNil && !IsEmpty(value) {
-			if r.err != nil {
-				return r.err
-			}
-			if r.skipNil {
-				return ErrEmpty
-			}
-			return ErrNil
+	if !r.condition {
+		return nil
+	}
+
+	if r.rejectPtr {
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+			return r.error()
 		}
 	}
+
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if !r.skipNil && !isNil || r.skipNil && !isNil && !isEmptyWithOptions(value, opts) {
+		return r.error()
+	}
 	return nil
 }
 
@@ -51,16 +65,16 @@ func (r absentRule) When(condition bool) absentRule {
 	return r
 }
 
+// Unless sets the condition that determines if the validation should be skipped.
+// It is the opposite of When.
+func (r absentRule) Unless(condition bool) absentRule {
+	r.condition = !condition
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r absentRule) Error(message string) absentRule {
-	if r.err == nil {
-		if r.skipNil {
-			r.err = ErrEmpty
-		} else {
-			r.err = ErrNil
-		}
-	}
-	r.err = r.err.SetMessage(message)
+	r.err = r.error().SetMessage(message)
 	return r
 }
 
@@ -69,3 +83,33 @@ func (r absentRule) ErrorObject(err Error) absentRule {
 	r.err = err
 	return r
 }
+
+// Metadata returns the description of the rule.
+// The kind is "nil" for Nil, "empty" for Empty and "absent" for Absent.
+// The kind is empty if the rule is disabled by When or Unless.
+func (r absentRule) Metadata() RuleInfo {
+	switch {
+	case !r.condition:
+		return RuleInfo{}
+	case r.rejectPtr:
+		return RuleInfo{Kind: "absent"}
+	case r.skipNil:
+		return RuleInfo{Kind: "empty"}
+	default:
+		return RuleInfo{Kind: "nil"}
+	}
+}
+
+// error returns the error of the rule, or the default error if it is not customized.
+func (r absentRule) error() Error {
+	switch {
+	case r.err != nil:
+		return r.err
+	case r.rejectPtr:
+		return ErrAbsent
+	case r.skipNil:
+		return ErrEmpty
+	default:
+		return ErrNil
+	}
+}
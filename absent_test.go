@@ -102,3 +102,40 @@ func TestAbsentRule_Error(t *testing.T) {
 	assert.Equal(t, err.Message(), r.err.Message())
 	assert.NotEqual(t, err, Nil.err)
 }
+
+func TestAbsent(t *testing.T) {
+	s1 := "123"
+	s2 := ""
+	var s3 *string
+	var m map[string]int
+	tests := []struct {
+		tag   string
+		rule  absentRule
+		value interface{}
+		err   string
+	}{
+		{"t1", Absent, 123, "must not be set"},
+		{"t2", Absent, "", ""},
+		{"t3", Absent, &s1, "must not be set"},
+		{"t4", Absent, &s2, "must not be set"},
+		{"t5", Absent, s3, ""},
+		{"t6", Absent, nil, ""},
+		{"t7", Absent, m, ""},
+		{"t8", Absent, map[string]int{}, ""},
+		{"t9", Absent, []int{1}, "must not be set"},
+		{"t10", Absent.When(false), &s1, ""},
+		{"t11", Absent.Unless(true), &s1, ""},
+		{"t12", Absent.Unless(false), &s1, "must not be set"},
+		{"t13", Absent.Error("either a or b"), 1, "either a or b"},
+		{"t14", Nil.Unless(false), &s2, "must be blank"},
+		{"t15", Empty.Unless(true), &s1, ""},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	assert.Equal(t, ErrAbsent, Absent.Validate(nil, 1))
+	assert.Equal(t, "validation_absent", Absent.Error("abc").err.Code())
+}
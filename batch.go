@@ -0,0 +1,235 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// BatchResult is the result of validating a slice of items with ValidateAll or ValidateAllParallel.
+type BatchResult struct {
+	// Errors holds the validation errors of the invalid items by their index.
+	Errors map[int]error
+	// Validated is the number of items that were validated. It is less than the number of items
+	// if the validation stopped early, e.g. because the maximum number of errors set by WithMaxErrors was reached.
+	Validated int
+	// opts holds the options of the validation, which format the keys returned by Err.
+	opts *options
+}
+
+// Valid reports whether all the validated items are valid.
+func (r BatchResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Err returns the validation errors as Errors keyed by item index, e.g. "3", or nil if all the validated items are valid.
+// The keys are formatted by the ElementKeyFunc set by WithElementKeyFunc in the context of the validation.
+func (r BatchResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	opts := r.opts
+	if opts == nil {
+		opts = defaultOptions.Load()
+	}
+	errs := make(Errors, len(r.Errors))
+	for i, err := range r.Errors {
+		errs[opts.elementKey(strconv.Itoa(i), i, true)] = err
+	}
+	return errs
+}
+
+func (r *BatchResult) add(i int, err error) {
+	if r.Errors == nil {
+		r.Errors = map[int]error{}
+	}
+	r.Errors[i] = err
+}
+
+// ValidateAll validates each item of a slice of structs or struct pointers with the schema, e.g. the records
+// of a bulk import. The errors of the invalid items are returned in the BatchResult by item index.
+// If the schema is nil, the items are validated by ValidateWithContext, e.g. with their Validate methods.
+//
+// Use WithMaxErrors to stop the validation once a number of invalid items is found. If an item returns
+// an internal error or the context is canceled, the validation stops and the error is returned
+// together with the result of the items validated so far.
+func ValidateAll[T any](ctx context.Context, items []T, schema *Schema) (BatchResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = startRuleCache(ctx)
+
+	result := BatchResult{opts: getOpts(ctx)}
+	maxErrors := result.opts.maxErrors
+	direct := passItemsDirectly(items)
+	for i := range items {
+		if err := ctx.Err(); err != nil {
+			return result, NewInternalError(err)
+		}
+
+		err := validateItem(ctx, items, i, direct, schema)
+		result.Validated++
+		if err == nil {
+			continue
+		}
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return result, err
+		}
+		result.add(i, err)
+		if maxErrors > 0 && len(result.Errors) >= maxErrors {
+			break
+		}
+	}
+	return result, nil
+}
+
+// ValidateAllParallel validates the items like ValidateAll, but splits them into shards validated concurrently.
+// The number of shards is bounded by the WithMaxWorkers option, which defaults to runtime.GOMAXPROCS(0).
+// The rules of the schema must be safe for concurrent use.
+//
+// When the maximum number of errors set by WithMaxErrors is reached, the other shards stop, so the reported
+// items are not necessarily the first invalid ones. If several items return an internal error, the one with
+// the lowest index is returned.
+func ValidateAllParallel[T any](ctx context.Context, items []T, schema *Schema) (BatchResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = startRuleCache(ctx)
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts := getOpts(ctx)
+	workers := opts.maxWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		result     = BatchResult{opts: opts}
+		stopped    bool
+		fatal      error
+		fatalIndex int
+	)
+	direct := passItemsDirectly(items)
+	validate := func(start, end int) {
+		defer wg.Done()
+		for i := start; i < end && ctx.Err() == nil; i++ {
+			err := validateItem(ctx, items, i, direct, schema)
+
+			mu.Lock()
+			result.Validated++
+			if err != nil && !stopped {
+				if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+					if fatal == nil || i < fatalIndex {
+						fatal, fatalIndex = err, i
+					}
+				} else {
+					result.add(i, err)
+					stopped = opts.maxErrors > 0 && len(result.Errors) >= opts.maxErrors
+				}
+			}
+			if stopped || fatal != nil {
+				cancel()
+			}
+			mu.Unlock()
+		}
+	}
+
+	if workers > 0 {
+		size := (len(items) + workers - 1) / workers
+		for start := 0; start < len(items); start += size {
+			end := start + size
+			if end > len(items) {
+				end = len(items)
+			}
+			wg.Add(1)
+			go validate(start, end)
+		}
+	}
+	wg.Wait()
+
+	if fatal != nil {
+		return result, fatal
+	}
+	if err := parent.Err(); err != nil {
+		return result, NewInternalError(err)
+	}
+	return result, nil
+}
+
+// passItemsDirectly reports whether the items are pointers or interfaces, which are validated as they are.
+// Other items are validated by pointer, so that struct items are not copied.
+func passItemsDirectly[T any](items []T) bool {
+	kind := reflect.TypeOf(items).Elem().Kind()
+	return kind == reflect.Ptr || kind == reflect.Interface
+}
+
+// validateItem validates the item at index i with the schema, or with ValidateWithContext if the schema is nil.
+func validateItem[T any](ctx context.Context, items []T, i int, direct bool, schema *Schema) error {
+	var item interface{}
+	if direct {
+		item = items[i]
+	} else {
+		item = &items[i]
+	}
+
+	ctx = withElement(ctx, strconv.Itoa(i), i)
+	if schema == nil {
+		return ValidateWithContext(ctx, item)
+	}
+	return schema.Validate(ctx, item)
+}
+
+// ValidateStream validates the items produced by next until it returns false, e.g. the records read from
+// a large CSV or JSON Lines file, without holding the items or their errors in memory. The item index,
+// counted from 0, and the error of every invalid item are passed to onError.
+// If the schema is nil, the items are validated by ValidateWithContext, e.g. with their Validate methods.
+//
+// The number of validated items is returned. Use WithMaxErrors to stop the validation once a number of
+// invalid items is found. If an item returns an internal error or the context is canceled, the validation
+// stops and the error is returned.
+func ValidateStream(ctx context.Context, next func() (interface{}, bool), schema *Schema, onError func(index int, err error)) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = startRuleCache(ctx)
+
+	maxErrors := getOpts(ctx).maxErrors
+	validated, failed := 0, 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return validated, NewInternalError(err)
+		}
+		item, ok := next()
+		if !ok {
+			return validated, nil
+		}
+
+		itemCtx := withElement(ctx, strconv.Itoa(validated), validated)
+		var err error
+		if schema == nil {
+			err = ValidateWithContext(itemCtx, item)
+		} else {
+			err = schema.Validate(itemCtx, item)
+		}
+		validated++
+		if err == nil {
+			continue
+		}
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return validated, err
+		}
+		onError(validated-1, err)
+		if failed++; maxErrors > 0 && failed >= maxErrors {
+			return validated, nil
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type batchItem struct {
+	SKU      string
+	Quantity int
+}
+
+func (i batchItem) Validate(ctx context.Context) error {
+	return ValidateStructWithContext(ctx, &i, Field(&i.SKU, Required))
+}
+
+func TestValidateAll(t *testing.T) {
+	schema := NewSchema(
+		Spec("SKU", Required),
+		Spec("Quantity", Min(1)),
+	)
+	items := []batchItem{{"a", 1}, {"", 1}, {"c", -1}, {"d", 2}, {"", 0}}
+
+	for _, validate := range []func(context.Context, []batchItem, *Schema) (BatchResult, error){ValidateAll[batchItem], ValidateAllParallel[batchItem]} {
+		result, err := validate(context.Background(), items, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, result.Validated)
+		assert.False(t, result.Valid())
+		assert.Equal(t, []int{1, 2, 4}, batchIndexes(result))
+		assert.EqualError(t, result.Err(), "1: (SKU: cannot be blank.); 2: (Quantity: must be no less than 1.); 4: (SKU: cannot be blank.).")
+
+		result, err = validate(context.Background(), items[:1], schema)
+		assert.NoError(t, err)
+		assert.True(t, result.Valid())
+		assert.Nil(t, result.Err())
+
+		result, err = validate(context.Background(), nil, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Validated)
+
+		// the keys are formatted by the element key func of the context
+		ctx := WithOptions(context.Background(), WithElementKeyFunc(OneBasedElementKey))
+		result, err = validate(ctx, items, schema)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 4}, batchIndexes(result))
+		assert.EqualError(t, result.Err(), "2: (SKU: cannot be blank.); 3: (Quantity: must be no less than 1.); 5: (SKU: cannot be blank.).")
+	}
+
+	// the maximum number of errors
+	ctx := WithOptions(context.Background(), WithMaxErrors(2))
+	result, err := ValidateAll(ctx, items, schema)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Validated)
+	assert.Equal(t, []int{1, 2}, batchIndexes(result))
+
+	ctx = WithOptions(context.Background(), WithMaxErrors(2), WithMaxWorkers(1))
+	result, err = ValidateAllParallel(ctx, items, schema)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Validated)
+	assert.Equal(t, []int{1, 2}, batchIndexes(result))
+
+	// pointers and a nil schema
+	ptrs := []*batchItem{&items[0], nil, &items[1]}
+	result, err = ValidateAll(context.Background(), ptrs, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, batchIndexes(result))
+
+	// the index is in the field path
+	var paths []string
+	record := NewSchema(Spec("SKU", By(func(ctx context.Context, value interface{}) error {
+		paths = append(paths, FieldPath(ctx).String())
+		return nil
+	})))
+	_, err = ValidateAll(context.Background(), items[:2], record)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.SKU", "1.SKU"}, paths)
+
+	// internal errors
+	internal := NewSchema(Spec("SKU", By(func(ctx context.Context, value interface{}) error {
+		if value == "c" || value == "d" {
+			return NewInternalError(errors.New("internal " + value.(string)))
+		}
+		return nil
+	})))
+	result, err = ValidateAll(context.Background(), items, internal)
+	assert.EqualError(t, err, "internal c")
+	assert.Equal(t, 3, result.Validated)
+	_, err = ValidateAllParallel(WithOptions(context.Background(), WithMaxWorkers(5)), items, internal)
+	assert.EqualError(t, err, "internal c")
+
+	// canceled context
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ValidateAll(canceled, items, schema)
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = ValidateAllParallel(canceled, items, schema)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func batchIndexes(r BatchResult) []int {
+	var indexes []int
+	for i := range r.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+func TestValidateStream(t *testing.T) {
+	schema := NewSchema(Spec("SKU", Required))
+	items := []batchItem{{"a", 1}, {"", 1}, {"c", 1}, {"", 1}, {"", 1}}
+	iterate := func(items []batchItem) func() (interface{}, bool) {
+		i := 0
+		return func() (interface{}, bool) {
+			if i >= len(items) {
+				return nil, false
+			}
+			i++
+			return items[i-1], true
+		}
+	}
+
+	var failed []int
+	onError := func(i int, err error) {
+		failed = append(failed, i)
+		assert.EqualError(t, err, "SKU: cannot be blank.")
+	}
+	n, err := ValidateStream(context.Background(), iterate(items), schema, onError)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, []int{1, 3, 4}, failed)
+
+	// the maximum number of errors
+	failed = nil
+	n, err = ValidateStream(WithOptions(context.Background(), WithMaxErrors(2)), iterate(items), schema, onError)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+	assert.Equal(t, []int{1, 3}, failed)
+
+	// a nil schema
+	failed = nil
+	n, err = ValidateStream(context.Background(), iterate(items[:2]), nil, onError)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []int{1}, failed)
+
+	// internal errors
+	internal := NewSchema(Spec("SKU", By(func(ctx context.Context, value interface{}) error {
+		return NewInternalError(errors.New("internal"))
+	})))
+	n, err = ValidateStream(context.Background(), iterate(items), internal, onError)
+	assert.EqualError(t, err, "internal")
+	assert.Equal(t, 1, n)
+
+	// canceled context
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	n, err = ValidateStream(canceled, iterate(items), schema, onError)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, n)
+}
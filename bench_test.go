@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The benchmarks cover the hot path of batch jobs validating many records: scalar values checked by
+// prebuilt rules should not allocate, and a valid struct should only allocate for its field rules and contexts,
+// not for an Errors map. The values are boxed before the loops, since converting a string to an interface
+// allocates in the caller, not in the validation.
+
+func TestValidate_Allocs(t *testing.T) {
+	ctx := context.Background()
+	strRules := []Rule{Required, Length(1, 50)}
+	intRules := []Rule{Required, Min(1), Max(100)}
+	values := []interface{}{"alice", "bob", "carol"}
+	i := 0
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := ValidateWithContext(ctx, values[i%len(values)], strRules...); err != nil {
+			t.Fatal(err)
+		}
+		i++
+	})
+	assert.Zero(t, allocs, "string")
+
+	allocs = testing.AllocsPerRun(100, func() {
+		if err := ValidateWithContext(ctx, 42, intRules...); err != nil {
+			t.Fatal(err)
+		}
+	})
+	assert.Zero(t, allocs, "int")
+}
+
+func BenchmarkValidate_String(b *testing.B) {
+	ctx := context.Background()
+	rules := []Rule{Required, Length(1, 50)}
+	values := []interface{}{"alice", "bob", "carol"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateWithContext(ctx, values[i%len(values)], rules...)
+	}
+}
+
+func BenchmarkValidate_Int(b *testing.B) {
+	ctx := context.Background()
+	rules := []Rule{Required, Min(1), Max(100)}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateWithContext(ctx, 42, rules...)
+	}
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	type record struct {
+		Name  string
+		Email string
+		Age   int
+	}
+	ctx := context.Background()
+	r := record{Name: "alice", Email: "alice@example.com", Age: 42}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateStructWithContext(ctx, &r,
+			Field(&r.Name, Required, Length(1, 50)),
+			Field(&r.Email, Required, Length(5, 100)),
+			Field(&r.Age, Min(18)),
+		)
+	}
+}
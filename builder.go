@@ -0,0 +1,76 @@
+package validation
+
+import "context"
+
+var _ Validatable = (*StructValidator)(nil)
+
+// StructValidator collects the field rules of a struct with a fluent API.
+// It is created by For and validates the struct when Validate is called.
+type StructValidator struct {
+	structPtr interface{}
+	fields    []FieldRules
+	opts      []Option
+}
+
+// For returns a StructValidator for the struct referenced by structPtr.
+// It is an alternative to calling ValidateStructWithContext with a long list of field rules.
+// For example,
+//
+//	err := validation.For(&user).
+//	    Field(&user.Name, validation.Required).
+//	    Field(&user.Email, validation.Required, is.Email).
+//	    StructField(&user.Address,
+//	        validation.Field(&user.Address.Street, validation.Required),
+//	    ).
+//	    Validate(ctx)
+func For(structPtr interface{}) *StructValidator {
+	return &StructValidator{structPtr: structPtr}
+}
+
+// Field adds a struct field and the corresponding validation rules. See Field for details.
+func (v *StructValidator) Field(fieldPtr interface{}, rules ...Rule) *StructValidator {
+	return v.With(Field(fieldPtr, rules...))
+}
+
+// NamedField adds a struct field specified by name and the corresponding validation rules.
+// See NamedField for details.
+func (v *StructValidator) NamedField(name string, rules ...Rule) *StructValidator {
+	return v.With(NamedField(name, rules...))
+}
+
+// StructField adds a nested struct field and the corresponding validation field rules.
+// See FieldStruct for details.
+func (v *StructValidator) StructField(structPtr interface{}, fields ...FieldRules) *StructValidator {
+	return v.With(FieldStruct(structPtr, fields...))
+}
+
+// NamedStructField adds a nested struct field specified by name and the corresponding validation field rules.
+// See NamedStructField for details.
+func (v *StructValidator) NamedStructField(name string, fields ...FieldRules) *StructValidator {
+	return v.With(NamedStructField(name, fields...))
+}
+
+// With adds the given field rules.
+func (v *StructValidator) With(fields ...FieldRules) *StructValidator {
+	v.fields = append(v.fields, fields...)
+	return v
+}
+
+// Options sets the options applied to the context when the struct is validated.
+func (v *StructValidator) Options(opts ...Option) *StructValidator {
+	v.opts = append(v.opts, opts...)
+	return v
+}
+
+// Fields returns the field rules collected so far.
+func (v *StructValidator) Fields() []FieldRules {
+	return v.fields
+}
+
+// Validate validates the struct with the collected field rules.
+func (v *StructValidator) Validate(ctx context.Context) error {
+	if len(v.opts) > 0 {
+		ctx = WithOptions(ctx, v.opts...)
+	}
+	return ValidateStructWithContext(ctx, v.structPtr, v.fields...)
+}
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFor(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+		City   string `json:"city"`
+	}
+	type User struct {
+		Name    string  `json:"name"`
+		Email   string  `json:"email"`
+		Age     int     `json:"age"`
+		Address Address `json:"address"`
+		Billing Address `json:"billing"`
+	}
+
+	u := User{Age: 10}
+	tests := []struct {
+		tag       string
+		validator *StructValidator
+		err       string
+	}{
+		{"t1", For(&u), ""},
+		{"t2", For(&u).Field(&u.Name, Required), "name: cannot be blank."},
+		{"t3", For(&u).Field(&u.Name, Required).Field(&u.Email, Required).NamedField("age", Min(18)), "age: must be no less than 18; email: cannot be blank; name: cannot be blank."},
+		{"t4", For(&u).StructField(&u.Address, Field(&u.Address.Street, Required)), "address: (street: cannot be blank.)."},
+		{"t5", For(&u).NamedStructField("Billing", NamedField("City", Required)), "billing: (city: cannot be blank.)."},
+		{"t6", For(&u).With(Field(&u.Name, Required), Field(&u.Email)), "name: cannot be blank."},
+		{"t7", For(&u).Field(&u.Name, Required).Options(WithGetErrorFieldNameFunc(func(f *reflect.StructField) string {
+			return "field_" + f.Name
+		})), "field_Name: cannot be blank."},
+		{"t8", For(u), ErrStructPointer.Error()},
+	}
+
+	for _, test := range tests {
+		err := test.validator.Validate(context.Background())
+		assertError(t, test.err, err, test.tag)
+	}
+
+	v := For(&u).Field(&u.Name).Field(&u.Email)
+	assert.Len(t, v.Fields(), 2)
+}
@@ -0,0 +1,61 @@
+package validation
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts a field name to snake_case, e.g. "UserID" to "user_id" and "HTTPServer" to "http_server".
+// It is meant to be used with WithFieldNameTransformer.
+func SnakeCase(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+	return strings.Join(words, "_")
+}
+
+// CamelCase converts a field name to camelCase, e.g. "UserID" to "userId" and "created_at" to "createdAt".
+// It is meant to be used with WithFieldNameTransformer.
+func CamelCase(s string) string {
+	words := splitWords(s)
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			r := []rune(w)
+			r[0] = unicode.ToUpper(r[0])
+			w = string(r)
+		}
+		words[i] = w
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords splits a name into words at the underscores, hyphens, spaces and dots, and at the case changes.
+// A run of upper case letters is kept as one word, except for its last letter if a lower case letter follows,
+// e.g. "HTTPServer" is split into "HTTP" and "Server".
+func splitWords(s string) []string {
+	var words []string
+	rs := []rune(s)
+	start := 0
+	for i, r := range rs {
+		switch {
+		case r == '_' || r == '-' || r == ' ' || r == '.':
+			if start < i {
+				words = append(words, string(rs[start:i]))
+			}
+			start = i + 1
+		case unicode.IsUpper(r) && start < i:
+			prev := rs[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(rs) && unicode.IsLower(rs[i+1]) {
+				words = append(words, string(rs[start:i]))
+				start = i
+			}
+		}
+	}
+	if start < len(rs) {
+		words = append(words, string(rs[start:]))
+	}
+	return words
+}
@@ -0,0 +1,49 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		tag      string
+		value    string
+		expected string
+	}{
+		{"t1", "", ""},
+		{"t2", "Name", "name"},
+		{"t3", "UserID", "user_id"},
+		{"t4", "HTTPServer", "http_server"},
+		{"t5", "createdAt", "created_at"},
+		{"t6", "created_at", "created_at"},
+		{"t7", "Address2", "address2"},
+		{"t8", "Line2Text", "line2_text"},
+		{"t9", "first-name", "first_name"},
+		{"t10", "ID", "id"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, SnakeCase(test.value), test.tag)
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := []struct {
+		tag      string
+		value    string
+		expected string
+	}{
+		{"t1", "", ""},
+		{"t2", "Name", "name"},
+		{"t3", "UserID", "userId"},
+		{"t4", "HTTPServer", "httpServer"},
+		{"t5", "created_at", "createdAt"},
+		{"t6", "createdAt", "createdAt"},
+		{"t7", "first-name", "firstName"},
+		{"t8", "ID", "id"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, CamelCase(test.value), test.tag)
+	}
+}
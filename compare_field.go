@@ -0,0 +1,130 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+var _ Rule = (*FieldCompareRule)(nil)
+
+var (
+	// ErrCompareFieldPointer is the error that the compared field is not specified as a pointer.
+	ErrCompareFieldPointer = errors.New("the compared field must be specified as a pointer")
+
+	// ErrEqualToField is the error that returns when a value is not equal to the compared field.
+	ErrEqualToField = NewError("validation_equal_to_field", "must be equal to {{.field}}")
+	// ErrNotEqualToField is the error that returns when a value is equal to the compared field.
+	ErrNotEqualToField = NewError("validation_not_equal_to_field", "must not be equal to {{.field}}")
+)
+
+// FieldCompareRule is a validation rule that compares a value with another struct field.
+type FieldCompareRule struct {
+	fieldPtr interface{}
+	equal    bool
+	err      Error
+	compare  *StringCompare
+}
+
+// EqualToField returns a validation rule that checks if a value is equal to the field referenced by fieldPtr.
+// The compared field is read when the rule is validated, so the rule can be declared together with the other
+// field rules passed to ValidateStruct. reflect.DeepEqual() is used to compare the two values.
+// For example,
+//
+//	validation.ValidateStruct(&s,
+//	    validation.Field(&s.ConfirmPassword, validation.EqualToField(&s.Password)),
+//	)
+//
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func EqualToField(fieldPtr interface{}) FieldCompareRule {
+	return FieldCompareRule{
+		fieldPtr: fieldPtr,
+		equal:    true,
+		err:      ErrEqualToField,
+	}
+}
+
+// NotEqualToField returns a validation rule that checks if a value is different from the field referenced by fieldPtr.
+// Like EqualToField, the compared field is read when the rule is validated.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func NotEqualToField(fieldPtr interface{}) FieldCompareRule {
+	return FieldCompareRule{
+		fieldPtr: fieldPtr,
+		equal:    false,
+		err:      ErrNotEqualToField,
+	}
+}
+
+// Error sets the error message for the rule.
+func (r FieldCompareRule) Error(message string) FieldCompareRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// StringCompare sets how the strings are compared, e.g. ignoring case.
+func (r FieldCompareRule) StringCompare(c StringCompare) FieldCompareRule {
+	r.compare = &c
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r FieldCompareRule) ErrorObject(err Error) FieldCompareRule {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "equal_to_field" or "not_equal_to_field", with the param "field" holding the pointer
+// to the compared field. Inspect resolves the pointer to the name of the compared field.
+// The param "fold_case" or "normalize" is true if the strings are compared as set by StringCompare.
+func (r FieldCompareRule) Metadata() RuleInfo {
+	kind := "not_equal_to_field"
+	if r.equal {
+		kind = "equal_to_field"
+	}
+	params := map[string]interface{}{"field": r.fieldPtr}
+	if r.compare != nil {
+		params = r.compare.params(params)
+	}
+	return RuleInfo{
+		Kind:   kind,
+		Params: params,
+	}
+}
+
+// Validate checks if the given value is valid or not.
+func (r FieldCompareRule) Validate(ctx context.Context, value interface{}) error {
+	fv := reflect.ValueOf(r.fieldPtr)
+	if fv.Kind() != reflect.Ptr || fv.IsNil() {
+		return NewInternalError(ErrCompareFieldPointer)
+	}
+
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	other, _ := indirectWithOptions(fv.Elem().Interface(), opts)
+	equal := reflect.DeepEqual(value, other)
+	if r.compare != nil {
+		equal = r.compare.equal(value, other)
+	}
+	if equal == r.equal {
+		return nil
+	}
+
+	return r.err.SetParams(map[string]interface{}{"field": r.fieldName(ctx, fv)})
+}
+
+// fieldName resolves the error field name of the compared field from the struct being validated.
+func (r FieldCompareRule) fieldName(ctx context.Context, fv reflect.Value) string {
+	if parent, ok := Parent(ctx); ok {
+		if pv := reflect.ValueOf(parent); pv.Kind() == reflect.Ptr && pv.Elem().Kind() == reflect.Struct {
+			if ft := findStructField(pv.Elem(), fv); ft != nil {
+				return getOpts(ctx).errorFieldName(ft)
+			}
+		}
+	}
+	return "the compared field"
+}
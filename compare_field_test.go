@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqualToField(t *testing.T) {
+	type Account struct {
+		Password        string `json:"password"`
+		ConfirmPassword string `json:"confirm_password"`
+		NewPassword     *string
+	}
+
+	same, other := "secret", "other"
+	tests := []struct {
+		tag     string
+		account Account
+		err     string
+	}{
+		{"t1", Account{Password: "secret", ConfirmPassword: "secret"}, ""},
+		{"t2", Account{Password: "secret", ConfirmPassword: ""}, ""},
+		{"t3", Account{Password: "secret", ConfirmPassword: "other"}, "confirm_password: must be equal to password."},
+		{"t4", Account{Password: "secret", NewPassword: &other}, ""},
+		{"t5", Account{Password: "secret", NewPassword: &same}, "NewPassword: must not be equal to password."},
+	}
+
+	for _, test := range tests {
+		a := test.account
+		err := ValidateStruct(&a,
+			Field(&a.ConfirmPassword, EqualToField(&a.Password)),
+			Field(&a.NewPassword, NotEqualToField(&a.Password)),
+		)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestFieldCompareRule_Validate(t *testing.T) {
+	password := "secret"
+
+	err := EqualToField(&password).Validate(context.Background(), "other")
+	assert.EqualError(t, err, "must be equal to the compared field")
+
+	err = NotEqualToField(&password).Validate(context.Background(), "secret")
+	assert.EqualError(t, err, "must not be equal to the compared field")
+
+	err = EqualToField(password).Validate(context.Background(), "secret")
+	assert.EqualError(t, err, ErrCompareFieldPointer.Error())
+	_, ok := err.(InternalError)
+	assert.True(t, ok)
+
+	err = EqualToField(&password).Error("passwords do not match").Validate(context.Background(), "other")
+	assert.EqualError(t, err, "passwords do not match")
+
+	err = EqualToField(&password).ErrorObject(NewError("code", "abc")).Validate(context.Background(), "other")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "code", err.(Error).Code())
+	}
+}
+
+func TestFieldCompareRule_StringCompare(t *testing.T) {
+	type Account struct {
+		Email        string `json:"email"`
+		ConfirmEmail string `json:"confirm_email"`
+		Username     string `json:"username"`
+	}
+	fold := StringCompare{FoldCase: true}
+
+	tests := []struct {
+		tag     string
+		account Account
+		err     string
+	}{
+		{"t1", Account{Email: "a@example.com", ConfirmEmail: "A@Example.com", Username: "bob"}, ""},
+		{"t2", Account{Email: "a@example.com", ConfirmEmail: "b@example.com", Username: "bob"}, "confirm_email: must be equal to email."},
+		{"t3", Account{Email: "a@example.com", ConfirmEmail: "a@example.com", Username: "A@EXAMPLE.COM"}, "username: must not be equal to email."},
+	}
+	for _, test := range tests {
+		a := test.account
+		err := ValidateStruct(&a,
+			Field(&a.ConfirmEmail, EqualToField(&a.Email).StringCompare(fold)),
+			Field(&a.Username, NotEqualToField(&a.Email).StringCompare(fold)),
+		)
+		assertError(t, test.err, err, test.tag)
+	}
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"reflect"
+	"strings"
+)
+
+// StringCompare specifies how In, NotIn, EqualToField and NotEqualToField compare strings, so that enum-like
+// strings sent with inconsistent casing or encoding can be validated predictably. It only applies when both
+// compared values are strings, whose types may differ, e.g. a string and a named string type.
+// The other values are compared with reflect.DeepEqual().
+type StringCompare struct {
+	// FoldCase compares the strings under Unicode case folding, e.g. "Active" equals "ACTIVE".
+	FoldCase bool
+	// Normalize, if set, maps both strings before they are compared, e.g. norm.NFC.String of golang.org/x/text
+	// to treat the composed and decomposed forms of accented letters as equal, or strings.TrimSpace.
+	Normalize func(string) string
+}
+
+// equal reports whether the two values are equal.
+func (c StringCompare) equal(a, b interface{}) bool {
+	av, bv := reflect.ValueOf(a), reflect.ValueOf(b)
+	if av.Kind() != reflect.String || bv.Kind() != reflect.String {
+		return reflect.DeepEqual(a, b)
+	}
+	as, bs := av.String(), bv.String()
+	if c.Normalize != nil {
+		as, bs = c.Normalize(as), c.Normalize(bs)
+	}
+	if c.FoldCase {
+		return strings.EqualFold(as, bs)
+	}
+	return as == bs
+}
+
+// params adds the params describing the comparison to the params of a rule's Metadata:
+// "fold_case" and "normalize" are set to true if the strings are compared case-insensitively or normalized.
+func (c StringCompare) params(params map[string]interface{}) map[string]interface{} {
+	if c.FoldCase {
+		params["fold_case"] = true
+	}
+	if c.Normalize != nil {
+		params["normalize"] = true
+	}
+	return params
+}
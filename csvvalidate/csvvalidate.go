@@ -0,0 +1,129 @@
+// Package csvvalidate validates the rows of CSV files, such as bulk uploads, with key rules mapped by the header.
+package csvvalidate
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/rockcookies/go-validation"
+)
+
+// RowError is the validation error of a CSV row.
+type RowError struct {
+	// Row is the number of the row, where the header is row 1, as shown by spreadsheet applications.
+	Row int
+	// Err is the validation error of the row, which is validation.Errors keyed by column name for the errors of
+	// the columns.
+	Err error
+}
+
+// Error returns the error message, with one `row 17, column "email": ...` entry per column error.
+func (e *RowError) Error() string {
+	var errs validation.Errors
+	if !errors.As(e.Err, &errs) {
+		return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+	}
+
+	columns := make([]string, 0, len(errs))
+	for column := range errs {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	msgs := make([]string, len(columns))
+	for i, column := range columns {
+		msgs[i] = fmt.Sprintf("row %d, column %q: %v", e.Row, column, errs[column])
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the validation error of the row.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is the validation errors of the invalid rows of a CSV file, in the order of the rows.
+type Errors []*RowError
+
+// Error returns the error messages of the rows, one row per line.
+func (es Errors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Validate reads the records from r, takes the first one as the header, and validates each of the others
+// as a map from the column names to the string values with the key rules, in the same way as validation.ValidateForm.
+// For example,
+//
+//	err := csvvalidate.Validate(ctx, csv.NewReader(file),
+//	    validation.Key("email", validation.Required, is.Email),
+//	    validation.Key("age", validation.AsInt(validation.Min(18))).Optional(),
+//	)
+//
+// A column missing from the header is reported with validation.ErrKeyMissing for every row unless Optional is
+// called on the key rules. Columns without rules are ignored. A UTF-8 byte order mark before the header is removed.
+//
+// The errors of the invalid rows are returned as Errors. Use validation.WithMaxErrors to stop reading the rows once
+// a number of invalid rows is found. An error reading the records, e.g. a *csv.ParseError, is returned as is,
+// and an internal error of a rule or the error of a canceled context as an InternalError.
+func Validate(ctx context.Context, r *csv.Reader, keys ...*validation.KeyRules) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\uFEFF")
+	}
+
+	var errs Errors
+	rule := validation.Map(keys...).AllowExtraKeys()
+	maxErrors := validation.GetOptions(ctx).MaxErrors()
+	for row := 2; ; row++ {
+		if err := ctx.Err(); err != nil {
+			return validation.NewInternalError(err)
+		}
+
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		values := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				values[column] = record[i]
+			}
+		}
+		if err := rule.Validate(ctx, values); err != nil {
+			var ie validation.InternalError
+			if errors.As(err, &ie) {
+				return err
+			}
+			errs = append(errs, &RowError{Row: row, Err: err})
+			if maxErrors > 0 && len(errs) >= maxErrors {
+				break
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package csvvalidate
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	keys := []*validation.KeyRules{
+		validation.Key("name", validation.Required),
+		validation.Key("age", validation.AsInt(validation.Min(18))).Optional(),
+	}
+
+	tests := []struct {
+		tag  string
+		data string
+		opts []validation.Option
+		err  string
+	}{
+		{"t1", "name,age,note\njohn,20,x\njane,,\n", nil, ""},
+		{"t2", "", nil, ""},
+		{"t3", "name,age\n", nil, ""},
+		{"t4", "\uFEFFname,age\njohn,20\n", nil, ""},
+		{"t5", "name,age\n,20\njohn,17\njane,x\n", nil,
+			"row 2, column \"name\": cannot be blank\n" +
+				"row 3, column \"age\": must be no less than 18\n" +
+				"row 4, column \"age\": must be an integer"},
+		{"t6", "name,age\n,x\n", nil, "row 2, column \"age\": must be an integer; row 2, column \"name\": cannot be blank"},
+		{"t7", "age\n20\n", nil, "row 2, column \"name\": required key is missing"},
+		{"t8", "name,age\n,20\n,17\n,16\n", []validation.Option{validation.WithMaxErrors(2)},
+			"row 2, column \"name\": cannot be blank\nrow 3, column \"age\": must be no less than 18; row 3, column \"name\": cannot be blank"},
+	}
+	for _, test := range tests {
+		ctx := validation.WithOptions(context.Background(), test.opts...)
+		err := Validate(ctx, csv.NewReader(strings.NewReader(test.data)), keys...)
+		if test.err == "" {
+			assert.NoError(t, err, test.tag)
+		} else if assert.Error(t, err, test.tag) {
+			assert.Equal(t, test.err, err.Error(), test.tag)
+		}
+	}
+}
+
+func TestValidate_Errors(t *testing.T) {
+	err := Validate(context.Background(), csv.NewReader(strings.NewReader("name\n\n\"a\n")), validation.Key("name"))
+	var pe *csv.ParseError
+	assert.True(t, errors.As(err, &pe))
+
+	var errs Errors
+	err = Validate(context.Background(), csv.NewReader(strings.NewReader("name\n\"\"\n")), validation.Key("name", validation.Required))
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.Equal(t, 2, errs[0].Row)
+		assert.Equal(t, validation.Errors{"name": validation.ErrRequired}, errs[0].Unwrap())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Validate(ctx, csv.NewReader(strings.NewReader("name\njohn\n")))
+	var ie validation.InternalError
+	assert.True(t, errors.As(err, &ie))
+
+	assert.Equal(t, "row 3: invalid", (&RowError{Row: 3, Err: errors.New("invalid")}).Error())
+}
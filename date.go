@@ -81,14 +81,28 @@ func (r DateRule) Max(max time.Time) DateRule {
 	return r
 }
 
+// Metadata returns the description of the rule.
+// The kind is "date", with the param "layout", and the params "min" and "max" if the date range is set.
+func (r DateRule) Metadata() RuleInfo {
+	params := map[string]interface{}{"layout": r.layout}
+	if !r.min.IsZero() {
+		params["min"] = r.min
+	}
+	if !r.max.IsZero() {
+		params["max"] = r.max
+	}
+	return RuleInfo{Kind: "date", Params: params}
+}
+
 // Validate checks if the given value is a valid date.
 func (r DateRule) Validate(ctx context.Context, value interface{}) error {
-	value, isNil := indirectWithOptions(value, GetOptions(ctx))
-	if isNil || IsEmpty(value) {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
 		return nil
 	}
 
-	str, err := EnsureString(value)
+	str, err := stringWithOptions(value, opts)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// validateDeep validates the exported fields of a struct, or the elements of a map, slice or array, with
+// ValidateWithContext, so that the Validatable values and the values with a registered Schema are validated
+// at every level. It is used by ValidateWithContext when WithDeepValidation is enabled.
+func validateDeep(ctx context.Context, rv reflect.Value) error {
+	errs := Errors{}
+	switch rv.Kind() {
+	case reflect.Struct:
+		if !rv.CanAddr() {
+			c := reflect.New(rv.Type()).Elem()
+			c.Set(rv)
+			rv = c
+		}
+		structPtr := rv.Addr().Interface()
+		if err := checkNesting(ctx, structPtr); err != nil {
+			return err
+		}
+		if err := validateDeepFields(ctx, structPtr, rv, errs); err != nil {
+			return err
+		}
+	case reflect.Map:
+		if !deepType(rv.Type().Elem()) {
+			return nil
+		}
+		for _, key := range rv.MapKeys() {
+			k := fmt.Sprintf("%v", key.Interface())
+			if err := ValidateWithContext(withElement(ctx, k, key.Interface()), rv.MapIndex(key).Interface()); err != nil {
+				if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+					return err
+				}
+				errs[getOpts(ctx).elementKey(k, key.Interface(), false)] = err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if !deepType(rv.Type().Elem()) {
+			return nil
+		}
+		for i := 0; i < rv.Len(); i++ {
+			k := strconv.Itoa(i)
+			if err := ValidateWithContext(withElement(ctx, k, i), rv.Index(i).Interface()); err != nil {
+				if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+					return err
+				}
+				errs[getOpts(ctx).elementKey(k, i, true)] = err
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// deepType reports whether the values of type t may hold values to be validated by the deep validation,
+// i.e. whether t is not a basic type, such as string or int, that does not implement Validatable.
+func deepType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return isValidatable(t)
+}
+
+// validateDeepFields validates the fields of the struct value rv, which is structPtr or a struct embedded in it,
+// and adds the errors to errs.
+func validateDeepFields(ctx context.Context, structPtr interface{}, rv reflect.Value, errs Errors) error {
+	opts := getOpts(ctx)
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		ft := t.Field(i)
+		if !deepType(ft.Type) {
+			continue
+		}
+		if !ft.IsExported() {
+			if ft.Anonymous && ft.Type.Kind() == reflect.Struct {
+				// the exported fields promoted from an unexported embedded struct
+				if err := validateDeepFields(ctx, structPtr, rv.Field(i), errs); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		name := opts.getErrorFieldNameFunc(&ft)
+		fv := rv.Field(i).Interface()
+		err := ValidateWithContext(withField(ctx, structPtr, &ft, name, fv), fv)
+		if err != nil {
+			if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+				return err
+			}
+			errs.addFieldError(&fieldError{field: &ft, name: opts.transformFieldName(name), err: err})
+		}
+	}
+	return nil
+}
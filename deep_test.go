@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithDeepValidation(t *testing.T) {
+	type server struct {
+		Host ptrValidatable `json:"host"`
+		Port int            `json:"port"`
+	}
+	type embedded struct {
+		Owner ptrValidatable `json:"owner"`
+	}
+	type config struct {
+		embedded
+		Server   server                    `json:"server"`
+		Backup   *server                   `json:"backup"`
+		Replicas []server                  `json:"replicas"`
+		Users    map[string]ptrValidatable `json:"users"`
+		Extra    interface{}               `json:"extra"`
+		Tags     []string                  `json:"tags"`
+		private  ptrValidatable
+	}
+
+	valid := ptrValidatable{Name: "a"}
+	deep := WithOptions(context.Background(), WithDeepValidation(true))
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		value interface{}
+		err   string
+	}{
+		{"t1", context.Background(), &config{}, ""},
+		{"t2", deep, &config{embedded: embedded{valid}, Server: server{Host: valid}}, ""},
+		{"t3", deep, &config{embedded: embedded{valid}}, "server: (host: (Name: cannot be blank.).)."},
+		{"t4", deep, config{Server: server{Host: valid}}, "owner: (Name: cannot be blank.)."},
+		{"t5", deep, &config{
+			embedded: embedded{valid},
+			Server:   server{Host: valid},
+			Backup:   &server{},
+			Replicas: []server{{Host: valid}, {}},
+			Users:    map[string]ptrValidatable{"x": {}},
+			Extra:    &server{},
+		}, "backup: (host: (Name: cannot be blank.).); extra: (host: (Name: cannot be blank.).); replicas: (1: (host: (Name: cannot be blank.).).); users: (x: (Name: cannot be blank.).)."},
+		{"t6", deep, []server{{}}, "0: (host: (Name: cannot be blank.).)."},
+		{"t7", deep, map[string]*server{"a": nil, "b": {Host: valid}}, ""},
+		{"t8", WithOptions(deep, WithSchema(server{}, NewSchema(Spec("Port", Required)))),
+			&config{embedded: embedded{valid}}, "server: (port: cannot be blank.)."},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
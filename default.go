@@ -0,0 +1,90 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+)
+
+var _ Normalizer = DefaultRule{}
+
+// DefaultRule is a normalizer that sets an empty value to a default value.
+type DefaultRule struct {
+	value interface{}
+}
+
+// Default returns a normalizer that sets the value to v if the value is empty, so that the rules after it
+// validate the default value. For example,
+//
+//	validation.Field(&c.Port, validation.Default(8080), validation.Min(1), validation.Max(65535))
+//
+// The value is written back to the struct field as described in Normalizer, so Default is usually applied
+// to struct fields or pointers. A value is empty as determined by IsEmpty, e.g. a zero number, an empty string
+// or a nil pointer. If the value is a nil pointer, it is set to a pointer to v.
+// The type of v must be assignable or convertible to the type of the value, e.g. an untyped integer constant
+// for an int64 field, but a number is never converted to a string. Otherwise, the value is left unchanged.
+func Default(v interface{}) DefaultRule {
+	return DefaultRule{value: v}
+}
+
+// Normalize returns the default value if the value is empty.
+// If the value is a pointer, the value it points to is set to the default value instead.
+func (r DefaultRule) Normalize(value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().CanSet() {
+		if target := rv.Elem(); IsEmpty(target.Interface()) {
+			if dv, ok := r.valueOf(target.Type()); ok {
+				target.Set(dv)
+			}
+		}
+		return value
+	}
+
+	if !IsEmpty(value) || !rv.IsValid() {
+		return value
+	}
+	if dv, ok := r.valueOf(rv.Type()); ok {
+		return dv.Interface()
+	}
+	return value
+}
+
+// valueOf returns the default value as a value of type t.
+func (r DefaultRule) valueOf(t reflect.Type) (reflect.Value, bool) {
+	dv := reflect.ValueOf(r.value)
+	if !dv.IsValid() {
+		return reflect.Value{}, false
+	}
+	if t.Kind() == reflect.Ptr && !dv.Type().AssignableTo(t) {
+		ev, ok := convertValue(dv, t.Elem())
+		if !ok {
+			return reflect.Value{}, false
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(ev)
+		return p, true
+	}
+	return convertValue(dv, t)
+}
+
+// convertValue converts v to type t if v is assignable to t, or convertible to t without turning a number
+// into a string.
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+	if v.Type().ConvertibleTo(t) && (v.Kind() == reflect.String) == (t.Kind() == reflect.String) {
+		return v.Convert(t), true
+	}
+	return reflect.Value{}, false
+}
+
+// Validate does nothing, as the rule only normalizes the value.
+func (r DefaultRule) Validate(context.Context, interface{}) error {
+	return nil
+}
+
+// Metadata returns the description of the rule.
+// The kind is "default", with the param "value" holding the default value.
+func (r DefaultRule) Metadata() RuleInfo {
+	return RuleInfo{Kind: "default", Params: map[string]interface{}{"value": r.value}}
+}
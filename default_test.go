@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultRule(t *testing.T) {
+	type myString string
+	var nilInt *int
+	five := 5
+
+	tests := []struct {
+		tag      string
+		rule     DefaultRule
+		value    interface{}
+		expected interface{}
+	}{
+		{"t1", Default("abc"), "", "abc"},
+		{"t2", Default("abc"), "xyz", "xyz"},
+		{"t3", Default(8080), int64(0), int64(8080)},
+		{"t4", Default("abc"), myString(""), myString("abc")},
+		{"t5", Default(65), "", ""},
+		{"t6", Default(time.Second), time.Duration(0), time.Second},
+		{"t7", Default(1), nil, nil},
+		{"t8", Default(nil), 0, 0},
+		{"t9", Default(10), &five, &five},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.rule.Normalize(test.value), test.tag)
+	}
+	assert.Equal(t, 5, five)
+
+	// the value is set through the pointer
+	n := 0
+	Default(10).Normalize(&n)
+	assert.Equal(t, 10, n)
+	Default(10).Normalize(&nilInt)
+	if assert.NotNil(t, nilInt) {
+		assert.Equal(t, 10, *nilInt)
+	}
+
+	assert.Equal(t, RuleInfo{Kind: "default", Params: map[string]interface{}{"value": 10}}, Default(10).Metadata())
+}
+
+func TestValidateStruct_Default(t *testing.T) {
+	type config struct {
+		Host    string         `json:"host"`
+		Port    int            `json:"port"`
+		Timeout *time.Duration `json:"timeout"`
+		Mode    string         `json:"mode"`
+	}
+
+	c := config{Mode: "fast"}
+	err := ValidateStruct(&c,
+		Field(&c.Host, Default("localhost"), Required),
+		Field(&c.Port, Default(8080), Min(1), Max(1024)),
+		Field(&c.Timeout, Default(time.Second)),
+		Field(&c.Mode, Default("safe"), In("safe")),
+	)
+	assertError(t, "mode: must be a valid value; port: must be no greater than 1024.", err, "t1")
+	assert.Equal(t, "localhost", c.Host)
+	assert.Equal(t, 8080, c.Port)
+	if assert.NotNil(t, c.Timeout) {
+		assert.Equal(t, time.Second, *c.Timeout)
+	}
+	assert.Equal(t, "fast", c.Mode)
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var _ Rule = (*DigitsRule)(nil)
+
+var (
+	// ErrDecimalInvalid is the error that returns when a string is not a decimal number.
+	ErrDecimalInvalid = NewError("validation_decimal_invalid", "must be a decimal number")
+	// ErrMinDigitsInvalid is the error that returns when a number has too few integer digits.
+	ErrMinDigitsInvalid = NewError("validation_min_digits_invalid", "must have at least {{.digits}} digits")
+	// ErrMaxDigitsInvalid is the error that returns when a number has too many integer digits.
+	ErrMaxDigitsInvalid = NewError("validation_max_digits_invalid", "must have no more than {{.digits}} digits")
+	// ErrDecimalPrecisionInvalid is the error that returns when a number has too many decimal places.
+	ErrDecimalPrecisionInvalid = NewError("validation_decimal_precision_invalid", "must have no more than {{.precision}} decimal places")
+)
+
+// MinDigits returns a validation rule that checks if the integer part of a number has at least n digits.
+// The value can be a decimal string (e.g. "-0012.50"), a byte slice, or an integer or float.
+// The digits of strings are counted as written, so leading zeros are counted, e.g. "0012" has 4 digits.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func MinDigits(n int) DigitsRule {
+	return DigitsRule{kind: "min_digits", n: n, err: ErrMinDigitsInvalid}
+}
+
+// MaxDigits returns a validation rule that checks if the integer part of a number has no more than n digits.
+// The value is handled in the same way as MinDigits.
+func MaxDigits(n int) DigitsRule {
+	return DigitsRule{kind: "max_digits", n: n, err: ErrMaxDigitsInvalid}
+}
+
+// DecimalPrecision returns a validation rule that checks if a number has no more than n decimal places,
+// e.g. DecimalPrecision(2) for money amounts. The decimal places of strings are counted as written,
+// so "1.50" has 2 decimal places. Floats are formatted with the fewest digits that represent them exactly,
+// so 1.5 has 1 decimal place, while the result of 0.1+0.2 has 17.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func DecimalPrecision(n int) DigitsRule {
+	return DigitsRule{kind: "decimal_precision", n: n, err: ErrDecimalPrecisionInvalid}
+}
+
+// DigitsRule is a validation rule that checks the number of digits of a number.
+type DigitsRule struct {
+	kind string
+	n    int
+	err  Error
+}
+
+// Error sets the error message for the rule.
+func (r DigitsRule) Error(message string) DigitsRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r DigitsRule) ErrorObject(err Error) DigitsRule {
+	r.err = err
+	return r
+}
+
+// Validate checks if the number of digits of the given value is valid.
+func (r DigitsRule) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	intDigits, fracDigits, err := countDigits(value, opts)
+	if err != nil {
+		return err
+	}
+
+	switch r.kind {
+	case "min_digits":
+		if intDigits >= r.n {
+			return nil
+		}
+	case "max_digits":
+		if intDigits <= r.n {
+			return nil
+		}
+	default:
+		if fracDigits <= r.n {
+			return nil
+		}
+		return r.err.SetParams(map[string]interface{}{"precision": r.n})
+	}
+	return r.err.SetParams(map[string]interface{}{"digits": r.n})
+}
+
+// Metadata returns the description of the rule.
+// The kind is "min_digits" or "max_digits" with the param "digits",
+// or "decimal_precision" with the param "precision".
+func (r DigitsRule) Metadata() RuleInfo {
+	param := "digits"
+	if r.kind == "decimal_precision" {
+		param = "precision"
+	}
+	return RuleInfo{
+		Kind:   r.kind,
+		Params: map[string]interface{}{param: r.n},
+	}
+}
+
+// countDigits returns the number of digits in the integer part and the fractional part of a number.
+func countDigits(value interface{}, opts Options) (int, int, error) {
+	var s string
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		s = strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, 0, ErrDecimalInvalid
+		}
+		s = strconv.FormatFloat(f, 'f', -1, rv.Type().Bits())
+	default:
+		str, err := stringWithOptions(value, opts)
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return 0, 0, err
+		} else if err != nil {
+			return 0, 0, errors.New("must be either a number, a string or byte slice")
+		}
+		s = str
+	}
+
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	intPart, fracPart, hasPoint := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) || hasPoint && fracPart == "" {
+		return 0, 0, ErrDecimalInvalid
+	}
+	return len(intPart), len(fracPart), nil
+}
+
+// isDigits reports whether s consists of ASCII digits only.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigitsRules(t *testing.T) {
+	var nilString *string
+	amount := "12.345"
+	a, b := 0.1, 0.2
+
+	tests := []struct {
+		tag   string
+		rule  DigitsRule
+		value interface{}
+		err   string
+	}{
+		{"t1", MinDigits(2), "", ""},
+		{"t2", MinDigits(2), nilString, ""},
+		{"t3", MinDigits(2), "12", ""},
+		{"t4", MinDigits(2), "1", "must have at least 2 digits"},
+		{"t5", MinDigits(4), "0012", ""},
+		{"t6", MinDigits(2), "-12.5", ""},
+		{"t7", MinDigits(2), 9, "must have at least 2 digits"},
+		{"t8", MinDigits(2), uint(10), ""},
+		{"t9", MaxDigits(3), "1234", "must have no more than 3 digits"},
+		{"t10", MaxDigits(3), "+123.4567", ""},
+		{"t11", MaxDigits(3), 1234.5, "must have no more than 3 digits"},
+		{"t12", MaxDigits(3), []byte("999"), ""},
+		{"t13", MaxDigits(0), ".5", ""},
+		{"t14", DecimalPrecision(2), "12.34", ""},
+		{"t15", DecimalPrecision(2), "12.340", "must have no more than 2 decimal places"},
+		{"t16", DecimalPrecision(2), &amount, "must have no more than 2 decimal places"},
+		{"t17", DecimalPrecision(2), 12.5, ""},
+		{"t18", DecimalPrecision(2), float32(0.25), ""},
+		{"t19", DecimalPrecision(2), a + b, "must have no more than 2 decimal places"},
+		{"t20", DecimalPrecision(0), 100, ""},
+		{"t21", DecimalPrecision(2), "abc", "must be a decimal number"},
+		{"t22", DecimalPrecision(2), "1.", "must be a decimal number"},
+		{"t23", DecimalPrecision(2), "-", "must be a decimal number"},
+		{"t24", DecimalPrecision(2), "1e3", "must be a decimal number"},
+		{"t25", DecimalPrecision(2), "--1", "must be a decimal number"},
+		{"t26", DecimalPrecision(2), math.Inf(1), "must be a decimal number"},
+		{"t27", DecimalPrecision(2), true, "must be either a number, a string or byte slice"},
+		{"t28", MaxDigits(2).Error("too long"), "123", "too long"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := NewError("code", "abc")
+	r := DecimalPrecision(1).ErrorObject(err)
+	assert.True(t, errors.Is(r.Validate(context.Background(), "1.25"), err))
+
+	assert.Equal(t, RuleInfo{Kind: "min_digits", Params: map[string]interface{}{"digits": 2}}, MinDigits(2).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "max_digits", Params: map[string]interface{}{"digits": 3}}, MaxDigits(3).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "decimal_precision", Params: map[string]interface{}{"precision": 2}}, DecimalPrecision(2).Metadata())
+}
@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var _ Rule = (*DiveRule)(nil)
+
+// Dive returns a validation rule that validates each struct element of a slice, array or map with the given field rules.
+// Because the rules are applied to every element, the fields must be specified by name, using NamedField,
+// NamedStructField or Spec, rather than by pointer. For example,
+//
+//	validation.Field(&order.Items, validation.Dive(
+//	    validation.NamedField("SKU", validation.Required),
+//	    validation.NamedField("Quantity", validation.Min(1)),
+//	))
+//
+// The errors are reported by element index or map key, e.g. "items.0.sku" or "addresses.home.street" when flattened.
+// Elements can be structs or pointers to structs; nil pointers are considered valid.
+// An empty iterable is considered valid. Use the Required rule to make sure it is not empty.
+func Dive(fields ...FieldRules) DiveRule {
+	return DiveRule{
+		fields: fields,
+	}
+}
+
+// DiveRule is a validation rule that validates the struct elements of a slice, array or map with field rules.
+type DiveRule struct {
+	fields []FieldRules
+}
+
+// Validate validates each element of the given slice, array or map with the field rules.
+func (r DiveRule) Validate(ctx context.Context, value interface{}) error {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+
+	errs := Errors{}
+	opts := getOpts(ctx)
+	validate := func(key string, elemKey interface{}, index bool, elem reflect.Value) error {
+		ptr, ok := structElemPtr(elem)
+		if !ok {
+			return nil
+		}
+		if err := ValidateStructWithContext(withElement(ctx, key, elemKey), ptr, r.fields...); err != nil {
+			if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+				return err
+			}
+			errs[opts.elementKey(key, elemKey, index)] = err
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validate(strconv.Itoa(i), i, true, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if err := validate(fmt.Sprintf("%v", k.Interface()), k.Interface(), false, v.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+	default:
+		return errors.New("must be an iterable (map, slice or array)")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Metadata returns the description of the rule.
+// The kind is "dive", with the param "fields" holding the field rules of the elements as []FieldRules.
+func (r DiveRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "dive",
+		Params: map[string]interface{}{"fields": r.fields},
+	}
+}
+
+// structElemPtr returns a pointer to the element, which can be validated by ValidateStructWithContext.
+// Non-addressable elements are copied. False is returned if the element is a nil pointer or interface.
+func structElemPtr(elem reflect.Value) (interface{}, bool) {
+	if elem.Kind() == reflect.Interface {
+		if elem.IsNil() {
+			return nil, false
+		}
+		elem = elem.Elem()
+	}
+	switch {
+	case elem.Kind() == reflect.Ptr:
+		if elem.IsNil() {
+			return nil, false
+		}
+		return elem.Interface(), true
+	case elem.CanAddr():
+		return elem.Addr().Interface(), true
+	default:
+		ptr := reflect.New(elem.Type())
+		ptr.Elem().Set(elem)
+		return ptr.Interface(), true
+	}
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type diveItem struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+func TestDive(t *testing.T) {
+	r := Dive(
+		NamedField("SKU", Required),
+		Spec("Quantity", Min(1)),
+	)
+
+	var nilItems *[]diveItem
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{"t1", nil, ""},
+		{"t2", nilItems, ""},
+		{"t3", []diveItem{}, ""},
+		{"t4", []diveItem{{SKU: "a", Quantity: 1}}, ""},
+		{"t5", []diveItem{{SKU: "a", Quantity: 1}, {Quantity: -1}}, "1: (quantity: must be no less than 1; sku: cannot be blank.)."},
+		{"t6", [2]diveItem{{}, {SKU: "a"}}, "0: (sku: cannot be blank.)."},
+		{"t7", &[]diveItem{{}}, "0: (sku: cannot be blank.)."},
+		{"t8", []*diveItem{nil, {}}, "1: (sku: cannot be blank.)."},
+		{"t9", []interface{}{nil, diveItem{}}, "1: (sku: cannot be blank.)."},
+		{"t10", "abc", "must be an iterable (map, slice or array)"},
+		{"t11", map[string]diveItem{}, ""},
+		{"t12", map[string]diveItem{"a": {SKU: "a"}, "b": {}}, "b: (sku: cannot be blank.)."},
+		{"t13", map[int]*diveItem{1: nil, 2: {}}, "2: (sku: cannot be blank.)."},
+	}
+	for _, test := range tests {
+		err := r.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// internal errors abort the validation
+	err := Dive(NamedField("SKU")).Validate(context.Background(), []int{1})
+	assert.True(t, errors.Is(err, ErrStructPointer))
+	_, ok := err.(InternalError)
+	assert.True(t, ok)
+
+	err = Dive(NamedField("Missing")).Validate(context.Background(), []diveItem{{}})
+	assertError(t, "0: missing required field: Missing.", err, "t14")
+}
+
+func TestDive_Struct(t *testing.T) {
+	type order struct {
+		Items []diveItem `json:"items"`
+	}
+	o := order{Items: []diveItem{{SKU: "a", Quantity: 1}, {Quantity: 2}}}
+	err := ValidateStructWithContext(context.Background(), &o,
+		Field(&o.Items, Required, Dive(NamedField("SKU", Required))),
+	)
+	if assert.Error(t, err) {
+		flat := err.(Errors).Flatten()
+		if assert.Len(t, flat, 1) {
+			assert.Equal(t, "items.1.sku", flat[0].Path.String())
+		}
+	}
+
+	type customer struct {
+		Addresses map[string]pathAddress `json:"addresses"`
+	}
+	c := customer{Addresses: map[string]pathAddress{"home": {Street: "main"}, "work": {}}}
+	err = ValidateStructWithContext(context.Background(), &c,
+		Field(&c.Addresses, Dive(NamedField("Street", Required))),
+	)
+	if assert.Error(t, err) {
+		flat := err.(Errors).Flatten()
+		if assert.Len(t, flat, 1) {
+			assert.Equal(t, "addresses.work.street", flat[0].Path.String())
+		}
+	}
+
+	infos, err := Inspect(&o, Field(&o.Items, Dive(NamedField("SKU", Required))))
+	assert.NoError(t, err)
+	assert.Equal(t, []FieldInfo{{Name: "items", Rules: []RuleInfo{{
+		Kind: "dive",
+		Params: map[string]interface{}{"fields": []FieldInfo{
+			{Name: "sku", Rules: []RuleInfo{{Kind: "required"}}},
+		}},
+	}}}}, infos)
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var _ Rule = (*DurationRule)(nil)
+
+var (
+	// ErrDurationInvalid is the error that returns when a string cannot be parsed into a duration.
+	ErrDurationInvalid = NewError("validation_duration_invalid", "must be a valid duration")
+	// ErrDurationOutOfRange is the error that returns when a duration is not within a specified range.
+	ErrDurationOutOfRange = NewError("validation_duration_out_of_range", "must be between {{.min}} and {{.max}}")
+)
+
+// DurationRule is a validation rule that checks if a duration is within a range.
+type DurationRule struct {
+	min, max time.Duration
+	err      Error
+}
+
+// DurationBetween returns a validation rule that checks if a duration is within the range [min, max], bounds included.
+// The value can be a time.Duration or a string that can be parsed by time.ParseDuration, e.g. "1m30s".
+// The bounds are reported in the error params "min" and "max" as human-readable strings, e.g. "1m0s".
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func DurationBetween(min, max time.Duration) DurationRule {
+	return DurationRule{min: min, max: max, err: ErrDurationOutOfRange}
+}
+
+// Error sets the error message for the rule.
+func (r DurationRule) Error(message string) DurationRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r DurationRule) ErrorObject(err Error) DurationRule {
+	r.err = err
+	return r
+}
+
+// Validate checks if the given duration is within the range.
+func (r DurationRule) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	d, ok := value.(time.Duration)
+	if !ok {
+		str, err := stringWithOptions(value, opts)
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return err
+		} else if err != nil {
+			return errors.New("must be either a time.Duration or a string")
+		}
+		if d, err = time.ParseDuration(str); err != nil {
+			return ErrDurationInvalid
+		}
+	}
+
+	if d < r.min || d > r.max {
+		return r.err.SetParams(map[string]interface{}{"min": r.min.String(), "max": r.max.String()})
+	}
+	return nil
+}
+
+// Metadata returns the description of the rule.
+// The kind is "duration_between", with the params "min" and "max" holding the bounds as time.Duration.
+func (r DurationRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "duration_between",
+		Params: map[string]interface{}{"min": r.min, "max": r.max},
+	}
+}
@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationBetween(t *testing.T) {
+	var nilDuration *time.Duration
+	timeout := 2 * time.Minute
+
+	tests := []struct {
+		tag   string
+		rule  DurationRule
+		value interface{}
+		err   string
+	}{
+		{"t1", DurationBetween(time.Second, time.Minute), 30 * time.Second, ""},
+		{"t2", DurationBetween(time.Second, time.Minute), time.Second, ""},
+		{"t3", DurationBetween(time.Second, time.Minute), time.Minute, ""},
+		{"t4", DurationBetween(time.Second, time.Minute), time.Millisecond, "must be between 1s and 1m0s"},
+		{"t5", DurationBetween(time.Second, time.Minute), &timeout, "must be between 1s and 1m0s"},
+		{"t6", DurationBetween(time.Second, time.Minute), time.Duration(0), ""},
+		{"t7", DurationBetween(time.Second, time.Minute), nilDuration, ""},
+		{"t8", DurationBetween(time.Second, time.Minute), "1m30s", "must be between 1s and 1m0s"},
+		{"t9", DurationBetween(time.Second, time.Minute), "500ms", "must be between 1s and 1m0s"},
+		{"t10", DurationBetween(time.Second, time.Minute), "45s", ""},
+		{"t11", DurationBetween(time.Second, time.Minute), "", ""},
+		{"t12", DurationBetween(time.Second, time.Minute), "10 seconds", "must be a valid duration"},
+		{"t13", DurationBetween(time.Second, time.Minute), 10, "must be either a time.Duration or a string"},
+		{"t14", DurationBetween(time.Second, time.Minute).Error("bad timeout"), "2h", "bad timeout"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := NewError("code", "abc")
+	r := DurationBetween(0, time.Second).ErrorObject(err)
+	assert.True(t, errors.Is(r.Validate(context.Background(), time.Hour), err))
+
+	assert.Equal(t, RuleInfo{Kind: "duration_between", Params: map[string]interface{}{"min": time.Duration(0), "max": time.Second}},
+		DurationBetween(0, time.Second).Metadata())
+}
@@ -24,27 +24,66 @@ func Each(rules ...Rule) EachRule {
 
 // EachRule is a validation rule that validates elements in a map/slice/array using the specified list of rules.
 type EachRule struct {
-	rules []Rule
+	rules       []Rule
+	nilElements nilElementPolicy
+}
+
+// nilElementPolicy specifies how EachRule handles nil pointer and nil interface elements.
+type nilElementPolicy int
+
+const (
+	// validateNilElements validates nil elements with the rules, which skip nil values except Required and NotNil.
+	validateNilElements nilElementPolicy = iota
+	skipNilElements
+	rejectNilElements
+)
+
+// SkipNil makes the rule skip nil pointer and nil interface elements without validating them,
+// so that even Required and NotNil do not reject them.
+func (r EachRule) SkipNil() EachRule {
+	r.nilElements = skipNilElements
+	return r
+}
+
+// RequireElements makes the rule reject nil pointer and nil interface elements with ErrNotNilRequired,
+// regardless of the element rules.
+func (r EachRule) RequireElements() EachRule {
+	r.nilElements = rejectNilElements
+	return r
 }
 
 // Validate loops through the given iterable and calls the Ozzo Validate() method for each value.
+// An internal error returned for an element is returned as is.
 func (r EachRule) Validate(ctx context.Context, value interface{}) error {
 	errs := Errors{}
+	opts := getOpts(ctx)
 
 	v := reflect.ValueOf(value)
+	ctx, err := r.prefetch(ctx, v)
+	if err != nil {
+		return err
+	}
 	switch v.Kind() {
 	case reflect.Map:
 		for _, k := range v.MapKeys() {
 			val := r.getInterface(v.MapIndex(k))
-			if err := ValidateWithContext(ctx, val, r.rules...); err != nil {
-				errs[r.getString(k)] = err
+			key := r.getString(k)
+			if err := r.validateElement(withElement(ctx, key, k.Interface()), val); err != nil {
+				if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+					return err
+				}
+				errs[opts.elementKey(key, k.Interface(), false)] = err
 			}
 		}
 	case reflect.Slice, reflect.Array:
 		for i := 0; i < v.Len(); i++ {
 			val := r.getInterface(v.Index(i))
-			if err := ValidateWithContext(ctx, val, r.rules...); err != nil {
-				errs[strconv.Itoa(i)] = err
+			key := strconv.Itoa(i)
+			if err := r.validateElement(withElement(ctx, key, i), val); err != nil {
+				if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+					return err
+				}
+				errs[opts.elementKey(key, i, true)] = err
 			}
 		}
 	default:
@@ -57,6 +96,69 @@ func (r EachRule) Validate(ctx context.Context, value interface{}) error {
 	return nil
 }
 
+// validateElement validates an element with the rules, unless the element is nil and the rule skips
+// or rejects nil elements.
+func (r EachRule) validateElement(ctx context.Context, value interface{}) error {
+	if rv := reflect.ValueOf(value); !rv.IsValid() || rv.Kind() == reflect.Ptr && rv.IsNil() {
+		switch r.nilElements {
+		case skipNilElements:
+			return nil
+		case rejectNilElements:
+			return ErrNotNilRequired
+		}
+	}
+	return ValidateWithContext(ctx, value, r.rules...)
+}
+
+// prefetch lets the element rules that implement prefetcher, such as UniqueIn with Batch, look up the elements at once.
+func (r EachRule) prefetch(ctx context.Context, v reflect.Value) (context.Context, error) {
+	var values []interface{}
+	for _, rule := range r.rules {
+		p, ok := rule.(prefetcher)
+		if !ok {
+			continue
+		}
+		if values == nil {
+			switch v.Kind() {
+			case reflect.Map:
+				iter := v.MapRange()
+				for iter.Next() {
+					values = append(values, r.getInterface(iter.Value()))
+				}
+			case reflect.Slice, reflect.Array:
+				for i := 0; i < v.Len(); i++ {
+					values = append(values, r.getInterface(v.Index(i)))
+				}
+			}
+		}
+		if len(values) == 0 {
+			return ctx, nil
+		}
+		var err error
+		if ctx, err = p.prefetch(ctx, values); err != nil {
+			return ctx, err
+		}
+	}
+	return ctx, nil
+}
+
+// Metadata returns the description of the rule.
+// The kind is "each", with the param "rules" holding the element rules as []Rule.
+// The param "skip_nil" or "require_elements" is set to true by SkipNil or RequireElements.
+func (r EachRule) Metadata() RuleInfo {
+	params := map[string]interface{}{"rules": r.rules}
+	switch r.nilElements {
+	case skipNilElements:
+		params["skip_nil"] = true
+	case rejectNilElements:
+		params["require_elements"] = true
+	}
+	return RuleInfo{
+		Kind:   "each",
+		Params: params,
+	}
+}
+
 func (r EachRule) getInterface(value reflect.Value) interface{} {
 	switch value.Kind() {
 	case reflect.Ptr, reflect.Interface:
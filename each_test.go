@@ -3,8 +3,11 @@ package validation
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestEach(t *testing.T) {
@@ -40,6 +43,31 @@ func TestEach(t *testing.T) {
 	}
 }
 
+func TestEach_NilElements(t *testing.T) {
+	var a *int
+	one := 1
+
+	tests := []struct {
+		tag   string
+		rule  EachRule
+		value interface{}
+		err   string
+	}{
+		{"t1", Each(Min(1)), []*int{nil, &one}, ""},
+		{"t2", Each(Required), []*int{nil, &one}, "0: cannot be blank."},
+		{"t3", Each(Required).SkipNil(), []*int{nil, &one}, ""},
+		{"t4", Each(Min(2)).SkipNil(), []*int{nil, &one}, "1: must be no less than 2."},
+		{"t5", Each(Min(1)).RequireElements(), []*int{nil, &one}, "0: is required."},
+		{"t6", Each().RequireElements(), []interface{}{nil, a, 0}, "0: is required; 1: is required."},
+		{"t7", Each(Min(1)).RequireElements(), map[string]*int{"a": nil, "b": &one}, "a: is required."},
+		{"t8", Each(Required).RequireElements().SkipNil(), map[string]*int{"a": nil}, ""},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
 func TestEachWithContext(t *testing.T) {
 	rule := Each(By(func(ctx context.Context, value interface{}) error {
 		if !strings.Contains(value.(string), ctx.Value(contains).(string)) {
@@ -84,3 +112,54 @@ func TestEachAndBy(t *testing.T) {
 		t.Fatal("slice of pointers does not get passed to `By` function by ref")
 	}
 }
+
+func TestEach_ElementKey(t *testing.T) {
+	bracket := func(key interface{}, index bool) string {
+		return fmt.Sprintf("[%v]", key)
+	}
+	tests := []struct {
+		tag   string
+		f     ElementKeyFunc
+		value interface{}
+		err   string
+	}{
+		{"t1", nil, []string{"a", ""}, "1: cannot be blank."},
+		{"t2", DefaultElementKey, []string{"a", ""}, "1: cannot be blank."},
+		{"t3", OneBasedElementKey, []string{"a", ""}, "2: cannot be blank."},
+		{"t4", OneBasedElementKey, map[int]string{1: ""}, "1: cannot be blank."},
+		{"t5", bracket, []string{"", "a"}, "[0]: cannot be blank."},
+		{"t6", bracket, map[string]string{"k": ""}, "[k]: cannot be blank."},
+	}
+	for _, test := range tests {
+		ctx := WithOptions(context.Background(), WithElementKeyFunc(test.f))
+		err := ValidateWithContext(ctx, test.value, Each(Required))
+		assertError(t, test.err, err, test.tag)
+	}
+
+	var path string
+	ctx := WithOptions(context.Background(), WithElementKeyFunc(OneBasedElementKey))
+	err := ValidateWithContext(ctx, []string{"a"}, Each(By(func(ctx context.Context, _ interface{}) error {
+		path = FieldPath(ctx).String()
+		return nil
+	})))
+	assert.NoError(t, err)
+	assert.Equal(t, "0", path)
+
+	err = ValidateWithContext(ctx, []ValidatableString123{"123", "abc"})
+	assertError(t, "2: error 123.", err, "validatable")
+
+	// the present fields of a partial update are matched by the bare indexes
+	type item struct {
+		Qty int `json:"qty"`
+	}
+	type order struct {
+		Items []item `json:"items"`
+	}
+	o := order{Items: []item{{Qty: -1}}}
+	ctx = WithOptions(ctx, WithPartial(map[string]bool{"items": true, "items.0": true, "items.0.qty": true}))
+	err = ValidateStructWithContext(ctx, &o, Field(&o.Items, Each(By(func(ctx context.Context, v interface{}) error {
+		it := v.(item)
+		return ValidateStructWithContext(ctx, &it, Field(&it.Qty, Min(0)))
+	}))))
+	assertError(t, "items: (1: (qty: must be no less than 0.).).", err, "partial")
+}
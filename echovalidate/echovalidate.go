@@ -0,0 +1,33 @@
+// Package echovalidate provides a helper for decoding and validating the request bodies in Echo handlers.
+//
+// It is a separate module so that the validation package does not depend on Echo.
+package echovalidate
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/rockcookies/go-validation"
+	"github.com/rockcookies/go-validation/httpvalidate"
+)
+
+// Bind decodes and validates the JSON request body like httpvalidate.DecodeAndValidate.
+// If it fails, an *echo.HTTPError is returned with the status code returned by httpvalidate.ErrorStatus,
+// the message built by httpvalidate.DefaultErrorEnvelope and the original error as the internal error, so that
+// Echo's HTTP error handler renders it as JSON. For example,
+//
+//	var req CreateUser
+//	if err := echovalidate.Bind(c, &req, validation.Field(&req.Name, validation.Required)); err != nil {
+//	    return err
+//	}
+func Bind(c echo.Context, dst any, fields ...validation.FieldRules) error {
+	return BindWith(httpvalidate.Binder{}, c, dst, fields...)
+}
+
+// BindWith decodes and validates the JSON request body like Bind, building the message of the *echo.HTTPError
+// with the envelope of the Binder b.
+func BindWith(b httpvalidate.Binder, c echo.Context, dst any, fields ...validation.FieldRules) error {
+	if err := httpvalidate.DecodeAndValidate(c.Request(), dst, fields...); err != nil {
+		status := httpvalidate.ErrorStatus(err)
+		return echo.NewHTTPError(status, b.ErrorBody(status, err)).SetInternal(err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package echovalidate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rockcookies/go-validation"
+	"github.com/rockcookies/go-validation/httpvalidate"
+	"github.com/stretchr/testify/assert"
+)
+
+type createUser struct {
+	Name string `json:"name"`
+}
+
+func TestBind(t *testing.T) {
+	e := echo.New()
+	e.POST("/users", func(c echo.Context) error {
+		var req createUser
+		if err := Bind(c, &req, validation.Field(&req.Name, validation.Required)); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, req.Name)
+	})
+
+	tests := []struct {
+		tag    string
+		body   string
+		status int
+		resp   string
+	}{
+		{"t1", `{"name":"john"}`, http.StatusOK, "john"},
+		{"t2", `{}`, http.StatusUnprocessableEntity, `{"message":"validation failed","errors":{"name":"cannot be blank"}}`},
+		{"t3", `{`, http.StatusBadRequest, `{"message":"invalid request body: unexpected EOF"}`},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		e.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(test.body)))
+		assert.Equal(t, test.status, w.Code, test.tag)
+		if test.status == http.StatusOK {
+			assert.Equal(t, test.resp, w.Body.String(), test.tag)
+		} else {
+			assert.JSONEq(t, test.resp, w.Body.String(), test.tag)
+		}
+	}
+}
+
+func TestBindWith(t *testing.T) {
+	b := httpvalidate.Binder{Envelope: func(status int, err error) any { return map[string]any{"code": status} }}
+	r := echo.New()
+	r.POST("/users", func(c echo.Context) error {
+		var req createUser
+		if err := BindWith(b, c, &req, validation.Field(&req.Name, validation.Required)); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, req.Name)
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"code":422}`, w.Body.String())
+}
@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ Rule = (*EnumRule[int])(nil)
+
+// ErrEnumInvalid is the error that returns when a value is not a valid enum value.
+var ErrEnumInvalid = NewError("validation_enum_invalid", "must be a valid value")
+
+// Enum is the constraint of the enum types supported by EnumOf, i.e. the types based on integers and strings,
+// such as the types of Go constants declared with iota and the enum types generated for protobuf.
+type Enum interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~string
+}
+
+// EnumOf returns a validation rule that checks if a value of the enum type T is one of the valid values,
+// which are typically the declared constants of the type, for example,
+//
+//	validation.Field(&o.Status, validation.EnumOf(StatusPending, StatusPaid, StatusShipped))
+//
+// Unlike In, the value must be of type T, so that the rule cannot be applied to a field of another type by mistake.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func EnumOf[T Enum](valid ...T) EnumRule[T] {
+	values := make(map[T]struct{}, len(valid))
+	for _, v := range valid {
+		values[v] = struct{}{}
+	}
+	return EnumRule[T]{
+		isValid: func(v T) bool {
+			_, ok := values[v]
+			return ok
+		},
+		values: valid,
+		err:    ErrEnumInvalid,
+	}
+}
+
+// EnumFunc returns a validation rule that checks if a value of type T is valid with the given function,
+// typically the IsValid method of an enum type, such as those generated by enumer or stringer-based tools,
+// so that the canonical definition of the enum is used rather than a duplicated list of values:
+//
+//	validation.Field(&o.Status, validation.EnumFunc(Status.IsValid))
+//
+// The value must be of type T. An empty value is considered valid. Use the Required rule to make sure
+// a value is not empty.
+func EnumFunc[T any](isValid func(T) bool) EnumRule[T] {
+	return EnumRule[T]{
+		isValid: isValid,
+		err:     ErrEnumInvalid,
+	}
+}
+
+// EnumRule is a validation rule that checks if a value is a valid value of an enum type.
+type EnumRule[T any] struct {
+	isValid func(T) bool
+	values  []T
+	err     Error
+}
+
+// Validate checks if the given value is valid or not.
+func (r EnumRule[T]) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	v, ok := value.(T)
+	if !ok {
+		var zero T
+		return fmt.Errorf("must be a value of type %T", zero)
+	}
+	if !r.isValid(v) {
+		return r.err
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r EnumRule[T]) Error(message string) EnumRule[T] {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r EnumRule[T]) ErrorObject(err Error) EnumRule[T] {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "enum", with the param "values" holding the valid values as []interface{} if the rule
+// is created by EnumOf. The valid values of a rule created by EnumFunc are unknown.
+func (r EnumRule[T]) Metadata() RuleInfo {
+	if r.values == nil {
+		return RuleInfo{Kind: "enum"}
+	}
+	return RuleInfo{
+		Kind:   "enum",
+		Params: map[string]interface{}{"values": toInterfaces(r.values)},
+	}
+}
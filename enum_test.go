@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStatus int
+
+const (
+	testStatusUnknown testStatus = iota
+	testStatusActive
+	testStatusInactive
+)
+
+func (s testStatus) IsValid() bool {
+	return s >= testStatusActive && s <= testStatusInactive
+}
+
+type testColor string
+
+func TestEnumOf(t *testing.T) {
+	active := testStatusActive
+	var nilStatus *testStatus
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{"t1", testStatusActive, ""},
+		{"t2", testStatusInactive, ""},
+		{"t3", testStatusUnknown, ""},
+		{"t4", testStatus(5), "must be a valid value"},
+		{"t5", &active, ""},
+		{"t6", nilStatus, ""},
+		{"t7", 1, "must be a value of type validation.testStatus"},
+	}
+
+	for _, test := range tests {
+		r := EnumOf(testStatusActive, testStatusInactive)
+		err := r.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	r := EnumOf[testColor]("red", "green")
+	assert.NoError(t, r.Validate(context.Background(), testColor("red")))
+	assertError(t, "must be a valid value", r.Validate(context.Background(), testColor("blue")), "string")
+}
+
+func TestEnumFunc(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{"t1", testStatusActive, ""},
+		{"t2", testStatusUnknown, ""},
+		{"t3", testStatus(5), "must be a valid value"},
+		{"t4", "active", "must be a value of type validation.testStatus"},
+	}
+
+	for _, test := range tests {
+		r := EnumFunc(testStatus.IsValid)
+		err := r.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestEnumRule_Error(t *testing.T) {
+	r := EnumOf(testStatusActive).Error("123")
+	assert.Equal(t, "123", r.err.Message())
+	assert.Equal(t, ErrEnumInvalid.Code(), r.err.Code())
+
+	err := NewError("code", "abc")
+	r = r.ErrorObject(err)
+	assert.Equal(t, err, r.err)
+}
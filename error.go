@@ -7,7 +7,6 @@ package validation
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"sort"
 	"strings"
 	"text/template"
@@ -57,6 +56,20 @@ func (e internalError) InternalError() error {
 	return e.error
 }
 
+// Unwrap returns the actual error that it wraps around, so that errors.Is and errors.As can inspect it.
+func (e internalError) Unwrap() error {
+	return e.error
+}
+
+// setErrorCode returns an Error with the code, keeping the message and params of e.
+// The Error interface has no method to set the code, so an ErrorObject is returned unless e is one.
+func setErrorCode(e Error, code string) Error {
+	if eo, ok := e.(ErrorObject); ok {
+		return eo.SetCode(code)
+	}
+	return NewError(code, e.Message()).SetParams(e.Params())
+}
+
 // SetCode set the error's translation code.
 func (e ErrorObject) SetCode(code string) Error {
 	e.code = code
@@ -113,12 +126,122 @@ func (e ErrorObject) Error() string {
 	return res.String()
 }
 
-// Error returns the error string of Errors.
+// Is reports whether the error has the same code as target, so that errors.Is matches validation errors
+// by identity regardless of their message or params, e.g. errors.Is(err, validation.ErrRequired).
+// Errors without a code are never matched this way.
+func (e ErrorObject) Is(target error) bool {
+	t, ok := target.(Error)
+	return ok && e.code != "" && e.code == t.Code()
+}
+
+// DefaultErrorFormat is the format used by Errors.Error. It may be changed at program start to render
+// all validation errors differently, e.g. one error per line in a CLI:
+//
+//	validation.DefaultErrorFormat = validation.NewErrorFormat().Indent("  ")
+var DefaultErrorFormat = NewErrorFormat()
+
+// Error returns the error string of Errors in DefaultErrorFormat. The errors are sorted by key,
+// so the output is deterministic.
 func (es Errors) Error() string {
+	return es.Format(DefaultErrorFormat)
+}
+
+// ErrorFormat specifies how Errors.Format renders errors. Use NewErrorFormat to get the format of
+// Errors.Error and change it with the builder methods, e.g.
+//
+//	err.Format(validation.NewErrorFormat().Flatten().Separator("\n").Terminator(""))
+type ErrorFormat struct {
+	separator    string
+	keySeparator string
+	terminator   string
+	indent       string
+	flatten      bool
+	multiline    bool
+}
+
+// NewErrorFormat returns the default format of Errors.Error, e.g. "address: (street: cannot be blank.); name: cannot be blank."
+func NewErrorFormat() ErrorFormat {
+	return ErrorFormat{
+		separator:    "; ",
+		keySeparator: ": ",
+		terminator:   ".",
+	}
+}
+
+// Separator sets the string written between errors, "; " by default.
+func (f ErrorFormat) Separator(separator string) ErrorFormat {
+	f.separator = separator
+	return f
+}
+
+// KeySeparator sets the string written between a key and its error, ": " by default.
+func (f ErrorFormat) KeySeparator(separator string) ErrorFormat {
+	f.keySeparator = separator
+	return f
+}
+
+// Terminator sets the string written after the errors, "." by default.
+// When errors are not flattened, it also ends the errors nested in parentheses.
+func (f ErrorFormat) Terminator(terminator string) ErrorFormat {
+	f.terminator = terminator
+	return f
+}
+
+// Flatten writes each leaf error with its full path instead of nesting errors in parentheses,
+// e.g. "address.street: cannot be blank; name: cannot be blank.".
+func (f ErrorFormat) Flatten() ErrorFormat {
+	f.flatten = true
+	return f
+}
+
+// Compact removes the spaces and the terminator, e.g. "address:(street:cannot be blank);name:cannot be blank".
+func (f ErrorFormat) Compact() ErrorFormat {
+	f.separator = ";"
+	f.keySeparator = ":"
+	f.terminator = ""
+	return f
+}
+
+// Indent writes each error on its own line and the errors nested in Errors below their key,
+// indented by one more level of indent, e.g.
+//
+//	address:
+//	  street: cannot be blank
+//	name: cannot be blank
+//
+// The separator is set to a newline and the terminator is removed. Combined with Flatten,
+// the leaf errors are written one per line with their full paths.
+func (f ErrorFormat) Indent(indent string) ErrorFormat {
+	f.indent = indent
+	f.multiline = true
+	f.separator = "\n"
+	f.terminator = ""
+	return f
+}
+
+// Format returns the error string of Errors in the given format. The errors are sorted by key.
+func (es Errors) Format(f ErrorFormat) string {
 	if len(es) == 0 {
 		return ""
 	}
 
+	var s strings.Builder
+	if f.flatten {
+		for i, fe := range es.Flatten() {
+			if i > 0 {
+				s.WriteString(f.separator)
+			}
+			s.WriteString(fe.Path.String() + f.keySeparator + fe.Err.Error())
+		}
+		s.WriteString(f.terminator)
+		return s.String()
+	}
+	if f.multiline {
+		es.writeIndented(&s, f, "")
+		s.WriteString(f.terminator)
+		return s.String()
+	}
+
 	keys := make([]string, len(es))
 	i := 0
 	for key := range es {
@@ -127,21 +250,64 @@ func (es Errors) Error() string {
 	}
 	sort.Strings(keys)
 
-	var s strings.Builder
 	for i, key := range keys {
 		if i > 0 {
-			s.WriteString("; ")
+			s.WriteString(f.separator)
 		}
 		if errs, ok := es[key].(Errors); ok {
-			_, _ = fmt.Fprintf(&s, "%v: (%v)", key, errs)
+			s.WriteString(key + f.keySeparator + "(" + errs.Format(f) + ")")
 		} else {
-			_, _ = fmt.Fprintf(&s, "%v: %v", key, es[key].Error())
+			s.WriteString(key + f.keySeparator + es[key].Error())
 		}
 	}
-	s.WriteString(".")
+	s.WriteString(f.terminator)
 	return s.String()
 }
 
+func (es Errors) writeIndented(s *strings.Builder, f ErrorFormat, prefix string) {
+	keys := make([]string, 0, len(es))
+	for key := range es {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if s.Len() > 0 {
+			s.WriteString(f.separator)
+		}
+		if errs, ok := es[key].(Errors); ok {
+			s.WriteString(prefix + key + strings.TrimRight(f.keySeparator, " "))
+			errs.writeIndented(s, f, prefix+f.indent)
+		} else {
+			s.WriteString(prefix + key + f.keySeparator + es[key].Error())
+		}
+	}
+}
+
+// MarshalText returns the error string of Errors, so that Errors can be used with encoders and loggers
+// that accept encoding.TextMarshaler.
+func (es Errors) MarshalText() ([]byte, error) {
+	return []byte(es.Error()), nil
+}
+
+// Unwrap returns the non-nil errors sorted by key, so that errors.Is and errors.As
+// can look for a particular error in nested Errors.
+func (es Errors) Unwrap() []error {
+	keys := make([]string, 0, len(es))
+	for key, err := range es {
+		if err != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	errs := make([]error, len(keys))
+	for i, key := range keys {
+		errs[i] = es[key]
+	}
+	return errs
+}
+
 // MarshalJSON converts the Errors into a valid JSON.
 func (es Errors) MarshalJSON() ([]byte, error) {
 	errs := map[string]interface{}{}
@@ -169,6 +335,121 @@ func (es Errors) Filter() error {
 	return es
 }
 
+// ErrorPath is the path of a validation error within nested Errors, e.g. ["address", "street"].
+// Each segment is an Errors key, i.e. a struct field name, a map key, or a slice index in decimal form
+// such as ["items", "3", "sku"].
+type ErrorPath []string
+
+// String returns the path segments joined by dots, e.g. "items.3.sku".
+func (p ErrorPath) String() string {
+	return strings.Join(p, ".")
+}
+
+// FieldError is a leaf validation error together with its path within nested Errors.
+type FieldError struct {
+	Path ErrorPath
+	Err  error
+}
+
+// Flatten returns the leaf errors of the (possibly nested) Errors with their paths,
+// sorted by path. Nil errors are ignored.
+func (es Errors) Flatten() []FieldError {
+	return es.flatten(nil, nil)
+}
+
+func (es Errors) flatten(prefix ErrorPath, result []FieldError) []FieldError {
+	keys := make([]string, 0, len(es))
+	for key := range es {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := make(ErrorPath, len(prefix)+1)
+		copy(path, prefix)
+		path[len(prefix)] = key
+
+		switch err := es[key].(type) {
+		case nil:
+		case Errors:
+			result = err.flatten(path, result)
+		default:
+			result = append(result, FieldError{Path: path, Err: err})
+		}
+	}
+	return result
+}
+
+// FilterPrefix returns the errors whose dot-joined path (see ErrorPath) equals the prefix or starts
+// with the prefix followed by a dot. The nested structure is preserved, e.g. the prefix "address"
+// keeps "address.street" and "address.city" but not "address_line". Nil is returned if no error matches.
+func (es Errors) FilterPrefix(prefix string) Errors {
+	if prefix == "" {
+		return es
+	}
+
+	var result Errors
+	for key, err := range es {
+		var matched error
+		switch {
+		case key == prefix || strings.HasPrefix(key, prefix+"."):
+			matched = err
+		case strings.HasPrefix(prefix, key+"."):
+			if nested, ok := err.(Errors); ok {
+				if sub := nested.FilterPrefix(strings.TrimPrefix(prefix, key+".")); sub != nil {
+					matched = sub
+				}
+			}
+		}
+		if matched != nil {
+			if result == nil {
+				result = Errors{}
+			}
+			result[key] = matched
+		}
+	}
+	return result
+}
+
+// First returns the first leaf error in path order. This is useful when only a single
+// human-readable problem should be reported. The boolean result is false if there is no error.
+func (es Errors) First() (FieldError, bool) {
+	keys := make([]string, 0, len(es))
+	for key := range es {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch err := es[key].(type) {
+		case nil:
+		case Errors:
+			if fe, ok := err.First(); ok {
+				fe.Path = append(ErrorPath{key}, fe.Path...)
+				return fe, true
+			}
+		default:
+			return FieldError{Path: ErrorPath{key}, Err: err}, true
+		}
+	}
+	return FieldError{}, false
+}
+
+// Count returns the number of leaf errors, counting nested Errors recursively.
+func (es Errors) Count() int {
+	count := 0
+	for _, err := range es {
+		switch err := err.(type) {
+		case nil:
+		case Errors:
+			count += err.Count()
+		default:
+			count++
+		}
+	}
+	return count
+}
+
 // NewError create new validation error.
 func NewError(code, message string) Error {
 	return ErrorObject{
@@ -0,0 +1,40 @@
+//go:build go1.21
+
+package validation
+
+import (
+	"log/slog"
+	"sort"
+)
+
+var _ slog.LogValuer = Errors(nil)
+
+// LogValue returns the errors as a slog group, so that structured loggers record each field as an attribute
+// rather than a single flattened string. Nested Errors become nested groups, and errors implementing Error
+// become groups with the attributes "code" and "message". For example,
+//
+//	logger.Warn("invalid request", "errors", err)
+//
+// is logged by slog.JSONHandler as {"errors":{"name":{"code":"validation_required","message":"cannot be blank"}}}.
+func (es Errors) LogValue() slog.Value {
+	keys := make([]string, 0, len(es))
+	for key, err := range es {
+		if err != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, len(keys))
+	for i, key := range keys {
+		switch err := es[key].(type) {
+		case Errors:
+			attrs[i] = slog.Attr{Key: key, Value: err.LogValue()}
+		case Error:
+			attrs[i] = slog.Group(key, slog.String("code", err.Code()), slog.String("message", err.Error()))
+		default:
+			attrs[i] = slog.String(key, err.Error())
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
@@ -0,0 +1,42 @@
+//go:build go1.21
+
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors_LogValue(t *testing.T) {
+	errs := Errors{
+		"name": ErrRequired,
+		"address": Errors{
+			"zip":  errors.New("must be 5 digits"),
+			"city": nil,
+		},
+		"email": nil,
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Warn("invalid request", "errors", errs)
+
+	assert.JSONEq(t, `{
+		"msg": "invalid request",
+		"errors": {
+			"address": {"zip": "must be 5 digits"},
+			"name": {"code": "validation_required", "message": "cannot be blank"}
+		}
+	}`, buf.String())
+}
@@ -5,7 +5,9 @@
 package validation
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -35,6 +37,48 @@ func TestErrors_Error(t *testing.T) {
 	assert.Equal(t, "", errs.Error())
 }
 
+func TestErrors_Format(t *testing.T) {
+	errs := Errors{
+		"name": errors.New("cannot be blank"),
+		"address": Errors{
+			"zip":    errors.New("must be 5 digits"),
+			"street": errors.New("cannot be blank"),
+		},
+	}
+
+	tests := []struct {
+		tag    string
+		format ErrorFormat
+		out    string
+	}{
+		{"t1", NewErrorFormat(), "address: (street: cannot be blank; zip: must be 5 digits.); name: cannot be blank."},
+		{"t2", NewErrorFormat().Separator(", ").Terminator(""), "address: (street: cannot be blank, zip: must be 5 digits), name: cannot be blank"},
+		{"t3", NewErrorFormat().KeySeparator(" "), "address (street cannot be blank; zip must be 5 digits.); name cannot be blank."},
+		{"t4", NewErrorFormat().Flatten(), "address.street: cannot be blank; address.zip: must be 5 digits; name: cannot be blank."},
+		{"t5", NewErrorFormat().Flatten().Separator("\n").Terminator(""), "address.street: cannot be blank\naddress.zip: must be 5 digits\nname: cannot be blank"},
+		{"t6", NewErrorFormat().Compact(), "address:(street:cannot be blank;zip:must be 5 digits);name:cannot be blank"},
+		{"t7", NewErrorFormat().Compact().Flatten(), "address.street:cannot be blank;address.zip:must be 5 digits;name:cannot be blank"},
+		{"t8", NewErrorFormat().Indent("  "), "address:\n  street: cannot be blank\n  zip: must be 5 digits\nname: cannot be blank"},
+		{"t9", NewErrorFormat().Indent("\t").Terminator("."), "address:\n\tstreet: cannot be blank\n\tzip: must be 5 digits\nname: cannot be blank."},
+		{"t10", NewErrorFormat().Indent("  ").Flatten(), "address.street: cannot be blank\naddress.zip: must be 5 digits\nname: cannot be blank"},
+		{"t11", NewErrorFormat().Indent("  ").KeySeparator(" - "), "address -\n  street - cannot be blank\n  zip - must be 5 digits\nname - cannot be blank"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.out, errs.Format(test.format), test.tag)
+	}
+	assert.Equal(t, errs.Error(), errs.Format(NewErrorFormat()))
+	assert.Equal(t, "", Errors{}.Format(NewErrorFormat().Flatten()))
+	assert.Equal(t, "", Errors{}.Format(NewErrorFormat().Indent("  ")))
+
+	defer func(f ErrorFormat) { DefaultErrorFormat = f }(DefaultErrorFormat)
+	DefaultErrorFormat = NewErrorFormat().Compact()
+	assert.Equal(t, "address:(street:cannot be blank;zip:must be 5 digits);name:cannot be blank", errs.Error())
+
+	text, err := errs.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, errs.Error(), string(text))
+}
+
 func TestErrors_MarshalMessage(t *testing.T) {
 	errs := Errors{
 		"A": errors.New("A1"),
@@ -70,6 +114,124 @@ func TestErrors_Filter(t *testing.T) {
 	assert.Nil(t, errs.Filter())
 }
 
+func TestErrors_Flatten(t *testing.T) {
+	errs := Errors{
+		"name": ErrRequired,
+		"address": Errors{
+			"street": errors.New("street1"),
+			"city":   nil,
+		},
+		"items": Errors{
+			"3": Errors{"sku": errors.New("sku1")},
+			"0": errors.New("item0"),
+		},
+	}
+
+	flat := errs.Flatten()
+	assert.Equal(t, []FieldError{
+		{Path: ErrorPath{"address", "street"}, Err: errors.New("street1")},
+		{Path: ErrorPath{"items", "0"}, Err: errors.New("item0")},
+		{Path: ErrorPath{"items", "3", "sku"}, Err: errors.New("sku1")},
+		{Path: ErrorPath{"name"}, Err: ErrRequired},
+	}, flat)
+	assert.Equal(t, "items.3.sku", flat[2].Path.String())
+
+	assert.Empty(t, Errors{}.Flatten())
+}
+
+func TestErrors_FilterPrefix(t *testing.T) {
+	errs := Errors{
+		"name":         ErrRequired,
+		"address":      Errors{"street": ErrRequired, "city": ErrRequired},
+		"address_line": ErrRequired,
+		"items":        Errors{"0": Errors{"sku": ErrRequired}, "1": ErrRequired},
+		"a.b":          ErrRequired,
+	}
+
+	tests := []struct {
+		tag    string
+		prefix string
+		want   Errors
+	}{
+		{"t1", "", errs},
+		{"t2", "name", Errors{"name": ErrRequired}},
+		{"t3", "address", Errors{"address": Errors{"street": ErrRequired, "city": ErrRequired}}},
+		{"t4", "address.street", Errors{"address": Errors{"street": ErrRequired}}},
+		{"t5", "items.0", Errors{"items": Errors{"0": Errors{"sku": ErrRequired}}}},
+		{"t6", "items.0.sku", Errors{"items": Errors{"0": Errors{"sku": ErrRequired}}}},
+		{"t7", "a", Errors{"a.b": ErrRequired}},
+		{"t8", "unknown", nil},
+		{"t9", "name.first", nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.want, errs.FilterPrefix(test.prefix), test.tag)
+	}
+}
+
+func TestErrors_First(t *testing.T) {
+	errs := Errors{
+		"name":    errors.New("name1"),
+		"address": Errors{"street": errors.New("street1"), "city": nil},
+		"age":     nil,
+	}
+	fe, ok := errs.First()
+	assert.True(t, ok)
+	assert.Equal(t, ErrorPath{"address", "street"}, fe.Path)
+	assert.EqualError(t, fe.Err, "street1")
+
+	_, ok = Errors{"a": nil, "b": Errors{}}.First()
+	assert.False(t, ok)
+}
+
+func TestErrors_Count(t *testing.T) {
+	errs := Errors{
+		"name":    ErrRequired,
+		"address": Errors{"street": ErrRequired, "city": nil},
+		"items":   Errors{"0": Errors{"sku": ErrRequired, "qty": ErrRequired}},
+		"age":     nil,
+	}
+	assert.Equal(t, 4, errs.Count())
+	assert.Equal(t, 0, Errors{}.Count())
+}
+
+func TestErrorsIs(t *testing.T) {
+	ctx := context.Background()
+	type model struct {
+		Name  string
+		Email string
+		Items []string
+	}
+	m := model{Items: []string{"a", ""}}
+	err := ValidateStructWithContext(ctx, &m,
+		Field(&m.Name, Required.Error("name please")),
+		Field(&m.Items, Each(Required)),
+	)
+
+	assert.True(t, errors.Is(err, ErrRequired))
+	assert.False(t, errors.Is(err, ErrNilOrNotEmpty))
+	assert.True(t, errors.Is(Required.Error("custom").Validate(ctx, ""), ErrRequired))
+	assert.True(t, errors.Is(ErrLengthOutOfRange.SetParams(map[string]interface{}{"min": 1}), ErrLengthOutOfRange))
+	assert.False(t, errors.Is(NewError("", "abc"), NewError("", "abc")))
+
+	var errs Errors
+	assert.True(t, errors.As(err, &errs))
+	var ve Error
+	if assert.True(t, errors.As(err, &ve)) {
+		assert.Equal(t, "validation_required", ve.Code())
+	}
+
+	cause := errors.New("db down")
+	ierr := NewInternalError(fmt.Errorf("lookup: %w", cause))
+	assert.True(t, errors.Is(ierr, cause))
+	assert.True(t, errors.Is(Errors{"a": nil, "b": ierr}, cause))
+}
+
+func TestErrors_Unwrap(t *testing.T) {
+	errs := Errors{"b": ErrRequired, "a": ErrNil, "c": nil}
+	assert.Equal(t, []error{ErrNil, ErrRequired}, errs.Unwrap())
+}
+
 func TestErrorObject_SetCode(t *testing.T) {
 	err := NewError("A", "msg").(ErrorObject)
 
@@ -0,0 +1,252 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+var _ Rule = (*LookupRule)(nil)
+
+var (
+	// ErrAlreadyExists is the error that returns when a value that must be unique already exists, e.g. in a database.
+	ErrAlreadyExists = NewError("validation_already_exists", "already exists")
+	// ErrReferenceNotFound is the error that returns when a value does not reference an existing record, e.g. in a database.
+	ErrReferenceNotFound = NewError("validation_reference_not_found", "must reference an existing value")
+)
+
+type (
+	// ExistsFunc reports whether a value already exists, e.g. by querying a database.
+	ExistsFunc func(ctx context.Context, value interface{}) (bool, error)
+
+	// BatchExistsFunc reports which of the values already exist with a single lookup, e.g. a query with an IN clause.
+	// The values missing from the returned map are considered not existing.
+	BatchExistsFunc func(ctx context.Context, values []interface{}) (map[interface{}]bool, error)
+)
+
+// UniqueIn returns a validation rule that checks if a value does not already exist, as reported by the lookup
+// function, e.g. to check that an email address is not registered yet:
+//
+//	validation.Field(&u.Email, validation.UniqueIn(func(ctx context.Context, v interface{}) (bool, error) {
+//	    return db.EmailExists(ctx, v.(string))
+//	}))
+//
+// The rule avoids repeating the lookups of the same value:
+//   - concurrent lookups of the same value by the rule, e.g. by ValidateAllParallel, share a single call
+//   - the results are memoized for the request with the WithLookupCache option
+//   - the lookups of the elements validated by Each are done at once if Batch is called on the rule
+//
+// An error returned by the lookup function is returned as an InternalError.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func UniqueIn(exists ExistsFunc) LookupRule {
+	return newLookupRule(exists, false, ErrAlreadyExists)
+}
+
+// ExistsIn returns a validation rule that checks if a value references an existing record, as reported by the lookup
+// function, e.g. to check that an order references an existing customer, with the repository of a service:
+//
+//	validation.Field(&o.CustomerID, validation.ExistsIn(func(ctx context.Context, id interface{}) (bool, error) {
+//	    return s.customers.Exists(ctx, id.(int64))
+//	}))
+//
+// A value that is not found is reported with ErrReferenceNotFound, while an error returned by the lookup function,
+// i.e. a failed lookup, is returned as an InternalError. The lookups are shared, memoized and batched in the same
+// way as UniqueIn. An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func ExistsIn(exists ExistsFunc) LookupRule {
+	return newLookupRule(exists, true, ErrReferenceNotFound)
+}
+
+func newLookupRule(exists ExistsFunc, existing bool, err Error) LookupRule {
+	return LookupRule{
+		exists:   exists,
+		existing: existing,
+		calls:    &lookupCalls{calls: map[interface{}]*lookupCall{}},
+		err:      err,
+	}
+}
+
+// LookupRule is a validation rule that checks if a value exists or not, e.g. in a database.
+type LookupRule struct {
+	exists ExistsFunc
+	batch  BatchExistsFunc
+	// existing tells whether the value must exist, or must not.
+	existing bool
+	// calls holds the lookups in flight, and identifies the rule in the lookup cache.
+	calls *lookupCalls
+	err   Error
+}
+
+// Batch sets the function that looks up the elements validated by Each with the rule at once,
+// instead of calling the lookup function of UniqueIn for every element.
+func (r LookupRule) Batch(exists BatchExistsFunc) LookupRule {
+	r.batch = exists
+	return r
+}
+
+// Error sets the error message for the rule.
+func (r LookupRule) Error(message string) LookupRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r LookupRule) ErrorObject(err Error) LookupRule {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "unique_in" or "exists_in". The param "batch" is true if a batch lookup function is set by Batch.
+func (r LookupRule) Metadata() RuleInfo {
+	kind := "unique_in"
+	if r.existing {
+		kind = "exists_in"
+	}
+	if r.batch != nil {
+		return RuleInfo{Kind: kind, Params: map[string]interface{}{"batch": true}}
+	}
+	return RuleInfo{Kind: kind}
+}
+
+// Validate checks if the given value is valid or not.
+func (r LookupRule) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	cache := lookupCacheFrom(ctx)
+	exists, ok := cache.get(r.calls, value)
+	if !ok {
+		var err error
+		if exists, err = r.calls.do(value, func() (bool, error) { return r.exists(ctx, value) }); err != nil {
+			return NewInternalError(err)
+		}
+		cache.set(r.calls, value, exists)
+	}
+	if exists != r.existing {
+		return r.err
+	}
+	return nil
+}
+
+// prefetch looks up the non-empty elements at once with the batch lookup function, if any, and returns
+// a context holding the results in the lookup cache, which is created if the context has none.
+func (r LookupRule) prefetch(ctx context.Context, values []interface{}) (context.Context, error) {
+	if r.batch == nil {
+		return ctx, nil
+	}
+
+	opts := GetOptions(ctx)
+	cache := lookupCacheFrom(ctx)
+	if cache == nil {
+		cache = &lookupCache{}
+		ctx = WithOptions(ctx, func(o *options) { o.lookupCache = cache })
+	}
+
+	var missing []interface{}
+	for _, v := range values {
+		v, isNil := indirectWithOptions(v, opts)
+		if isNil || isEmptyWithOptions(v, opts) || !isComparable(v) {
+			continue
+		}
+		if _, ok := cache.get(r.calls, v); !ok {
+			missing = append(missing, v)
+		}
+	}
+	if len(missing) == 0 {
+		return ctx, nil
+	}
+
+	existing, err := r.batch(ctx, missing)
+	if err != nil {
+		return ctx, NewInternalError(err)
+	}
+	for _, v := range missing {
+		cache.set(r.calls, v, existing[v])
+	}
+	return ctx, nil
+}
+
+// prefetcher is implemented by the rules that look up the elements validated by Each at once.
+type prefetcher interface {
+	prefetch(ctx context.Context, values []interface{}) (context.Context, error)
+}
+
+// lookupCall is a lookup in flight.
+type lookupCall struct {
+	wg     sync.WaitGroup
+	exists bool
+	err    error
+}
+
+// lookupCalls makes the concurrent lookups of the same value share a single call.
+type lookupCalls struct {
+	mu    sync.Mutex
+	calls map[interface{}]*lookupCall
+}
+
+func (c *lookupCalls) do(value interface{}, lookup func() (bool, error)) (bool, error) {
+	if !isComparable(value) {
+		return lookup()
+	}
+
+	c.mu.Lock()
+	if call, ok := c.calls[value]; ok {
+		c.mu.Unlock()
+		call.wg.Wait()
+		return call.exists, call.err
+	}
+	call := &lookupCall{}
+	call.wg.Add(1)
+	c.calls[value] = call
+	c.mu.Unlock()
+
+	call.exists, call.err = lookup()
+	call.wg.Done()
+
+	c.mu.Lock()
+	delete(c.calls, value)
+	c.mu.Unlock()
+	return call.exists, call.err
+}
+
+// lookupKey identifies a value looked up by a rule.
+type lookupKey struct {
+	calls *lookupCalls
+	value interface{}
+}
+
+// lookupCache memoizes the results of the lookups of the LookupRules, typically for a request, as set by WithLookupCache.
+// A nil cache memoizes nothing.
+type lookupCache struct {
+	results sync.Map
+}
+
+func lookupCacheFrom(ctx context.Context) *lookupCache {
+	return getOpts(ctx).lookupCache
+}
+
+func (c *lookupCache) get(calls *lookupCalls, value interface{}) (bool, bool) {
+	if c == nil || !isComparable(value) {
+		return false, false
+	}
+	exists, ok := c.results.Load(lookupKey{calls, value})
+	if !ok {
+		return false, false
+	}
+	return exists.(bool), true
+}
+
+func (c *lookupCache) set(calls *lookupCalls, value interface{}, exists bool) {
+	if c != nil && isComparable(value) {
+		c.results.Store(lookupKey{calls, value}, exists)
+	}
+}
+
+// isComparable reports whether a value can be used as a map key. The value is checked rather than its type,
+// since a struct or an array of a comparable type may hold a slice or a map in an interface field.
+func isComparable(value interface{}) bool {
+	return value != nil && reflect.ValueOf(value).Comparable()
+}
@@ -0,0 +1,192 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// emailLookup is a fake database of registered email addresses, which counts the lookups.
+type emailLookup struct {
+	emails  map[string]bool
+	lookups int32
+	batches int32
+}
+
+func (l *emailLookup) exists(_ context.Context, v interface{}) (bool, error) {
+	atomic.AddInt32(&l.lookups, 1)
+	if v == "error" {
+		return false, errors.New("db down")
+	}
+	return l.emails[v.(string)], nil
+}
+
+func (l *emailLookup) batch(_ context.Context, values []interface{}) (map[interface{}]bool, error) {
+	atomic.AddInt32(&l.batches, 1)
+	existing := map[interface{}]bool{}
+	for _, v := range values {
+		if l.emails[v.(string)] {
+			existing[v] = true
+		}
+	}
+	return existing, nil
+}
+
+func TestUniqueIn(t *testing.T) {
+	l := &emailLookup{emails: map[string]bool{"a@example.com": true}}
+	r := UniqueIn(l.exists)
+	email := "b@example.com"
+
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{"t1", "b@example.com", ""},
+		{"t2", "a@example.com", "already exists"},
+		{"t3", "", ""},
+		{"t4", nil, ""},
+		{"t5", &email, ""},
+		{"t6", "error", "db down"},
+	}
+	for _, test := range tests {
+		err := r.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	var ie InternalError
+	assert.True(t, errors.As(r.Validate(context.Background(), "error"), &ie))
+}
+
+func TestUniqueIn_LookupCache(t *testing.T) {
+	l := &emailLookup{emails: map[string]bool{"a@example.com": true}}
+	r := UniqueIn(l.exists)
+
+	ctx := WithOptions(context.Background(), WithLookupCache())
+	for i := 0; i < 3; i++ {
+		assert.Error(t, r.Validate(ctx, "a@example.com"))
+		assert.NoError(t, r.Validate(ctx, "b@example.com"))
+	}
+	assert.Equal(t, int32(2), l.lookups)
+
+	// another rule is looked up separately
+	assert.NoError(t, UniqueIn(func(context.Context, interface{}) (bool, error) { return false, nil }).Validate(ctx, "a@example.com"))
+
+	// without the cache, every validation looks up the value
+	assert.NoError(t, r.Validate(context.Background(), "b@example.com"))
+	assert.Equal(t, int32(3), l.lookups)
+
+	// the values holding a slice or a map in an interface field are not cached
+	var lookups int
+	r = UniqueIn(func(context.Context, interface{}) (bool, error) {
+		lookups++
+		return false, nil
+	})
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, r.Validate(ctx, struct{ V interface{} }{V: []int{1}}))
+	}
+	assert.Equal(t, 2, lookups)
+}
+
+func TestUniqueIn_Concurrent(t *testing.T) {
+	release := make(chan struct{})
+	var lookups int32
+	r := UniqueIn(func(context.Context, interface{}) (bool, error) {
+		atomic.AddInt32(&lookups, 1)
+		<-release
+		return true, nil
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = r.Validate(context.Background(), "a@example.com")
+		}(i)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), lookups)
+	for _, err := range errs {
+		assert.Equal(t, ErrAlreadyExists, err)
+	}
+}
+
+func TestUniqueIn_Batch(t *testing.T) {
+	l := &emailLookup{emails: map[string]bool{"a@example.com": true, "c@example.com": true}}
+	r := Each(UniqueIn(l.exists).Batch(l.batch))
+
+	err := r.Validate(context.Background(), []string{"a@example.com", "b@example.com", "", "c@example.com"})
+	assert.EqualError(t, err, "0: already exists; 3: already exists.")
+	assert.Equal(t, int32(1), l.batches)
+	assert.Equal(t, int32(0), l.lookups)
+
+	// the results are added to the lookup cache of the request
+	ctx := WithOptions(context.Background(), WithLookupCache())
+	assert.NoError(t, r.Validate(ctx, map[string]string{"x": "b@example.com"}))
+	assert.NoError(t, r.Validate(ctx, map[string]string{"x": "b@example.com", "y": "d@example.com"}))
+	assert.Equal(t, int32(3), l.batches)
+	assert.Equal(t, int32(0), l.lookups)
+
+	// the batch lookup errors are internal errors
+	r = Each(UniqueIn(l.exists).Batch(func(context.Context, []interface{}) (map[interface{}]bool, error) {
+		return nil, errors.New("db down")
+	}))
+	var ie InternalError
+	assert.True(t, errors.As(r.Validate(context.Background(), []string{"a"}), &ie))
+}
+
+func TestExistsIn(t *testing.T) {
+	l := &emailLookup{emails: map[string]bool{"a@example.com": true}}
+	r := ExistsIn(l.exists)
+
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{"t1", "a@example.com", ""},
+		{"t2", "b@example.com", "must reference an existing value"},
+		{"t3", "", ""},
+		{"t4", "error", "db down"},
+	}
+	for _, test := range tests {
+		err := r.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	var ie InternalError
+	assert.True(t, errors.As(r.Validate(context.Background(), "error"), &ie))
+	var ve Error
+	if assert.True(t, errors.As(r.Validate(context.Background(), "b@example.com"), &ve)) {
+		assert.Equal(t, "validation_reference_not_found", ve.Code())
+	}
+
+	err := Each(ExistsIn(l.exists).Batch(l.batch)).Validate(context.Background(), []string{"a@example.com", "b@example.com"})
+	assert.EqualError(t, err, "1: must reference an existing value.")
+	assert.Equal(t, int32(1), l.batches)
+}
+
+func TestLookupRule_Error(t *testing.T) {
+	r := UniqueIn(nil).Error("123")
+	assert.Equal(t, "123", r.err.Message())
+	assert.Equal(t, ErrAlreadyExists.Code(), r.err.Code())
+
+	err := NewError("code", "abc")
+	r = r.ErrorObject(err)
+	assert.Equal(t, err, r.err)
+
+	assert.Equal(t, RuleInfo{Kind: "unique_in"}, UniqueIn(nil).Metadata())
+	l := &emailLookup{}
+	assert.Equal(t, RuleInfo{Kind: "unique_in", Params: map[string]interface{}{"batch": true}}, UniqueIn(l.exists).Batch(l.batch).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "exists_in"}, ExistsIn(nil).Metadata())
+}
@@ -8,8 +8,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go/token"
 	"reflect"
 	"strings"
+	"sync"
 )
 
 var (
@@ -18,6 +20,10 @@ var (
 
 	// ErrFieldRequired indicates that a required field is missing.
 	ErrFieldRequired = NewError("validation_field_required", "missing required field: {{.field_name}}")
+
+	// ErrFieldInvalid is the error that returns when a field with a message or code set by Message or Code
+	// fails validation with an error that is not an Error, e.g. the nested errors of a struct field.
+	ErrFieldInvalid = NewError("validation_field_invalid", "is invalid")
 )
 
 type (
@@ -51,6 +57,12 @@ type NamedFieldRules struct {
 	rules            []Rule
 	validatePtrValue bool
 	skipIfNotFound   bool
+	match            FieldMatch
+	tag              string
+	// path holds the segments of the name; pathErr is set if the name is not a valid path.
+	path    []pathSegment
+	pathErr error
+	fieldOverrides
 }
 
 var _ FieldRules = (*NamedFieldRules)(nil)
@@ -59,7 +71,19 @@ func (n *NamedFieldRules) Name() string {
 	return n.name
 }
 
+// Rules returns the validation rules of the named field. If the name is a path, the rules
+// of the value found by the path are wrapped in a rule applied to the first field of the path.
 func (n *NamedFieldRules) Rules() []Rule {
+	if len(n.path) > 1 {
+		return []Rule{pathRule{
+			path:           n.path[1:],
+			rules:          n.rules,
+			ptrValue:       n.validatePtrValue,
+			skipIfNotFound: n.skipIfNotFound,
+			match:          n.match,
+			tag:            n.tag,
+		}}
+	}
 	return n.rules
 }
 
@@ -72,6 +96,30 @@ func (n *NamedFieldRules) SetSkipIfNotFound(skip bool) *NamedFieldRules {
 	return n
 }
 
+// Message sets the error message of the field, which replaces the error returned by any of the rules.
+func (n *NamedFieldRules) Message(message string) *NamedFieldRules {
+	n.message = message
+	return n
+}
+
+// Code sets the error code of the field, which replaces the code of the error returned by any of the rules.
+func (n *NamedFieldRules) Code(code string) *NamedFieldRules {
+	n.code = code
+	return n
+}
+
+// OnError sets a function that rewrites the error of the field. See PointerFieldRules.OnError for details.
+func (n *NamedFieldRules) OnError(f func(err error) error) *NamedFieldRules {
+	n.onError = f
+	return n
+}
+
+// Key sets the key of the field in the validation errors. See PointerFieldRules.Key for details.
+func (n *NamedFieldRules) Key(key string) *NamedFieldRules {
+	n.key = key
+	return n
+}
+
 // toFieldName converts a field name to its struct field representation.
 // If the name starts with a lowercase letter, it converts the first letter to uppercase.
 func toFieldName(name string) string {
@@ -81,30 +129,119 @@ func toFieldName(name string) string {
 	return name
 }
 
-func (n *NamedFieldRules) FindStructField(structValue reflect.Value, idx int) (*reflect.StructField, any, error) {
-	name := toFieldName(n.name)
+// FieldMatch specifies how NamedField looks up a struct field by name.
+type FieldMatch int
+
+const (
+	// MatchDefault matches the Go field name. The first letter of the name is converted to uppercase,
+	// unless strict field names are enabled by WithStrictFieldNames.
+	MatchDefault FieldMatch = iota
+	// MatchExact matches the Go field name exactly.
+	MatchExact
+	// MatchJSON matches the name of the field in JSON, i.e. the name in the "json" tag, or the Go field name
+	// if the tag does not specify a name. Fields with the tag `json:"-"` never match.
+	MatchJSON
+	// MatchCaseInsensitive matches the Go field name case-insensitively.
+	MatchCaseInsensitive
+)
 
-	var ft *reflect.StructField
+// lookupField looks up the exported struct field of type t by name according to the match mode.
+// Fields promoted from embedded structs are found as well.
+// If tag is not empty, the field is looked up by the name in the tag instead.
+func lookupField(t reflect.Type, name string, match FieldMatch, tag string, strict bool) (reflect.StructField, bool) {
+	if tag != "" {
+		return lookupFieldByTag(t, tag, name)
+	}
+	var (
+		sf reflect.StructField
+		ok bool
+	)
+	switch match {
+	case MatchExact:
+		sf, ok = t.FieldByName(name)
+	case MatchJSON:
+		return lookupFieldByTag(t, "json", name)
+	case MatchCaseInsensitive:
+		sf, ok = t.FieldByNameFunc(func(s string) bool {
+			return token.IsExported(s) && strings.EqualFold(s, name)
+		})
+	default:
+		if !strict {
+			name = toFieldName(name)
+		}
+		sf, ok = t.FieldByName(name)
+	}
+	if !ok || !sf.IsExported() {
+		// the values of unexported fields cannot be read
+		return reflect.StructField{}, false
+	}
+	return sf, true
+}
 
-	fv := structValue.FieldByName(name)
-	if fv.IsValid() {
-		sf, ok := structValue.Type().FieldByName(name)
-		if ok {
-			ft = &sf
-			fv = structValue.FieldByName(name).Addr()
+// lookupFieldByTag looks up the exported struct field of type t whose name in the given tag is name.
+// Fields without the tag are matched by their Go name. If several fields match, the least nested one is returned.
+func lookupFieldByTag(t reflect.Type, tag, name string) (reflect.StructField, bool) {
+	var (
+		found reflect.StructField
+		ok    bool
+	)
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() || ok && len(sf.Index) >= len(found.Index) {
+			continue
+		}
+		tagName, _, _ := strings.Cut(sf.Tag.Get(tag), ",")
+		if tagName == "-" {
+			continue
+		}
+		if tagName == "" {
+			tagName = sf.Name
+		}
+		if tagName == name {
+			found, ok = sf, true
 		}
 	}
+	return found, ok
+}
 
-	if ft == nil {
+// MatchBy sets how the struct field is looked up by name. It applies to every field name in the path.
+func (n *NamedFieldRules) MatchBy(match FieldMatch) *NamedFieldRules {
+	n.match = match
+	return n
+}
+
+// FindStructField looks for the struct field by name, with strict field names disabled.
+func (n *NamedFieldRules) FindStructField(structValue reflect.Value, idx int) (*reflect.StructField, any, error) {
+	return n.findStructField(structValue, false)
+}
+
+func (n *NamedFieldRules) findStructFieldWithContext(ctx context.Context, structValue reflect.Value, _ int) (*reflect.StructField, any, error) {
+	return n.findStructField(structValue, getOpts(ctx).strictFieldNames)
+}
+
+func (n *NamedFieldRules) findStructField(structValue reflect.Value, strict bool) (*reflect.StructField, any, error) {
+	if n.pathErr != nil {
+		return nil, nil, NewInternalError(n.pathErr)
+	}
+
+	sf, ok := lookupField(structValue.Type(), n.path[0].name, n.match, n.tag, strict)
+	if !ok {
 		if n.skipIfNotFound {
 			return nil, nil, ErrSkipFieldNotFound
 		}
 
 		return nil, nil, ErrFieldRequired.SetParams(map[string]any{"field_name": n.name})
 	}
+	ft := &sf
+
+	fv, err := structValue.FieldByIndexErr(sf.Index)
+	if err != nil {
+		// the field is promoted through a nil embedded struct pointer
+		return nil, nil, ErrSkipFieldNotFound
+	}
+	fv = fv.Addr()
 
 	var value interface{}
-	if !n.validatePtrValue {
+	if !n.validatePtrValue || len(n.path) > 1 {
 		value = fv.Elem().Interface()
 	} else {
 		value = fv.Interface()
@@ -114,10 +251,47 @@ func (n *NamedFieldRules) FindStructField(structValue reflect.Value, idx int) (*
 }
 
 // NamedField specifies a named field and the corresponding validation rules.
+//
+// The name can be a path that traverses nested structs, pointers, slices, arrays and maps,
+// using dots for struct fields and brackets for slice indexes and map keys, for example,
+//
+//	validation.NamedField("Address.Street", validation.Required)
+//	validation.NamedField("Items[0].SKU", validation.Required)
+//	validation.NamedField("Labels[env]", validation.In("dev", "prod"))
+//
+// The wildcard index "*" applies the rules to every element of a slice, array or map, for example,
+//
+//	validation.NamedField("Items[*].Quantity", validation.Min(1))
+//
+// The errors are nested by the path segments, e.g. "Address: (Street: cannot be blank.)" or
+// "Items: (2: (Quantity: must be no less than 1.).)", and the errors of several paths sharing the same prefix are merged. If the path goes through a nil pointer,
+// a missing slice element or a missing map key, the rules are applied to nil.
 func NamedField(name string, rules ...Rule) *NamedFieldRules {
+	return newNamedFieldRules(name, rules, false)
+}
+
+// NamedFieldByTag specifies a field by its name in the given struct tag and the corresponding validation rules.
+// It allows rules to be written against the names used in API payloads without knowing the Go struct layout:
+//
+//	validation.NamedFieldByTag("json", "first_name", validation.Required)
+//
+// Fields without the tag are matched by their Go field name, and fields whose tag name is "-" never match.
+// The name can be a path as described in NamedField, in which case every field name in the path is looked up
+// by the tag. MatchBy has no effect on the field rules.
+func NamedFieldByTag(tag, name string, rules ...Rule) *NamedFieldRules {
+	n := newNamedFieldRules(name, rules, false)
+	n.tag = tag
+	return n
+}
+
+func newNamedFieldRules(name string, rules []Rule, validatePtrValue bool) *NamedFieldRules {
+	path, err := parsePath(name)
 	return &NamedFieldRules{
-		name:  name,
-		rules: rules,
+		name:             name,
+		rules:            rules,
+		validatePtrValue: validatePtrValue,
+		path:             path,
+		pathErr:          err,
 	}
 }
 
@@ -137,14 +311,24 @@ func NamedField(name string, rules ...Rule) *NamedFieldRules {
 //	  ),
 //	)
 func NamedStructField(name string, fields ...FieldRules) *NamedFieldRules {
-	return &NamedFieldRules{
-		name: name,
-		rules: []Rule{&inlineRule{
-			f: func(ctx context.Context, value interface{}) error {
-				return ValidateStructWithContext(ctx, value, fields...)
-			},
-		}},
-		validatePtrValue: true,
+	return newNamedFieldRules(name, []Rule{&structFieldsRule{fields: fields}}, true)
+}
+
+// structFieldsRule validates a nested struct with the given field rules.
+type structFieldsRule struct {
+	fields []FieldRules
+}
+
+func (r *structFieldsRule) Validate(ctx context.Context, value interface{}) error {
+	return ValidateStructWithContext(ctx, value, r.fields...)
+}
+
+// Metadata returns the description of the rule.
+// The kind is "struct", with the param "fields" holding the nested field rules.
+func (r *structFieldsRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "struct",
+		Params: map[string]interface{}{"fields": r.fields},
 	}
 }
 
@@ -152,6 +336,7 @@ type PointerFieldRules struct {
 	fieldPtr         interface{}
 	rules            []Rule
 	validatePtrValue bool
+	fieldOverrides
 }
 
 var _ FieldRules = (*PointerFieldRules)(nil)
@@ -160,6 +345,51 @@ func (f *PointerFieldRules) Rules() []Rule {
 	return f.rules
 }
 
+// Message sets the error message of the field, which replaces the error returned by any of the rules.
+// For example,
+//
+//	validation.Field(&u.Email, validation.Required, is.Email).Message("please provide a contact email")
+//
+// If the error is an Error, its code and params are kept. Otherwise, e.g. for the errors of a nested struct,
+// the error is replaced by ErrFieldInvalid with the message.
+func (f *PointerFieldRules) Message(message string) *PointerFieldRules {
+	f.message = message
+	return f
+}
+
+// Code sets the error code of the field, which replaces the code of the error returned by any of the rules.
+// For example,
+//
+//	validation.Field(&u.Email, validation.Required, is.Email).Code("EMAIL_INVALID")
+//
+// The message and params of the error are kept. If the error is not an Error, it is replaced by
+// ErrFieldInvalid with the code.
+func (f *PointerFieldRules) Code(code string) *PointerFieldRules {
+	f.code = code
+	return f
+}
+
+// OnError sets a function that rewrites the error of the field, e.g. to add a hint or a link to the documentation.
+// The function is called with the validation error of the field, after the message and code set by Message and Code
+// are applied, and the error it returns is reported instead. If it returns nil, the field is considered valid.
+// It is not called for internal errors.
+func (f *PointerFieldRules) OnError(fn func(err error) error) *PointerFieldRules {
+	f.onError = fn
+	return f
+}
+
+// Key sets the key of the field in the validation errors, which replaces the name returned by the
+// ErrorFieldNameFunc option, e.g. the name in the json tag. For example,
+//
+//	validation.Field(&u.Address, validation.Required).Key("shipping_address")
+//
+// The key is also used as the name of the field in FieldPath. It has no effect on an anonymous struct field,
+// whose errors are merged into the errors of the enclosing struct.
+func (f *PointerFieldRules) Key(key string) *PointerFieldRules {
+	f.key = key
+	return f
+}
+
 func (f *PointerFieldRules) FindStructField(structValue reflect.Value, idx int) (*reflect.StructField, any, error) {
 	fv := reflect.ValueOf(f.fieldPtr)
 	if fv.Kind() != reflect.Ptr {
@@ -183,7 +413,7 @@ func (f *PointerFieldRules) FindStructField(structValue reflect.Value, idx int)
 
 // Field specifies a struct field and the corresponding validation rules.
 // The struct field must be specified as a pointer to it.
-func Field(fieldPtr interface{}, rules ...Rule) FieldRules {
+func Field(fieldPtr interface{}, rules ...Rule) *PointerFieldRules {
 	return &PointerFieldRules{
 		fieldPtr: fieldPtr,
 		rules:    rules,
@@ -208,20 +438,193 @@ func Field(fieldPtr interface{}, rules ...Rule) FieldRules {
 //	)
 func FieldStruct(structPtr interface{}, fields ...FieldRules) *PointerFieldRules {
 	return &PointerFieldRules{
-		fieldPtr: structPtr,
-		rules: []Rule{&inlineRule{
-			f: func(ctx context.Context, value interface{}) error {
-				return ValidateStructWithContext(ctx, value, fields...)
-			},
-		}},
+		fieldPtr:         structPtr,
+		rules:            []Rule{&structFieldsRule{fields: fields}},
 		validatePtrValue: true,
 	}
 }
 
+// fieldAddr returns the pointer to the struct field, to which normalizers write the normalized value.
+func (f *PointerFieldRules) fieldAddr(reflect.Value, *reflect.StructField) (reflect.Value, bool) {
+	fv := reflect.ValueOf(f.fieldPtr)
+	return fv, fv.Kind() == reflect.Ptr && !fv.IsNil()
+}
+
+// fieldAddr returns the pointer to the struct field, to which normalizers write the normalized value.
+// The field of a path is not addressable, so the normalized value is not written back.
+func (n *NamedFieldRules) fieldAddr(structValue reflect.Value, ft *reflect.StructField) (reflect.Value, bool) {
+	if len(n.path) > 1 {
+		return reflect.Value{}, false
+	}
+	fv, err := structValue.FieldByIndexErr(ft.Index)
+	if err != nil || !fv.CanAddr() {
+		return reflect.Value{}, false
+	}
+	return fv.Addr(), true
+}
+
+// addressableField is implemented by field rules that can return the pointer to their struct field.
+type addressableField interface {
+	fieldAddr(structValue reflect.Value, ft *reflect.StructField) (reflect.Value, bool)
+}
+
+// fieldOverrides holds the settings of field rules that override the error of the field,
+// or the options used to validate the field.
+type fieldOverrides struct {
+	message string
+	code    string
+	onError func(err error) error
+	key     string
+	options []Option
+}
+
+func (o *fieldOverrides) overrides() *fieldOverrides {
+	return o
+}
+
+// overrideError returns err overridden by the settings.
+func (o *fieldOverrides) overrideError(err error) error {
+	if o.message != "" || o.code != "" {
+		e, ok := err.(Error)
+		if !ok {
+			e = ErrFieldInvalid
+		}
+		if o.message != "" {
+			e = e.SetMessage(o.message)
+		}
+		if o.code != "" {
+			e = setErrorCode(e, o.code)
+		}
+		err = e
+	}
+	if o.onError != nil {
+		err = o.onError(err)
+	}
+	return err
+}
+
+// fieldOverrider is implemented by field rules that can override the error of the field.
+type fieldOverrider interface {
+	overrides() *fieldOverrides
+}
+
+// WithScopedOptions makes the field, and the nested structs and elements validated with it, use the options on top
+// of those of the context, while the other fields keep using the options of the context. It allows a subtree to use
+// different settings, e.g. the field names of an embedded legacy struct taken from another tag:
+//
+//	err := validation.ValidateStructWithContext(ctx, &order,
+//	    validation.Field(&order.ID, validation.Required),
+//	    validation.WithScopedOptions(
+//	        validation.FieldStruct(&order.Legacy, validation.Field(&order.Legacy.Code, validation.Required)),
+//	        validation.WithGetErrorFieldNameFunc(xmlFieldName),
+//	    ),
+//	)
+//
+// The name of the field itself is taken with the options of the context. For the field rules returned by Field,
+// FieldStruct and the other functions of this package, a copy holding the options is returned, so that the given
+// field rules can be reused, e.g. as package variables. Other field rules, including the struct-level rules such as
+// StructRule and ExactlyOneOf, are wrapped.
+func WithScopedOptions(field FieldRules, opts ...Option) FieldRules {
+	switch f := field.(type) {
+	case *PointerFieldRules:
+		c := *f
+		c.options = scopedOptions(f.options, opts)
+		return &c
+	case *NamedFieldRules:
+		c := *f
+		c.options = scopedOptions(f.options, opts)
+		return &c
+	case *scopedFieldRules:
+		c := *f
+		c.options = scopedOptions(f.options, opts)
+		return &c
+	case *scopedStructRules:
+		c := *f
+		c.options = scopedOptions(f.options, opts)
+		return &c
+	case structLevelRules:
+		return &scopedStructRules{structLevelRules: f, options: opts}
+	}
+	return &scopedFieldRules{FieldRules: field, fieldOverrides: fieldOverrides{options: opts}}
+}
+
+// scopedOptions returns a new slice of the options followed by opts, leaving options unchanged.
+func scopedOptions(options, opts []Option) []Option {
+	return append(options[:len(options):len(options)], opts...)
+}
+
+// scopedFieldRules adds the options set by WithScopedOptions to the field rules that do not hold fieldOverrides.
+type scopedFieldRules struct {
+	FieldRules
+	fieldOverrides
+}
+
+// scopedStructRules adds the options set by WithScopedOptions to the struct-level rules.
+// It is separate from scopedFieldRules, which must not be taken for a struct-level rule.
+type scopedStructRules struct {
+	structLevelRules
+	options []Option
+}
+
+func (r *scopedStructRules) validateStruct(ctx context.Context, structPtr interface{}) error {
+	return r.structLevelRules.validateStruct(WithOptions(ctx, r.options...), structPtr)
+}
+
+// structFieldCacheKey identifies a struct field by the struct type, the field type and the field offset.
+type structFieldCacheKey struct {
+	structType reflect.Type
+	fieldType  reflect.Type
+	offset     uintptr
+}
+
+// structFieldCache caches the results of findStructField so that repeated validations of the same
+// struct type do not need to scan the struct fields.
+var structFieldCache sync.Map
+
+// ClearRuleCache clears the cached struct field lookups used when validating struct fields.
+// It is mainly useful in tests and benchmarks.
+func ClearRuleCache() {
+	structFieldCache.Range(func(key, _ interface{}) bool {
+		structFieldCache.Delete(key)
+		return true
+	})
+}
+
 // findStructField looks for a field in the given struct.
 // The field being looked for should be a pointer to the actual struct field.
 // If found, the field info will be returned. Otherwise, nil will be returned.
+// The lookup result is cached by struct type and field offset when the field lies within the struct memory.
 func findStructField(structValue reflect.Value, fieldValue reflect.Value) *reflect.StructField {
+	if !structValue.CanAddr() || fieldValue.Kind() != reflect.Ptr {
+		return scanStructField(structValue, fieldValue)
+	}
+
+	base, ptr := structValue.UnsafeAddr(), fieldValue.Pointer()
+	if ptr < base || ptr >= base+structValue.Type().Size() {
+		// the field may belong to an embedded struct pointer, whose address is unrelated to the struct
+		return scanStructField(structValue, fieldValue)
+	}
+
+	key := structFieldCacheKey{
+		structType: structValue.Type(),
+		fieldType:  fieldValue.Type().Elem(),
+		offset:     ptr - base,
+	}
+	if cached, ok := structFieldCache.Load(key); ok {
+		sf := *cached.(*reflect.StructField)
+		return &sf
+	}
+
+	sf := scanStructField(structValue, fieldValue)
+	if sf != nil {
+		cached := *sf
+		structFieldCache.Store(key, &cached)
+	}
+	return sf
+}
+
+// scanStructField looks for a field in the given struct by comparing the field addresses.
+func scanStructField(structValue reflect.Value, fieldValue reflect.Value) *reflect.StructField {
 	ptr := fieldValue.Pointer()
 	for i := structValue.NumField() - 1; i >= 0; i-- {
 		sf := structValue.Type().Field(i)
@@ -239,7 +642,7 @@ func findStructField(structValue reflect.Value, fieldValue reflect.Value) *refle
 				fi = fi.Elem()
 			}
 			if fi.Kind() == reflect.Struct {
-				if f := findStructField(fi, fieldValue); f != nil {
+				if f := scanStructField(fi, fieldValue); f != nil {
 					return f
 				}
 			}
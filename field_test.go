@@ -7,7 +7,9 @@ package validation
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -664,7 +666,7 @@ func TestPointerFieldRules_ValidatePtrValue(t *testing.T) {
 
 	// Test with validatePtrValue = false (default for Field)
 	outer := &Outer{Inner: Inner{Value: "test"}}
-	fr1 := Field(&outer.Inner, Required).(*PointerFieldRules)
+	fr1 := Field(&outer.Inner, Required)
 	assert.False(t, fr1.validatePtrValue)
 
 	// Test with validatePtrValue = true (FieldStruct)
@@ -685,3 +687,397 @@ func TestPointerFieldRules_ValidatePtrValue(t *testing.T) {
 	_, ok = value2.(*Inner)
 	assert.True(t, ok, "FieldStruct should return pointer to value")
 }
+
+func TestFindStructFieldCache(t *testing.T) {
+	ClearRuleCache()
+	defer ClearRuleCache()
+
+	var s1, s2 Struct1
+	s3 := Struct3{Struct2: &Struct2{}}
+	v1 := reflect.ValueOf(&s1).Elem()
+	v2 := reflect.ValueOf(&s2).Elem()
+	v3 := reflect.ValueOf(&s3).Elem()
+
+	tests := []struct {
+		tag   string
+		value reflect.Value
+		field interface{}
+		name  string
+	}{
+		{"t1", v1, &s1.Field1, "Field1"},
+		{"t2", v2, &s2.Field1, "Field1"},
+		{"t3", v1, &s1.Struct2, "Struct2"},
+		{"t4", v1, &s1.Field21, "Field21"},
+		{"t5", v2, &s2.Struct2.Field22, "Field22"},
+		{"t6", v3, &s3.Struct2, "Struct2"},
+		{"t7", v3, &s3.Field21, "Field21"},
+		{"t8", v3, &s3.S1, "S1"},
+	}
+
+	for _, round := range []string{"uncached", "cached"} {
+		for _, test := range tests {
+			sf := findStructField(test.value, reflect.ValueOf(test.field))
+			if assert.NotNil(t, sf, round+" "+test.tag) {
+				assert.Equal(t, test.name, sf.Name, round+" "+test.tag)
+			}
+		}
+	}
+
+	// a field of another struct must not be found even if the offset matches a cached entry
+	var other Struct2
+	assert.Nil(t, findStructField(v1, reflect.ValueOf(&other.Field21)))
+
+	// the returned field info must not be shared with the cache
+	sf := findStructField(v1, reflect.ValueOf(&s1.Field1))
+	sf.Name = "changed"
+	assert.Equal(t, "Field1", findStructField(v1, reflect.ValueOf(&s1.Field1)).Name)
+
+	ClearRuleCache()
+	count := 0
+	structFieldCache.Range(func(_, _ interface{}) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 0, count)
+}
+
+func TestNamedFieldRules_MatchBy(t *testing.T) {
+	type Embedded struct {
+		Code string `json:"code"`
+	}
+	type TestStruct struct {
+		Embedded
+		FirstName string `json:"first_name"`
+		LastName  string `json:",omitempty"`
+		Secret    string `json:"-"`
+		url       string
+		URL       string `json:"url"`
+	}
+
+	v := TestStruct{
+		Embedded:  Embedded{Code: "c"},
+		FirstName: "John",
+		LastName:  "Doe",
+		Secret:    "s",
+		URL:       "u",
+	}
+	strict := WithOptions(context.Background(), WithStrictFieldNames(true))
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		field *NamedFieldRules
+		err   string
+	}{
+		{"t1", context.Background(), NamedField("firstName", In("x")), "first_name: must be a valid value."},
+		{"t2", strict, NamedField("firstName", In("x")), "missing required field: firstName"},
+		{"t3", strict, NamedField("FirstName", In("x")), "first_name: must be a valid value."},
+		{"t4", strict, NamedField("firstName", In("x")).MatchBy(MatchCaseInsensitive), "first_name: must be a valid value."},
+		{"t5", context.Background(), NamedField("firstName", In("x")).MatchBy(MatchExact), "missing required field: firstName"},
+		{"t6", context.Background(), NamedField("first_name", In("x")).MatchBy(MatchJSON), "first_name: must be a valid value."},
+		{"t7", context.Background(), NamedField("LastName", In("x")).MatchBy(MatchJSON), "LastName: must be a valid value."},
+		{"t8", context.Background(), NamedField("Secret", In("x")).MatchBy(MatchJSON), "missing required field: Secret"},
+		{"t9", context.Background(), NamedField("code", In("x")).MatchBy(MatchJSON), "code: must be a valid value."},
+		{"t10", context.Background(), NamedField("url", In("x")).MatchBy(MatchJSON), "url: must be a valid value."},
+		{"t11", context.Background(), NamedField("FIRSTNAME", In("x")).MatchBy(MatchCaseInsensitive), "first_name: must be a valid value."},
+		{"t12", context.Background(), NamedField("missing", In("x")).MatchBy(MatchJSON).SetSkipIfNotFound(true), ""},
+	}
+	for _, test := range tests {
+		s := v
+		err := ValidateStructWithContext(test.ctx, &s, test.field)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestNamedFieldRules_Unexported(t *testing.T) {
+	type TestStruct struct {
+		name string
+		code string
+		Code string
+	}
+
+	v := TestStruct{name: "n", code: "c", Code: "C"}
+	strict := WithOptions(context.Background(), WithStrictFieldNames(true))
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		field *NamedFieldRules
+		err   string
+	}{
+		{"t1", strict, NamedField("name", In("x")), "missing required field: name"},
+		{"t2", context.Background(), NamedField("name", In("x")).MatchBy(MatchExact), "missing required field: name"},
+		{"t3", context.Background(), NamedField("name", In("x")).MatchBy(MatchCaseInsensitive), "missing required field: name"},
+		{"t4", strict, NamedField("name", In("x")).SetSkipIfNotFound(true), ""},
+		{"t5", strict, NamedField("code", In("x")), "missing required field: code"},
+		{"t6", context.Background(), NamedField("code", In("x")).MatchBy(MatchCaseInsensitive), "Code: must be a valid value."},
+		{"t7", context.Background(), NamedField("code", In("x")).MatchBy(MatchExact), "missing required field: code"},
+	}
+	for _, test := range tests {
+		s := v
+		err := ValidateStructWithContext(test.ctx, &s, test.field)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestNamedFieldRules_MatchByPath(t *testing.T) {
+	type Address struct {
+		ZipCode string `json:"zip_code"`
+	}
+	type TestStruct struct {
+		HomeAddress *Address `json:"home_address"`
+	}
+
+	s := TestStruct{HomeAddress: &Address{}}
+	err := ValidateStructWithContext(context.Background(), &s,
+		NamedField("home_address.zip_code", Required).MatchBy(MatchJSON),
+	)
+	assertError(t, "home_address: (zip_code: cannot be blank.).", err, "t1")
+
+	strict := WithOptions(context.Background(), WithStrictFieldNames(true))
+	err = ValidateStructWithContext(strict, &s, NamedField("HomeAddress.zipCode", Required))
+	assertError(t, "home_address: missing required field: zipCode.", err, "t2")
+}
+
+func TestNamedFieldRules_NilEmbeddedPointer(t *testing.T) {
+	type Embedded struct {
+		Code string
+	}
+	type TestStruct struct {
+		*Embedded
+	}
+
+	err := ValidateStructWithContext(context.Background(), &TestStruct{}, NamedField("Code", Required))
+	assert.NoError(t, err)
+	err = ValidateStructWithContext(context.Background(), &TestStruct{Embedded: &Embedded{}}, NamedField("Code", Required))
+	assertError(t, "Code: cannot be blank.", err, "t1")
+}
+
+func TestNamedFieldByTag(t *testing.T) {
+	type Address struct {
+		ZipCode string `json:"zip_code" form:"zip"`
+	}
+	type TestStruct struct {
+		FirstName string   `json:"first_name" form:"fname"`
+		Nickname  string   `form:"-"`
+		Address   *Address `json:"address" form:"addr"`
+	}
+
+	s := TestStruct{Address: &Address{}}
+	tests := []struct {
+		tag   string
+		field *NamedFieldRules
+		err   string
+	}{
+		{"t1", NamedFieldByTag("json", "first_name", Required), "first_name: cannot be blank."},
+		{"t2", NamedFieldByTag("form", "fname", Required), "first_name: cannot be blank."},
+		{"t3", NamedFieldByTag("form", "first_name", Required), "missing required field: first_name"},
+		{"t4", NamedFieldByTag("form", "Nickname", Required), "missing required field: Nickname"},
+		{"t5", NamedFieldByTag("json", "Nickname", Required), "Nickname: cannot be blank."},
+		{"t6", NamedFieldByTag("form", "addr.zip", Required), "address: (zip_code: cannot be blank.)."},
+		{"t7", NamedFieldByTag("form", "FirstName", Required).MatchBy(MatchExact), "missing required field: FirstName"},
+		{"t8", NamedFieldByTag("form", "unknown", Required).SetSkipIfNotFound(true), ""},
+	}
+	for _, test := range tests {
+		v := s
+		err := ValidateStructWithContext(context.Background(), &v, test.field)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestFieldRules_Message(t *testing.T) {
+	type Address struct {
+		Street string
+	}
+	type TestStruct struct {
+		Email   string
+		Address Address
+	}
+
+	s := TestStruct{Email: "abc"}
+	tests := []struct {
+		tag   string
+		field FieldRules
+		err   string
+		code  string
+	}{
+		{"t1", Field(&s.Email, Required, Length(5, 10)).Message("please provide a contact email"), "Email: please provide a contact email.", "validation_length_out_of_range"},
+		{"t2", Field(&s.Email, Required).Message("please provide a contact email"), "", ""},
+		{"t3", Field(&s.Address, By(func(context.Context, interface{}) error { return errors.New("invalid") })).Message("bad address"), "Address: bad address.", "validation_field_invalid"},
+		{"t4", FieldStruct(&s.Address, Field(&s.Address.Street, Required)).Message("bad address"), "Address: bad address.", "validation_field_invalid"},
+		{"t5", NamedField("email", In("xyz")).Message("unknown email"), "Email: unknown email.", "validation_in_invalid"},
+		{"t6", NamedField("address.street", Required).Message("street required"), "Address: street required.", "validation_field_invalid"},
+	}
+	for _, test := range tests {
+		err := ValidateStruct(&s, test.field)
+		assertError(t, test.err, err, test.tag)
+		if test.code != "" {
+			for _, e := range err.(Errors) {
+				if ce, ok := e.(Error); assert.True(t, ok, test.tag) {
+					assert.Equal(t, test.code, ce.Code(), test.tag)
+				}
+			}
+		}
+	}
+}
+
+func TestFieldRules_Code(t *testing.T) {
+	type TestStruct struct {
+		Email string
+		Age   int
+	}
+
+	s := TestStruct{Email: "abc", Age: 10}
+	hint := func(err error) error {
+		return fmt.Errorf("%w (see https://example.com/docs/email)", err)
+	}
+	tests := []struct {
+		tag   string
+		field FieldRules
+		err   string
+		code  string
+	}{
+		{"t1", Field(&s.Email, Length(5, 10)).Code("EMAIL_INVALID"), "Email: the length must be between 5 and 10.", "EMAIL_INVALID"},
+		{"t2", Field(&s.Email, Length(5, 10)).Code("EMAIL_INVALID").Message("bad email"), "Email: bad email.", "EMAIL_INVALID"},
+		{"t3", Field(&s.Email, By(func(context.Context, interface{}) error { return errors.New("invalid") })).Code("EMAIL_INVALID"), "Email: is invalid.", "EMAIL_INVALID"},
+		{"t4", NamedField("age", Min(18)).Code("AGE_INVALID"), "Age: must be no less than 18.", "AGE_INVALID"},
+		{"t5", Field(&s.Email, Length(5, 10)).OnError(hint), "Email: the length must be between 5 and 10 (see https://example.com/docs/email).", ""},
+		{"t6", NamedField("email", Length(5, 10)).OnError(func(error) error { return nil }), "", ""},
+		{"t7", Field(&s.Email, Required).OnError(hint), "", ""},
+	}
+	for _, test := range tests {
+		err := ValidateStruct(&s, test.field)
+		assertError(t, test.err, err, test.tag)
+		if test.code != "" {
+			for _, e := range err.(Errors) {
+				if ce, ok := e.(Error); assert.True(t, ok, test.tag) {
+					assert.Equal(t, test.code, ce.Code(), test.tag)
+				}
+			}
+		}
+	}
+
+	internal := Field(&s.Email, By(func(context.Context, interface{}) error {
+		return NewInternalError(errors.New("internal"))
+	})).OnError(func(error) error { return nil })
+	assert.EqualError(t, ValidateStruct(&s, internal), "internal")
+}
+
+func TestFieldRules_Key(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type TestStruct struct {
+		Address Address `json:"address"`
+		Name    string  `json:"name"`
+	}
+
+	s := TestStruct{}
+	tests := []struct {
+		tag   string
+		field FieldRules
+		err   string
+	}{
+		{"t1", Field(&s.Address, Required).Key("shipping_address"), "shipping_address: cannot be blank."},
+		{"t2", FieldStruct(&s.Address, Field(&s.Address.Street, Required).Key("line1")).Key("shipping_address"), "shipping_address: (line1: cannot be blank.)."},
+		{"t3", NamedField("name", Required).Key("full_name"), "full_name: cannot be blank."},
+		{"t4", NamedField("address.street", Required).Key("shipping_address"), "shipping_address: (street: cannot be blank.)."},
+	}
+	for _, test := range tests {
+		err := ValidateStruct(&s, test.field)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	var path string
+	err := ValidateStruct(&s, Field(&s.Name, By(func(ctx context.Context, _ interface{}) error {
+		path = FieldPath(ctx).String()
+		return nil
+	})).Key("full_name"))
+	assert.NoError(t, err)
+	assert.Equal(t, "full_name", path)
+
+	infos, err := Inspect(&s, Field(&s.Name, Required).Key("full_name"))
+	assert.NoError(t, err)
+	assert.Equal(t, []FieldInfo{{Name: "full_name", Rules: []RuleInfo{{Kind: "required"}}}}, infos)
+}
+
+func TestWithScopedOptions(t *testing.T) {
+	type legacy struct {
+		Code string `xml:"code_x" json:"code"`
+	}
+	type order struct {
+		ID     string `json:"id"`
+		Legacy legacy `json:"legacy"`
+		Notes  legacy `json:"notes"`
+	}
+	xmlName := func(f *reflect.StructField) string {
+		return strings.Split(f.Tag.Get("xml"), ",")[0]
+	}
+	upperName := func(f *reflect.StructField) string {
+		return strings.ToUpper(f.Name)
+	}
+
+	var o order
+	ctx := WithOptions(context.Background(), WithGetErrorFieldNameFunc(upperName))
+
+	// the options of the context propagate into the nested structs
+	err := ValidateStructWithContext(ctx, &o,
+		FieldStruct(&o.Legacy, Field(&o.Legacy.Code, Required)),
+		NamedStructField("Notes", Field(&o.Notes.Code, Required)),
+	)
+	assert.EqualError(t, err, "LEGACY: (CODE: cannot be blank.); NOTES: (CODE: cannot be blank.).")
+
+	// the scoped options apply to the subtree only
+	err = ValidateStructWithContext(ctx, &o,
+		Field(&o.ID, Required),
+		WithScopedOptions(FieldStruct(&o.Legacy, Field(&o.Legacy.Code, Required)), WithGetErrorFieldNameFunc(xmlName)),
+		NamedStructField("Notes", Field(&o.Notes.Code, Required)),
+	)
+	assert.EqualError(t, err, "ID: cannot be blank; LEGACY: (code_x: cannot be blank.); NOTES: (CODE: cannot be blank.).")
+
+	// the named fields keep their other settings
+	err = ValidateStructWithContext(ctx, &o,
+		WithScopedOptions(NamedStructField("Legacy", Field(&o.Legacy.Code, Required)).Key("old"), WithGetErrorFieldNameFunc(xmlName)),
+	)
+	assert.EqualError(t, err, "old: (code_x: cannot be blank.).")
+
+	// other field rules are wrapped
+	s := NewSchema(Spec("Legacy", NewSchema(Spec("Code", Required))))
+	err = ValidateStructWithContext(ctx, &o, WithScopedOptions(s.Fields()[0], WithGetErrorFieldNameFunc(xmlName)))
+	assert.EqualError(t, err, "LEGACY: (code_x: cannot be blank.).")
+
+	// the given field rules are not changed, so that they can be reused
+	legacyField := FieldStruct(&o.Legacy, Field(&o.Legacy.Code, Required))
+	scoped := WithScopedOptions(legacyField, WithGetErrorFieldNameFunc(xmlName))
+	assert.NotSame(t, legacyField, scoped)
+	assert.Empty(t, legacyField.options)
+	for i := 0; i < 3; i++ {
+		scoped2 := WithScopedOptions(scoped, WithStrictFieldNames(true))
+		assert.Len(t, scoped2.(*PointerFieldRules).options, 2)
+	}
+	assert.Len(t, scoped.(*PointerFieldRules).options, 1)
+	err = ValidateStructWithContext(ctx, &o, legacyField)
+	assert.EqualError(t, err, "LEGACY: (CODE: cannot be blank.).")
+
+	// the struct-level rules keep failing
+	var scopedOpts []string
+	rule := StructRule(func(ctx context.Context, o *order) error {
+		scopedOpts = append(scopedOpts, GetOptions(ctx).StructErrorKey())
+		return errors.New("invalid order")
+	})
+	err = ValidateStructWithContext(ctx, &o, WithScopedOptions(rule, WithStructErrorKey("_")))
+	assert.EqualError(t, err, "_struct: invalid order.")
+	err = ValidateStructWithContext(ctx, &o, WithScopedOptions(WithScopedOptions(rule, WithStructErrorKey("_")), WithStrictFieldNames(true)))
+	assert.EqualError(t, err, "_struct: invalid order.")
+	assert.Equal(t, []string{"_", "_"}, scopedOpts)
+	type contact struct {
+		Email string `xml:"email_x"`
+		Phone string `xml:"phone_x"`
+	}
+	var c contact
+	err = ValidateStructWithContext(ctx, &c,
+		Field(&c.Email, Length(0, 1)),
+		WithScopedOptions(ExactlyOneOf(&c.Email, &c.Phone), WithGetErrorFieldNameFunc(xmlName)),
+	)
+	assert.EqualError(t, err, "email_x,phone_x: exactly one of email_x, phone_x must be set.")
+}
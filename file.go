@@ -0,0 +1,98 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ Rule = (*FileRule)(nil)
+
+var (
+	// ErrFileNotExist is the error that returns when a path is not an existing file.
+	ErrFileNotExist = NewError("validation_file_not_exist", "must be an existing file")
+	// ErrDirNotExist is the error that returns when a path is not an existing directory.
+	ErrDirNotExist = NewError("validation_dir_not_exist", "must be an existing directory")
+)
+
+var (
+	// FileExists checks if a string is the path of an existing regular file.
+	// The path is looked up in the file system set by WithFS, or in the file system of the operating system
+	// if none is set. An error other than the file not existing, e.g. a permission error,
+	// is returned as an InternalError. An empty value is considered valid.
+	FileExists = FileRule{err: ErrFileNotExist}
+	// DirExists checks if a string is the path of an existing directory.
+	// The path is looked up in the same way as FileExists. An empty value is considered valid.
+	DirExists = FileRule{dir: true, err: ErrDirNotExist}
+)
+
+// FileRule is a validation rule that checks if a string is the path of an existing file or directory.
+type FileRule struct {
+	dir bool
+	err Error
+}
+
+// Validate checks if the given value is the path of an existing file or directory.
+func (r FileRule) Validate(ctx context.Context, value interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	opts := getOpts(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+	path, err := stringWithOptions(value, opts)
+	if err != nil {
+		return err
+	}
+
+	info, err := statPath(opts.fs, path)
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
+		return r.err
+	} else if err != nil {
+		return NewInternalError(err)
+	}
+	if info.IsDir() != r.dir || !r.dir && !info.Mode().IsRegular() {
+		return r.err
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r FileRule) Error(message string) FileRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r FileRule) ErrorObject(err Error) FileRule {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "file_exists" or "dir_exists".
+func (r FileRule) Metadata() RuleInfo {
+	if r.dir {
+		return RuleInfo{Kind: "dir_exists"}
+	}
+	return RuleInfo{Kind: "file_exists"}
+}
+
+// statPath returns the FileInfo of the file at path in fsys, or in the file system of the operating system
+// if fsys is nil. Because the paths of an fs.FS are unrooted, the absolute paths are looked up from the root of fsys.
+func statPath(fsys fs.FS, path string) (fs.FileInfo, error) {
+	if fsys == nil {
+		return os.Stat(path)
+	}
+	name := strings.TrimPrefix(filepath.ToSlash(filepath.Clean(path)), "/")
+	if name == "" {
+		name = "."
+	}
+	return fs.Stat(fsys, name)
+}
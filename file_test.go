@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileRule(t *testing.T) {
+	fsys := fstest.MapFS{
+		"etc/app.conf": {Data: []byte("debug = true")},
+		"var/log":      {Mode: fs.ModeDir},
+	}
+	ctx := WithOptions(context.Background(), WithFS(fsys))
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "app.conf")
+	assert.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		rule  Rule
+		value interface{}
+		err   string
+	}{
+		{"t1", ctx, FileExists, "", ""},
+		{"t2", ctx, FileExists, "/etc/app.conf", ""},
+		{"t3", ctx, FileExists, "etc/app.conf", ""},
+		{"t4", ctx, FileExists, "etc/missing.conf", "must be an existing file"},
+		{"t5", ctx, FileExists, "var/log", "must be an existing file"},
+		{"t6", ctx, FileExists, "../etc/app.conf", "must be an existing file"},
+		{"t7", ctx, DirExists, "/var/log", ""},
+		{"t8", ctx, DirExists, "/", ""},
+		{"t9", ctx, DirExists, "etc/app.conf", "must be an existing directory"},
+		{"t10", ctx, DirExists, "tmp", "must be an existing directory"},
+		{"t11", ctx, FileExists.Error("is not found"), "x", "is not found"},
+		{"t12", context.Background(), FileExists, file, ""},
+		{"t13", context.Background(), DirExists, dir, ""},
+		{"t14", context.Background(), FileExists, filepath.Join(dir, "missing"), "must be an existing file"},
+		{"t15", ctx, FileExists, 123, "must be either a string or byte slice"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(test.ctx, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	ctx = WithOptions(context.Background(), WithFS(failingFS{}))
+	err := FileExists.Validate(ctx, "app.conf")
+	if assert.IsType(t, internalError{}, err) {
+		assert.ErrorIs(t, err, fs.ErrPermission)
+	}
+}
+
+type failingFS struct{}
+
+func (failingFS) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+}
@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+var _ Rule = (*CoerceRule)(nil)
+
+var (
+	// ErrAsIntInvalid is the error that returns when a string cannot be converted to an integer.
+	ErrAsIntInvalid = NewError("validation_as_int_invalid", "must be an integer")
+	// ErrAsFloatInvalid is the error that returns when a string cannot be converted to a number.
+	ErrAsFloatInvalid = NewError("validation_as_float_invalid", "must be a number")
+)
+
+// ValidateForm validates form values, such as a parsed query string or form post, with the given key rules.
+// Each key is validated with its first value as a string, or with all its values as []string if Multi is
+// called on the key rules. For example,
+//
+//	err := validation.ValidateForm(ctx, r.URL.Query(),
+//	    validation.Key("q", validation.Required, validation.Length(1, 100)),
+//	    validation.Key("page", validation.AsInt(validation.Min(1))).Optional(),
+//	    validation.Key("tag", validation.Each(validation.In("a", "b"))).Multi().Optional(),
+//	)
+//
+// A missing key is reported with ErrKeyMissing unless Optional is called on the key rules, while a key
+// with an empty value is present and can be rejected by Required. Keys without rules are ignored.
+// The errors are reported in the same way as Map.
+func ValidateForm(ctx context.Context, values url.Values, keys ...*KeyRules) error {
+	m := make(map[string]interface{}, len(keys))
+	for _, kr := range keys {
+		key, ok := kr.key.(string)
+		if !ok {
+			// reported by MapRule as ErrKeyWrongType
+			continue
+		}
+		vs, ok := values[key]
+		switch {
+		case !ok:
+		case kr.multi:
+			m[key] = vs
+		case len(vs) > 0:
+			m[key] = vs[0]
+		default:
+			m[key] = ""
+		}
+	}
+	return Map(keys...).AllowExtraKeys().Validate(ctx, m)
+}
+
+// CoerceRule is a validation rule that converts a string to another type and validates the result with other rules.
+type CoerceRule struct {
+	kind  string
+	parse func(string) (interface{}, error)
+	rules []Rule
+	err   Error
+}
+
+// AsInt returns a validation rule that checks if a string is a decimal integer, and validates
+// the parsed int with the given rules. It is useful to validate query strings and form posts:
+//
+//	validation.Key("page", validation.AsInt(validation.Min(1), validation.Max(100)))
+//
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func AsInt(rules ...Rule) CoerceRule {
+	return CoerceRule{
+		kind: "as_int",
+		parse: func(s string) (interface{}, error) {
+			return strconv.Atoi(s)
+		},
+		rules: rules,
+		err:   ErrAsIntInvalid,
+	}
+}
+
+// AsFloat returns a validation rule that checks if a string is a number, and validates
+// the parsed float64 with the given rules.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func AsFloat(rules ...Rule) CoerceRule {
+	return CoerceRule{
+		kind: "as_float",
+		parse: func(s string) (interface{}, error) {
+			return strconv.ParseFloat(s, 64)
+		},
+		rules: rules,
+		err:   ErrAsFloatInvalid,
+	}
+}
+
+// Error sets the error message that is used when the value cannot be converted.
+func (r CoerceRule) Error(message string) CoerceRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct that is used when the value cannot be converted.
+func (r CoerceRule) ErrorObject(err Error) CoerceRule {
+	r.err = err
+	return r
+}
+
+// Validate converts the value and validates the result with the rules.
+func (r CoerceRule) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	str, err := stringWithOptions(value, opts)
+	if err != nil {
+		return err
+	}
+
+	v, err := r.parse(str)
+	if err != nil {
+		return r.err
+	}
+	return ValidateWithContext(ctx, v, r.rules...)
+}
+
+// Metadata returns the description of the rule.
+// The kind is "as_int", "as_float" or "json_string", with the param "rules" holding the rules of the converted value as []Rule.
+func (r CoerceRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   r.kind,
+		Params: map[string]interface{}{"rules": r.rules},
+	}
+}
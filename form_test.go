@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateForm(t *testing.T) {
+	keys := []*KeyRules{
+		Key("q", Required, Length(1, 5)),
+		Key("page", AsInt(Min(1))).Optional(),
+		Key("tag", Each(In("a", "b"))).Multi().Optional(),
+	}
+
+	tests := []struct {
+		tag   string
+		query string
+		err   string
+	}{
+		{"t1", "q=abc", ""},
+		{"t2", "q=abc&page=2&tag=a&tag=b&extra=1", ""},
+		{"t3", "", "q: required key is missing."},
+		{"t4", "q=", "q: cannot be blank."},
+		{"t5", "q=abcdef", "q: the length must be between 1 and 5."},
+		{"t6", "q=abc&q=abcdef", ""},
+		{"t7", "q=abc&page=x", "page: must be an integer."},
+		{"t8", "q=abc&page=-1", "page: must be no less than 1."},
+		{"t9", "q=abc&page=", ""},
+		{"t10", "q=abc&tag=a&tag=c", "tag: (1: must be a valid value.)."},
+	}
+	for _, test := range tests {
+		values, err := url.ParseQuery(test.query)
+		assert.NoError(t, err, test.tag)
+		err = ValidateForm(context.Background(), values, keys...)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// a key of the wrong type
+	err := ValidateForm(context.Background(), url.Values{}, Key(1, Required))
+	assertError(t, "1: key not the correct type.", err, "t11")
+
+	// a key without values
+	err = ValidateForm(context.Background(), url.Values{"q": nil}, Key("q", Required))
+	assertError(t, "q: cannot be blank.", err, "t12")
+}
+
+func TestAsInt(t *testing.T) {
+	tests := []struct {
+		tag   string
+		rule  CoerceRule
+		value interface{}
+		err   string
+	}{
+		{"t1", AsInt(), "12", ""},
+		{"t2", AsInt(), "", ""},
+		{"t3", AsInt(), "1.5", "must be an integer"},
+		{"t4", AsInt(In(1, 2)), "2", ""},
+		{"t5", AsInt(In(1, 2)), "3", "must be a valid value"},
+		{"t6", AsInt(), 12, "must be either a string or byte slice"},
+		{"t7", AsInt().Error("bad"), "x", "bad"},
+		{"t8", AsFloat(Max(1.5)), "1.25", ""},
+		{"t9", AsFloat(Max(1.5)), "2", "must be no greater than 1.5"},
+		{"t10", AsFloat(), "abc", "must be a number"},
+		{"t11", AsFloat(), []byte("1e3"), ""},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := NewError("code", "abc")
+	r := AsFloat().ErrorObject(err)
+	assert.True(t, errors.Is(r.Validate(context.Background(), "x"), err))
+	assert.Equal(t, RuleInfo{Kind: "as_float", Params: map[string]interface{}{"rules": []Rule(nil)}}, r.Metadata())
+}
@@ -0,0 +1,34 @@
+// Package ginvalidate provides a helper for decoding and validating the request bodies in Gin handlers.
+//
+// It is a separate module so that the validation package does not depend on Gin.
+package ginvalidate
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rockcookies/go-validation"
+	"github.com/rockcookies/go-validation/httpvalidate"
+)
+
+// Bind decodes and validates the JSON request body like httpvalidate.DecodeAndValidate, without
+// Gin's own binding validation. If it fails, the request is aborted with the status code returned by
+// httpvalidate.ErrorStatus and the JSON body built by httpvalidate.DefaultErrorEnvelope, and the error
+// is returned, so that the handler can simply return. For example,
+//
+//	var req CreateUser
+//	if err := ginvalidate.Bind(c, &req, validation.Field(&req.Name, validation.Required)); err != nil {
+//	    return
+//	}
+func Bind(c *gin.Context, dst any, fields ...validation.FieldRules) error {
+	return BindWith(httpvalidate.Binder{}, c, dst, fields...)
+}
+
+// BindWith decodes and validates the JSON request body like Bind, rendering the error response with the
+// envelope of the Binder b.
+func BindWith(b httpvalidate.Binder, c *gin.Context, dst any, fields ...validation.FieldRules) error {
+	if err := httpvalidate.DecodeAndValidate(c.Request, dst, fields...); err != nil {
+		status := httpvalidate.ErrorStatus(err)
+		c.AbortWithStatusJSON(status, b.ErrorBody(status, err))
+		return err
+	}
+	return nil
+}
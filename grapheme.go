@@ -0,0 +1,115 @@
+package validation
+
+import "unicode"
+
+// graphemeClass is the property of a rune that determines the grapheme cluster boundaries around it.
+type graphemeClass int
+
+const (
+	graphemeOther graphemeClass = iota
+	graphemeCR
+	graphemeLF
+	graphemeControl
+	graphemeExtend
+	graphemeZWJ
+	graphemeSpacingMark
+	graphemeRegionalIndicator
+	graphemePictographic
+	graphemeL
+	graphemeV
+	graphemeT
+	graphemeLV
+	graphemeLVT
+)
+
+// graphemeCount returns the number of user-perceived characters in s. It follows the extended grapheme cluster
+// boundaries of Unicode Standard Annex #29, approximating the Unicode properties that are not available in the
+// standard library with its tables: marks and emoji modifiers extend the previous character, emoji joined by ZWJ,
+// pairs of regional indicators (flags) and Hangul syllable sequences are single characters.
+func graphemeCount(s string) int {
+	var (
+		n    int
+		prev graphemeClass
+		// pict is true if the current cluster has an emoji followed by Extend* ZWJ.
+		pict bool
+		// ri is the number of consecutive regional indicators in the current cluster.
+		ri int
+	)
+	for i, r := range s {
+		c := graphemeClassOf(r)
+		if i == 0 || graphemeBreak(prev, c, pict, ri) {
+			n++
+			pict, ri = false, 0
+		}
+		switch c {
+		case graphemePictographic:
+			pict = true
+		case graphemeExtend, graphemeZWJ:
+		default:
+			pict = false
+		}
+		if c == graphemeRegionalIndicator {
+			ri++
+		}
+		prev = c
+	}
+	return n
+}
+
+// graphemeBreak reports whether there is a grapheme cluster boundary between two runes of the classes prev and c.
+func graphemeBreak(prev, c graphemeClass, pict bool, ri int) bool {
+	switch {
+	case prev == graphemeCR && c == graphemeLF:
+		return false
+	case prev == graphemeCR || prev == graphemeLF || prev == graphemeControl,
+		c == graphemeCR || c == graphemeLF || c == graphemeControl:
+		return true
+	case prev == graphemeL && (c == graphemeL || c == graphemeV || c == graphemeLV || c == graphemeLVT),
+		(prev == graphemeLV || prev == graphemeV) && (c == graphemeV || c == graphemeT),
+		(prev == graphemeLVT || prev == graphemeT) && c == graphemeT:
+		return false
+	case c == graphemeExtend || c == graphemeZWJ || c == graphemeSpacingMark:
+		return false
+	case prev == graphemeZWJ && c == graphemePictographic && pict:
+		return false
+	case prev == graphemeRegionalIndicator && c == graphemeRegionalIndicator:
+		return ri%2 == 0
+	}
+	return true
+}
+
+// graphemeClassOf returns the grapheme cluster break class of r.
+func graphemeClassOf(r rune) graphemeClass {
+	switch {
+	case r == '\r':
+		return graphemeCR
+	case r == '\n':
+		return graphemeLF
+	case r == 0x200D:
+		return graphemeZWJ
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return graphemeRegionalIndicator
+	case r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F, r == 0x200C,
+		unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r):
+		// emoji modifiers, tags and ZWNJ extend the previous character
+		return graphemeExtend
+	case unicode.Is(unicode.Mc, r):
+		return graphemeSpacingMark
+	case unicode.IsControl(r), unicode.Is(unicode.Zl, r), unicode.Is(unicode.Zp, r), unicode.Is(unicode.Cf, r):
+		return graphemeControl
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return graphemeL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return graphemeV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return graphemeT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return graphemeLV
+		}
+		return graphemeLVT
+	case r >= 0x1F000 && r <= 0x1FAFF, r >= 0x2600 && r <= 0x27BF, unicode.Is(unicode.So, r):
+		return graphemePictographic
+	}
+	return graphemeOther
+}
@@ -0,0 +1,121 @@
+// Package grpcvalidate provides gRPC server interceptors that validate the request messages.
+//
+// It is a separate module so that the validation package does not depend on gRPC.
+package grpcvalidate
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/rockcookies/go-validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Option configures the interceptors.
+type Option func(*options)
+
+type options struct {
+	schemas map[reflect.Type]*validation.Schema
+}
+
+// WithSchema validates the messages of the same type as msg with the given Schema,
+// instead of calling their Validate method.
+func WithSchema(msg interface{}, s *validation.Schema) Option {
+	return func(o *options) {
+		o.schemas[reflect.TypeOf(msg)] = s
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{schemas: map[reflect.Type]*validation.Schema{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// validate validates msg with its registered Schema, or by its Validate method if it implements Validatable.
+// Other messages are considered valid.
+func (o *options) validate(ctx context.Context, msg interface{}) error {
+	if s, ok := o.schemas[reflect.TypeOf(msg)]; ok {
+		return s.Validate(ctx, msg)
+	}
+	if v, ok := msg.(validation.Validatable); ok {
+		return v.Validate(ctx)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor returns a unary server interceptor that validates the request messages.
+// If the validation fails, the handler is not called and the error converted by ToStatus is returned.
+func UnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := o.validate(ctx, req); err != nil {
+			return nil, ToStatus(err).Err()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor that validates every message received
+// by the handler. If the validation fails, RecvMsg returns the error converted by ToStatus.
+func StreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, opts: o})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	opts *options
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := s.opts.validate(s.Context(), m); err != nil {
+		return ToStatus(err).Err()
+	}
+	return nil
+}
+
+// ToStatus converts a validation error into a gRPC status.
+//   - An InternalError is converted into codes.Internal, without exposing the underlying error.
+//   - Errors is converted into codes.InvalidArgument with a BadRequest detail, which holds a field
+//     violation for every field error. The field is the dot-separated error path, e.g. "address.street".
+//   - Any other error is converted into codes.InvalidArgument with the error message.
+func ToStatus(err error) *status.Status {
+	var ie validation.InternalError
+	if errors.As(err, &ie) {
+		return status.New(codes.Internal, "internal error")
+	}
+
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+
+	fieldErrs := errs.Flatten()
+	br := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(fieldErrs)),
+	}
+	for i, fe := range fieldErrs {
+		br.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Path.String(),
+			Description: fe.Err.Error(),
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, errs.Error())
+	if ds, err := st.WithDetails(br); err == nil {
+		return ds
+	}
+	return st
+}
@@ -0,0 +1,127 @@
+package grpcvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type address struct {
+	Street string `json:"street"`
+}
+
+type createUserRequest struct {
+	Name    string  `json:"name"`
+	Address address `json:"address"`
+}
+
+func (r *createUserRequest) Validate(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, r,
+		validation.Field(&r.Name, validation.Required),
+		validation.FieldStruct(&r.Address,
+			validation.Field(&r.Address.Street, validation.Required),
+		),
+	)
+}
+
+type plainRequest struct {
+	ID string
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	idSchema := validation.NewSchema(validation.Spec("ID", validation.Required))
+
+	tests := []struct {
+		tag  string
+		opts []Option
+		req  interface{}
+		code codes.Code
+	}{
+		{"t1", nil, &createUserRequest{Name: "john", Address: address{Street: "main"}}, codes.OK},
+		{"t2", nil, &createUserRequest{}, codes.InvalidArgument},
+		{"t3", nil, &plainRequest{}, codes.OK},
+		{"t4", []Option{WithSchema(&plainRequest{}, idSchema)}, &plainRequest{}, codes.InvalidArgument},
+		{"t5", []Option{WithSchema(&plainRequest{}, idSchema)}, &plainRequest{ID: "1"}, codes.OK},
+	}
+	for _, test := range tests {
+		resp, err := UnaryServerInterceptor(test.opts...)(context.Background(), test.req, &grpc.UnaryServerInfo{}, handler)
+		assert.Equal(t, test.code, status.Code(err), test.tag)
+		if test.code == codes.OK {
+			assert.Equal(t, "ok", resp, test.tag)
+		} else {
+			assert.Nil(t, resp, test.tag)
+		}
+	}
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	msgs []createUserRequest
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *mockServerStream) RecvMsg(m interface{}) error {
+	if len(s.msgs) == 0 {
+		return errors.New("EOF")
+	}
+	*m.(*createUserRequest) = s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	ss := &mockServerStream{msgs: []createUserRequest{
+		{Name: "john", Address: address{Street: "main"}},
+		{},
+	}}
+	var errs []error
+	err := StreamServerInterceptor()(nil, ss, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		for i := 0; i < 3; i++ {
+			errs = append(errs, stream.RecvMsg(&createUserRequest{}))
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, errs, 3) {
+		assert.NoError(t, errs[0])
+		assert.Equal(t, codes.InvalidArgument, status.Code(errs[1]))
+		assert.EqualError(t, errs[2], "EOF")
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	st := ToStatus(validation.NewInternalError(errors.New("db down")))
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "internal error", st.Message())
+
+	st = ToStatus(validation.ErrRequired)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "cannot be blank", st.Message())
+	assert.Empty(t, st.Details())
+
+	st = ToStatus((&createUserRequest{}).Validate(context.Background()))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "address: (street: cannot be blank.); name: cannot be blank.", st.Message())
+	if assert.Len(t, st.Details(), 1) {
+		br, ok := st.Details()[0].(*errdetails.BadRequest)
+		if assert.True(t, ok) && assert.Len(t, br.FieldViolations, 2) {
+			assert.Equal(t, "address.street", br.FieldViolations[0].Field)
+			assert.Equal(t, "cannot be blank", br.FieldViolations[0].Description)
+			assert.Equal(t, "name", br.FieldViolations[1].Field)
+			assert.Equal(t, "cannot be blank", br.FieldViolations[1].Description)
+		}
+	}
+}
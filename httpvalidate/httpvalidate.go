@@ -0,0 +1,211 @@
+// Package httpvalidate provides helpers for decoding and validating HTTP request bodies.
+package httpvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rockcookies/go-validation"
+)
+
+// DecodeError is the error returned by DecodeAndValidate when the request body cannot be decoded.
+type DecodeError struct {
+	Err error
+}
+
+// Error returns the error message.
+func (e *DecodeError) Error() string {
+	return "invalid request body: " + e.Err.Error()
+}
+
+// Unwrap returns the decoding error.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorResponse is the JSON body written by WriteError.
+type ErrorResponse struct {
+	// Message describes the error.
+	Message string `json:"message"`
+	// Errors holds the validation errors indexed by field names, if any.
+	Errors validation.Errors `json:"errors,omitempty"`
+}
+
+// DecodeAndValidate decodes the JSON request body into dst and validates it with the request context.
+// dst must be a pointer to a struct. If fields are given, dst is validated by ValidateStruct with the fields,
+// which must refer to the fields of dst. Otherwise dst is validated by Validate, which calls dst.Validate()
+// if dst implements Validatable.
+//
+// A *DecodeError is returned if the body cannot be decoded. Otherwise the validation error, if any, is returned.
+func DecodeAndValidate(r *http.Request, dst any, fields ...validation.FieldRules) error {
+	if err := json.NewDecoder(r.Body).Decode(dst); err != nil {
+		return &DecodeError{Err: err}
+	}
+	return validate(r.Context(), dst, fields)
+}
+
+func validate(ctx context.Context, dst any, fields []validation.FieldRules) error {
+	if len(fields) > 0 {
+		return validation.ValidateStructWithContext(ctx, dst, fields...)
+	}
+	return validation.ValidateWithContext(ctx, dst)
+}
+
+// ErrorStatus returns the HTTP status code corresponding to err:
+//   - 400 Bad Request for a *DecodeError
+//   - 500 Internal Server Error for an InternalError
+//   - 422 Unprocessable Entity for validation errors and any other error
+func ErrorStatus(err error) int {
+	var (
+		de *DecodeError
+		ie validation.InternalError
+	)
+	switch {
+	case errors.As(err, &de):
+		return http.StatusBadRequest
+	case errors.As(err, &ie):
+		return http.StatusInternalServerError
+	}
+	return http.StatusUnprocessableEntity
+}
+
+// Binder writes the error responses of WriteError, Bind and Middleware, as well as of the adapters for web frameworks,
+// such as ginvalidate. The zero value renders the errors with DefaultErrorEnvelope. Set Envelope to use another
+// error format, for example,
+//
+//	binder := httpvalidate.Binder{
+//	    Envelope: func(status int, err error) any {
+//	        return map[string]any{"error": map[string]any{"code": status, "details": err}}
+//	    },
+//	}
+type Binder struct {
+	// Envelope builds the JSON body of the response written for err with the status code returned by ErrorStatus.
+	// DefaultErrorEnvelope is used if it is nil.
+	Envelope func(status int, err error) any
+}
+
+// ErrorBody returns the JSON body of the response for err with the status code returned by ErrorStatus.
+func (b Binder) ErrorBody(status int, err error) any {
+	if b.Envelope == nil {
+		return DefaultErrorEnvelope(status, err)
+	}
+	return b.Envelope(status, err)
+}
+
+// DefaultErrorEnvelope builds an ErrorResponse for err. The message of an internal error is not exposed to the client.
+func DefaultErrorEnvelope(status int, err error) any {
+	resp := ErrorResponse{Message: "validation failed"}
+
+	var (
+		de   *DecodeError
+		errs validation.Errors
+	)
+	switch {
+	case errors.As(err, &de):
+		resp.Message = de.Error()
+	case status == http.StatusInternalServerError:
+		resp.Message = http.StatusText(http.StatusInternalServerError)
+	case errors.As(err, &errs):
+		resp.Errors = errs
+	default:
+		resp.Message = err.Error()
+	}
+	return resp
+}
+
+// WriteError writes the JSON body built by DefaultErrorEnvelope for err, with the status code returned by ErrorStatus.
+// See Binder.WriteError for details.
+func WriteError(w http.ResponseWriter, err error) error {
+	return Binder{}.WriteError(w, err)
+}
+
+// WriteError writes the JSON body built by the envelope for err, with the status code returned by ErrorStatus.
+// The body is encoded before the header is written, so that a body that cannot be encoded is reported to the client
+// as an internal error. The error of the encoding or of writing the response is returned.
+func (b Binder) WriteError(w http.ResponseWriter, err error) error {
+	status := ErrorStatus(err)
+	body, encErr := json.Marshal(b.ErrorBody(status, err))
+	if encErr != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return fmt.Errorf("httpvalidate: encode error response: %w", encErr)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if _, werr := w.Write(append(body, '\n')); werr != nil {
+		return fmt.Errorf("httpvalidate: write error response: %w", werr)
+	}
+	return nil
+}
+
+// Bind decodes and validates the JSON request body with the default Binder. See Binder.Bind for details.
+func Bind(w http.ResponseWriter, r *http.Request, dst any, fields ...validation.FieldRules) error {
+	return Binder{}.Bind(w, r, dst, fields...)
+}
+
+// Bind decodes and validates the JSON request body like DecodeAndValidate. If it fails, the error is written by
+// WriteError and returned, joined with the error of writing the response if any, so that the handler,
+// e.g. of net/http or chi, can simply return. For example,
+//
+//	var req CreateUser
+//	if err := httpvalidate.Bind(w, r, &req, validation.Field(&req.Name, validation.Required)); err != nil {
+//	    return
+//	}
+func (b Binder) Bind(w http.ResponseWriter, r *http.Request, dst any, fields ...validation.FieldRules) error {
+	if err := DecodeAndValidate(r, dst, fields...); err != nil {
+		if werr := b.WriteError(w, err); werr != nil {
+			return errors.Join(err, werr)
+		}
+		return err
+	}
+	return nil
+}
+
+type bodyCtxKeyType struct{}
+
+// Middleware returns a middleware that decodes the JSON request body into a new T and validates it
+// in the same way as DecodeAndValidate. If fields is not nil, it is called with the new T to build
+// the field rules. If the decoding or the validation fails, the error is written by WriteError and
+// the next handler is not called. Otherwise the next handler can get the decoded value by Body.
+// Use MiddlewareWith to write the errors with another Binder.
+// For example,
+//
+//	mw := httpvalidate.Middleware(func(req *CreateUser) []validation.FieldRules {
+//	    return []validation.FieldRules{
+//	        validation.Field(&req.Name, validation.Required),
+//	    }
+//	})
+//	http.Handle("/users", mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//	    req, _ := httpvalidate.Body[CreateUser](r.Context())
+//	    ...
+//	})))
+func Middleware[T any](fields func(dst *T) []validation.FieldRules) func(http.Handler) http.Handler {
+	return MiddlewareWith(Binder{}, fields)
+}
+
+// MiddlewareWith returns a middleware like Middleware, which writes the errors with the Binder b.
+func MiddlewareWith[T any](b Binder, fields func(dst *T) []validation.FieldRules) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dst := new(T)
+			var frs []validation.FieldRules
+			if fields != nil {
+				frs = fields(dst)
+			}
+			if err := b.Bind(w, r, dst, frs...); err != nil {
+				// the error response has been written
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), bodyCtxKeyType{}, dst)))
+		})
+	}
+}
+
+// Body returns the request body decoded and validated by Middleware.
+// False is returned if the context does not carry a body of type T.
+func Body[T any](ctx context.Context) (*T, bool) {
+	dst, ok := ctx.Value(bodyCtxKeyType{}).(*T)
+	return dst, ok
+}
@@ -0,0 +1,168 @@
+package httpvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type createUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type validatableUser struct {
+	Name string `json:"name"`
+}
+
+func (u *validatableUser) Validate(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, u,
+		validation.Field(&u.Name, validation.Required),
+	)
+}
+
+func TestDecodeAndValidate(t *testing.T) {
+	tests := []struct {
+		tag  string
+		body string
+		err  string
+	}{
+		{"t1", `{"name":"john","email":"john@example.com"}`, ""},
+		{"t2", `{"name":"john"}`, "email: cannot be blank."},
+		{"t3", `{"name":`, "invalid request body: unexpected EOF"},
+	}
+	for _, test := range tests {
+		var u createUser
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+		err := DecodeAndValidate(r, &u,
+			validation.Field(&u.Name, validation.Required),
+			validation.Field(&u.Email, validation.Required),
+		)
+		if test.err == "" {
+			assert.NoError(t, err, test.tag)
+		} else {
+			assert.EqualError(t, err, test.err, test.tag)
+		}
+	}
+
+	var de *DecodeError
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`[]`))
+	err := DecodeAndValidate(r, &createUser{})
+	assert.True(t, errors.As(err, &de))
+
+	// without field rules, Validatable is used
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	err = DecodeAndValidate(r, &validatableUser{})
+	assert.EqualError(t, err, "name: cannot be blank.")
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		tag    string
+		err    error
+		status int
+		body   string
+	}{
+		{"t1", validation.Errors{"name": validation.ErrRequired}, http.StatusUnprocessableEntity,
+			`{"message":"validation failed","errors":{"name":"cannot be blank"}}`},
+		{"t2", validation.ErrRequired, http.StatusUnprocessableEntity, `{"message":"cannot be blank"}`},
+		{"t3", &DecodeError{Err: errors.New("bad")}, http.StatusBadRequest, `{"message":"invalid request body: bad"}`},
+		{"t4", validation.NewInternalError(errors.New("db down")), http.StatusInternalServerError,
+			`{"message":"Internal Server Error"}`},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		assert.NoError(t, WriteError(w, test.err), test.tag)
+		assert.Equal(t, test.status, w.Code, test.tag)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"), test.tag)
+		assert.JSONEq(t, test.body, w.Body.String(), test.tag)
+	}
+}
+
+func TestBinder_WriteError(t *testing.T) {
+	b := Binder{Envelope: func(status int, err error) any {
+		return map[string]any{"code": status, "details": err}
+	}}
+	w := httptest.NewRecorder()
+	assert.NoError(t, b.WriteError(w, validation.Errors{"name": validation.ErrRequired}))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"code":422,"details":{"name":"cannot be blank"}}`, w.Body.String())
+
+	// a body that cannot be encoded is reported as an internal error
+	b = Binder{Envelope: func(int, error) any { return make(chan int) }}
+	w = httptest.NewRecorder()
+	err := b.WriteError(w, validation.ErrRequired)
+	var je *json.UnsupportedTypeError
+	assert.True(t, errors.As(err, &je))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestBind(t *testing.T) {
+	var u createUser
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"john"}`))
+	assert.NoError(t, Bind(w, r, &u, validation.Field(&u.Name, validation.Required)))
+	assert.Equal(t, "john", u.Name)
+	assert.Equal(t, 0, w.Body.Len())
+
+	u = createUser{}
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`))
+	err := Bind(w, r, &u, validation.Field(&u.Name, validation.Required))
+	assert.EqualError(t, err, "name: cannot be blank.")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"message":"validation failed","errors":{"name":"cannot be blank"}}`, w.Body.String())
+}
+
+func TestMiddleware(t *testing.T) {
+	mw := Middleware(func(u *createUser) []validation.FieldRules {
+		return []validation.FieldRules{
+			validation.Field(&u.Name, validation.Required),
+		}
+	})
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := Body[createUser](r.Context())
+		assert.True(t, ok)
+		_, ok = Body[validatableUser](r.Context())
+		assert.False(t, ok)
+		_, _ = w.Write([]byte(u.Name))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"john"}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "john", w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{"message":"validation failed","errors":{"name":"cannot be blank"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// without field rules, Validatable is used
+	handler = Middleware[validatableUser](nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the handler should not be called")
+	}))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	// the errors are written with the given Binder
+	b := Binder{Envelope: func(status int, err error) any { return map[string]any{"code": status} }}
+	handler = MiddlewareWith[validatableUser](b, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the handler should not be called")
+	}))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{}`)))
+	assert.JSONEq(t, `{"code":422}`, w.Body.String())
+}
@@ -25,22 +25,31 @@ func In[T any](values ...T) InRule[T] {
 	}
 }
 
+// InFold returns a validation rule that checks if a string can be found in the given list of values,
+// ignoring case, e.g. "ACTIVE" is valid for InFold("active", "inactive"). It is a shortcut for
+// In(values...).StringCompare(StringCompare{FoldCase: true}).
+func InFold(values ...string) InRule[string] {
+	return In(values...).StringCompare(StringCompare{FoldCase: true})
+}
+
 // InRule is a validation rule that validates if a value can be found in the given list of values.
 type InRule[T any] struct {
 	elements []T
 	err      Error
+	compare  *StringCompare
 }
 
 // Validate checks if the given value is valid or not.
 func (r InRule[T]) Validate(ctx context.Context, value interface{}) error {
-	value, isNil := indirectWithOptions(value, GetOptions(ctx))
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
 
-	if isNil || IsEmpty(value) {
+	if isNil || isEmptyWithOptions(value, opts) {
 		return nil
 	}
 
 	for _, e := range r.elements {
-		if reflect.DeepEqual(e, value) {
+		if r.compare != nil && r.compare.equal(e, value) || r.compare == nil && reflect.DeepEqual(e, value) {
 			return nil
 		}
 	}
@@ -48,6 +57,12 @@ func (r InRule[T]) Validate(ctx context.Context, value interface{}) error {
 	return r.err
 }
 
+// StringCompare sets how the strings are compared, e.g. ignoring case.
+func (r InRule[T]) StringCompare(c StringCompare) InRule[T] {
+	r.compare = &c
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r InRule[T]) Error(message string) InRule[T] {
 	r.err = r.err.SetMessage(message)
@@ -59,3 +74,21 @@ func (r InRule[T]) ErrorObject(err Error) InRule[T] {
 	r.err = err
 	return r
 }
+
+// Metadata returns the description of the rule.
+// The kind is "in", with the param "values" holding the allowed values as []interface{}.
+// The param "fold_case" or "normalize" is true if the strings are compared as set by StringCompare.
+func (r InRule[T]) Metadata() RuleInfo {
+	values := make([]interface{}, len(r.elements))
+	for i, e := range r.elements {
+		values[i] = e
+	}
+	params := map[string]interface{}{"values": values}
+	if r.compare != nil {
+		params = r.compare.params(params)
+	}
+	return RuleInfo{
+		Kind:   "in",
+		Params: params,
+	}
+}
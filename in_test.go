@@ -5,6 +5,8 @@
 package validation
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -87,3 +89,29 @@ func TestValidateAgainstList(t *testing.T) {
 	// This is better, no need to convert to interface
 	assert.NoError(t, ValidateWithContext(nil, "a", In(optionsList...)))
 }
+
+func TestInFold(t *testing.T) {
+	type status string
+	tests := []struct {
+		tag   string
+		rule  InRule[string]
+		value interface{}
+		err   string
+	}{
+		{"t1", InFold("active", "inactive"), "ACTIVE", ""},
+		{"t2", InFold("active", "inactive"), "Inactive", ""},
+		{"t3", InFold("active", "inactive"), "deleted", "must be a valid value"},
+		{"t4", InFold("active"), status("Active"), ""},
+		{"t5", InFold("active"), 1, "must be a valid value"},
+		{"t6", In("café").StringCompare(StringCompare{Normalize: strings.TrimSpace}), " café ", ""},
+		{"t7", In("a").StringCompare(StringCompare{}), status("a"), ""},
+		{"t8", In("a"), status("a"), "must be a valid value"},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	assert.Equal(t, RuleInfo{Kind: "in", Params: map[string]interface{}{"values": []interface{}{"a"}, "fold_case": true}}, InFold("a").Metadata())
+}
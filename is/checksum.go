@@ -0,0 +1,140 @@
+package is
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rockcookies/go-validation"
+)
+
+var (
+	// ErrLuhn is the error that returns in case of a number failing the Luhn checksum.
+	ErrLuhn = validation.NewError("validation_is_luhn", "must be a valid Luhn number")
+	// ErrIBAN is the error that returns in case of an invalid IBAN.
+	ErrIBAN = validation.NewError("validation_is_iban", "must be a valid IBAN")
+	// ErrISIN is the error that returns in case of an invalid ISIN.
+	ErrISIN = validation.NewError("validation_is_isin", "must be a valid ISIN")
+	// ErrEAN is the error that returns in case of an invalid EAN.
+	ErrEAN = validation.NewError("validation_is_ean", "must be a valid EAN")
+	// ErrUPC is the error that returns in case of an invalid UPC.
+	ErrUPC = validation.NewError("validation_is_upc", "must be a valid UPC")
+)
+
+var (
+	// Luhn validates if a string is a number whose last digit is a valid Luhn check digit, e.g. a card number or an IMEI
+	Luhn = validation.NewStringRuleWithError(isLuhn, ErrLuhn)
+	// IBAN validates if a string is an International Bank Account Number with the length of its country and a valid
+	// check digits. The groups of the printed format may be separated by spaces, e.g. "GB82 WEST 1234 5698 7654 32"
+	IBAN = validation.NewStringRuleWithError(isIBAN, ErrIBAN)
+	// ISIN validates if a string is an International Securities Identification Number with a valid check digit
+	ISIN = validation.NewStringRuleWithError(isISIN, ErrISIN)
+	// EAN validates if a string is an EAN-8 or EAN-13 barcode number with a valid check digit
+	EAN = validation.NewStringRuleWithError(isEAN, ErrEAN)
+	// UPC validates if a string is a UPC-A barcode number with a valid check digit
+	UPC = validation.NewStringRuleWithError(isUPC, ErrUPC)
+)
+
+// ibanLengths holds the IBAN lengths by country code, as published in the SWIFT IBAN registry.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+func isLuhn(value string) bool {
+	return len(value) > 1 && isDigit(value) && luhn(value)
+}
+
+// luhn reports whether the last digit of the digit string s is its Luhn check digit.
+func luhn(s string) bool {
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		d := int(s[len(s)-1-i] - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func isIBAN(value string) bool {
+	value = strings.ReplaceAll(value, " ", "")
+	if len(value) < 4 || ibanLengths[value[:2]] != len(value) || !isDigit(value[2:4]) {
+		return false
+	}
+
+	// move the country code and check digits to the end, and compute the remainder of the
+	// number formed by replacing the letters with 10 to 35 divided by 97
+	rem := 0
+	for _, c := range value[4:] + value[:4] {
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return rem == 1
+}
+
+func isISIN(value string) bool {
+	if len(value) != 12 || !isUpperAlpha(value[:2]) || !isDigit(value[11:]) {
+		return false
+	}
+
+	// the letters are replaced with 10 to 35 before computing the Luhn checksum
+	var digits strings.Builder
+	for _, c := range value {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			digits.WriteString(strconv.Itoa(int(c-'A') + 10))
+		default:
+			return false
+		}
+	}
+	return luhn(digits.String())
+}
+
+func isEAN(value string) bool {
+	return (len(value) == 8 || len(value) == 13) && isDigit(value) && gtin(value)
+}
+
+func isUPC(value string) bool {
+	return len(value) == 12 && isDigit(value) && gtin(value)
+}
+
+// gtin reports whether the last digit of the digit string s is its GTIN check digit, as used by EAN and UPC,
+// for which the digits are weighted 3 and 1 alternately from the right.
+func gtin(s string) bool {
+	sum := 0
+	for i := 0; i < len(s); i++ {
+		d := int(s[len(s)-1-i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+func isUpperAlpha(value string) bool {
+	for _, c := range value {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package is
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/rockcookies/go-validation"
+)
+
+var (
+	// ErrCIDR is the error that returns in case of an invalid CIDR notation.
+	ErrCIDR = validation.NewError("validation_is_cidr", "must be a valid CIDR notation")
+	// ErrIPRange is the error that returns in case of an IP address outside the allowed ranges.
+	ErrIPRange = validation.NewError("validation_is_ip_range", "must be an IP address within {{.ranges}}")
+	// ErrPortRange is the error that returns in case of an invalid port range.
+	ErrPortRange = validation.NewError("validation_is_port_range", "must be a valid port range")
+	// ErrHostPort is the error that returns in case of an invalid host and port pair.
+	ErrHostPort = validation.NewError("validation_is_host_port", "must be a valid host and port")
+)
+
+var (
+	// CIDR validates if a string is an IPv4 or IPv6 address prefix in CIDR notation, e.g. "10.0.0.0/8"
+	CIDR = validation.NewStringRuleWithError(isCIDR, ErrCIDR)
+	// PortRange validates if a string is a port number or a range of port numbers separated by a hyphen,
+	// e.g. "8080" or "30000-32767", whose first port is not greater than the last one
+	PortRange = validation.NewStringRuleWithError(isPortRange, ErrPortRange)
+	// HostPort validates if a string is a host and a port number separated by a colon, e.g. "example.com:443"
+	// or "[::1]:8080", where the host is an IP address or a DNS name. The host may be empty,
+	// as in the listen address ":8080"
+	HostPort = validation.NewStringRuleWithError(isHostPort, ErrHostPort)
+)
+
+// IPRange returns a validation rule that checks if a string is an IP address within one of the given
+// address ranges in CIDR notation, e.g. is.IPRange("10.0.0.0/8", "192.168.0.0/16").
+// The param "ranges" of the error is set to the ranges joined by ", ".
+// It panics if a range is not a valid CIDR notation.
+func IPRange(cidrs ...string) validation.StringRule {
+	prefixes := make([]netip.Prefix, len(cidrs))
+	for i, cidr := range cidrs {
+		p, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("is: IPRange called with an invalid range: %v", err))
+		}
+		prefixes[i] = p.Masked()
+	}
+
+	return validation.NewStringRuleWithError(func(value string) bool {
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return false
+		}
+		addr = addr.Unmap()
+		for _, p := range prefixes {
+			if p.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}, ErrIPRange.SetParams(map[string]interface{}{"ranges": strings.Join(cidrs, ", ")}))
+}
+
+func isCIDR(value string) bool {
+	_, err := netip.ParsePrefix(value)
+	return err == nil
+}
+
+func isPortRange(value string) bool {
+	first, last, found := strings.Cut(value, "-")
+	if !found {
+		last = first
+	}
+	low, ok := parsePort(first)
+	if !ok {
+		return false
+	}
+	high, ok := parsePort(last)
+	return ok && low <= high
+}
+
+func isHostPort(value string) bool {
+	host, port, err := net.SplitHostPort(value)
+	if err != nil {
+		return false
+	}
+	if _, ok := parsePort(port); !ok {
+		return false
+	}
+	if _, err := netip.ParseAddr(host); err == nil {
+		return true
+	}
+	return host == "" || govalidator.IsDNSName(host)
+}
+
+// parsePort parses a port number between 1 and 65535 written in decimal digits.
+func parsePort(s string) (int, bool) {
+	if !isDigit(s) {
+		return 0, false
+	}
+	port, err := strconv.Atoi(s)
+	return port, err == nil && port >= 1 && port <= 65535
+}
@@ -0,0 +1,36 @@
+package is
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/rockcookies/go-validation"
+)
+
+var (
+	// ErrAbsolutePath is the error that returns in case of a path that is not absolute.
+	ErrAbsolutePath = validation.NewError("validation_is_absolute_path", "must be an absolute path")
+	// ErrCleanPath is the error that returns in case of a path referring to parent directories.
+	ErrCleanPath = validation.NewError("validation_is_clean_path", "must not refer to parent directories")
+)
+
+var (
+	// AbsolutePath validates if a string is an absolute path of the operating system, e.g. "/etc/app.conf"
+	AbsolutePath = validation.NewStringRuleWithError(filepath.IsAbs, ErrAbsolutePath)
+	// CleanPath validates if a string is a path without ".." elements, so that it cannot traverse out of the
+	// directory it is resolved in, e.g. "templates/mail.html" is valid but "../secrets" and "a/../../b" are not.
+	// Both slashes and the path separator of the operating system are treated as separators
+	CleanPath = validation.NewStringRuleWithError(isCleanPath, ErrCleanPath)
+)
+
+func isCleanPath(value string) bool {
+	elems := strings.FieldsFunc(value, func(c rune) bool {
+		return c == '/' || c == filepath.Separator
+	})
+	for _, e := range elems {
+		if e == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package is
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rockcookies/go-validation"
+)
+
+var (
+	// ErrPhoneE164 is the error that returns in case of a phone number not in the E.164 format.
+	ErrPhoneE164 = validation.NewError("validation_is_phone_e164", "must be a valid phone number in E.164 format")
+	// ErrPhone is the error that returns in case of an invalid phone number of a region.
+	ErrPhone = validation.NewError("validation_is_phone", "must be a valid phone number")
+)
+
+// PhoneE164 validates if a string is a phone number in the canonical E.164 format, i.e. a "+" followed by
+// 7 to 15 digits without separators, e.g. "+14155552671". Unlike E164, the leading "+" is required.
+var PhoneE164 = validation.NewStringRuleWithError(isPhoneE164, ErrPhoneE164)
+
+var rePhoneE164 = regexp.MustCompile(`^\+[1-9]\d{6,14}$`)
+
+// phoneRegion describes the phone numbers of a region.
+type phoneRegion struct {
+	// code is the country calling code.
+	code string
+	// trunk is the prefix dialed before national numbers within the region, which is dropped in the international format.
+	trunk string
+	// min and max are the lengths of the national significant numbers.
+	min, max int
+}
+
+// phoneRegions holds the phone number plans by ISO 3166-1 alpha-2 region code.
+var phoneRegions = map[string]phoneRegion{
+	"AR": {"54", "0", 10, 11},
+	"AT": {"43", "0", 4, 13},
+	"AU": {"61", "0", 9, 9},
+	"BE": {"32", "0", 8, 9},
+	"BR": {"55", "0", 10, 11},
+	"CA": {"1", "1", 10, 10},
+	"CH": {"41", "0", 9, 9},
+	"CN": {"86", "0", 7, 11},
+	"CZ": {"420", "", 9, 9},
+	"DE": {"49", "0", 6, 13},
+	"DK": {"45", "", 8, 8},
+	"ES": {"34", "", 9, 9},
+	"FI": {"358", "0", 5, 12},
+	"FR": {"33", "0", 9, 9},
+	"GB": {"44", "0", 9, 10},
+	"GR": {"30", "", 10, 10},
+	"HK": {"852", "", 8, 8},
+	"IE": {"353", "0", 7, 9},
+	"IL": {"972", "0", 8, 9},
+	"IN": {"91", "0", 10, 10},
+	"IT": {"39", "", 6, 11},
+	"JP": {"81", "0", 9, 10},
+	"KR": {"82", "0", 8, 10},
+	"MX": {"52", "", 10, 10},
+	"NL": {"31", "0", 9, 9},
+	"NO": {"47", "", 8, 8},
+	"NZ": {"64", "0", 8, 10},
+	"PL": {"48", "", 9, 9},
+	"PT": {"351", "", 9, 9},
+	"RU": {"7", "8", 10, 10},
+	"SE": {"46", "0", 7, 9},
+	"SG": {"65", "", 8, 8},
+	"TR": {"90", "0", 10, 10},
+	"TW": {"886", "0", 8, 9},
+	"UA": {"380", "0", 9, 9},
+	"US": {"1", "1", 10, 10},
+	"ZA": {"27", "0", 9, 9},
+}
+
+// Phone returns a validation rule that checks if a string is a phone number of the given region, specified by its
+// ISO 3166-1 alpha-2 code, e.g. "GB". The number may be in the international format with the country calling code
+// of the region, e.g. "+44 20 7946 0958", or in the national format, e.g. "020 7946 0958".
+// Spaces, hyphens, dots and parentheses are allowed as separators.
+// The rule checks the lengths of the numbers of the region, but not whether the numbers are assigned.
+// The param "region" of the error is set to the region code.
+// It panics if the region is not supported.
+func Phone(region string) validation.StringRule {
+	region = strings.ToUpper(region)
+	r, ok := phoneRegions[region]
+	if !ok {
+		panic(fmt.Sprintf("is: Phone called with an unsupported region %q", region))
+	}
+	return validation.NewStringRuleWithError(r.valid, ErrPhone.SetParams(map[string]interface{}{"region": region}))
+}
+
+func isPhoneE164(value string) bool {
+	return rePhoneE164.MatchString(value)
+}
+
+// valid reports whether value is a phone number of the region in the international or national format.
+func (r phoneRegion) valid(value string) bool {
+	number := strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(value)
+	if strings.HasPrefix(number, "+") {
+		if !strings.HasPrefix(number, "+"+r.code) {
+			return false
+		}
+		number = number[len(r.code)+1:]
+	} else if len(number) > r.max {
+		// the trunk prefix is only recognized if the number is too long without it, because
+		// the national significant numbers of some regions may start with the digits of the prefix
+		number = strings.TrimPrefix(number, r.trunk)
+	}
+	return len(number) >= r.min && len(number) <= r.max && isDigit(number)
+}
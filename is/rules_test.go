@@ -99,3 +99,188 @@ func assertError(t *testing.T, expected string, err error, tag string) {
 		assert.Equal(t, expected, err.Error(), tag)
 	}
 }
+
+func TestChecksums(t *testing.T) {
+	tests := []struct {
+		tag   string
+		rule  validation.Rule
+		value string
+		err   string
+	}{
+		{"Luhn1", Luhn, "79927398713", ""},
+		{"Luhn2", Luhn, "4111111111111111", ""},
+		{"Luhn3", Luhn, "79927398710", "must be a valid Luhn number"},
+		{"Luhn4", Luhn, "0", "must be a valid Luhn number"},
+		{"Luhn5", Luhn, "7992 7398 713", "must be a valid Luhn number"},
+		{"IBAN1", IBAN, "GB82WEST12345698765432", ""},
+		{"IBAN2", IBAN, "GB82 WEST 1234 5698 7654 32", ""},
+		{"IBAN3", IBAN, "DE89370400440532013000", ""},
+		{"IBAN4", IBAN, "NO9386011117947", ""},
+		{"IBAN5", IBAN, "GB82WEST12345698765431", "must be a valid IBAN"},
+		{"IBAN6", IBAN, "GB82WEST1234569876543", "must be a valid IBAN"},
+		{"IBAN7", IBAN, "XX82WEST12345698765432", "must be a valid IBAN"},
+		{"IBAN8", IBAN, "gb82west12345698765432", "must be a valid IBAN"},
+		{"IBAN9", IBAN, "GB8", "must be a valid IBAN"},
+		{"ISIN1", ISIN, "US0378331005", ""},
+		{"ISIN2", ISIN, "AU0000XVGZA3", ""},
+		{"ISIN3", ISIN, "GB0002634946", ""},
+		{"ISIN4", ISIN, "US0378331006", "must be a valid ISIN"},
+		{"ISIN5", ISIN, "US037833100", "must be a valid ISIN"},
+		{"ISIN6", ISIN, "120378331005", "must be a valid ISIN"},
+		{"EAN1", EAN, "4006381333931", ""},
+		{"EAN2", EAN, "96385074", ""},
+		{"EAN3", EAN, "4006381333932", "must be a valid EAN"},
+		{"EAN4", EAN, "036000291452", "must be a valid EAN"},
+		{"UPC1", UPC, "036000291452", ""},
+		{"UPC2", UPC, "036000291453", "must be a valid UPC"},
+		{"UPC3", UPC, "03600029145a", "must be a valid UPC"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestPhone(t *testing.T) {
+	tests := []struct {
+		tag   string
+		rule  validation.Rule
+		value string
+		err   string
+	}{
+		{"t1", PhoneE164, "+14155552671", ""},
+		{"t2", PhoneE164, "+442079460958", ""},
+		{"t3", PhoneE164, "14155552671", "must be a valid phone number in E.164 format"},
+		{"t4", PhoneE164, "+1 415 555 2671", "must be a valid phone number in E.164 format"},
+		{"t5", PhoneE164, "+0123456789", "must be a valid phone number in E.164 format"},
+		{"t6", PhoneE164, "+1234567890123456", "must be a valid phone number in E.164 format"},
+		{"t7", Phone("GB"), "+44 20 7946 0958", ""},
+		{"t8", Phone("GB"), "020 7946 0958", ""},
+		{"t9", Phone("gb"), "07911 123456", ""},
+		{"t10", Phone("GB"), "+1 415 555 2671", "must be a valid phone number"},
+		{"t11", Phone("GB"), "020 7946", "must be a valid phone number"},
+		{"t12", Phone("US"), "(415) 555-2671", ""},
+		{"t13", Phone("US"), "1-415-555-2671", ""},
+		{"t14", Phone("US"), "+1.415.555.2671", ""},
+		{"t15", Phone("US"), "415-555-267", "must be a valid phone number"},
+		{"t16", Phone("US"), "415-555-267a", "must be a valid phone number"},
+		{"t17", Phone("DE"), "030 123456", ""},
+		{"t18", Phone("DE"), "+49 30 123456", ""},
+		{"t19", Phone("RU"), "8 800 123 4567", ""},
+		{"t20", Phone("RU"), "800 123 4567", ""},
+		{"t21", Phone("IT"), "+39 06 1234 5678", ""},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := Phone("FR").Validate(nil, "123")
+	if e, ok := err.(validation.Error); assert.True(t, ok) {
+		assert.Equal(t, "validation_is_phone", e.Code())
+		assert.Equal(t, map[string]interface{}{"region": "FR"}, e.Params())
+	}
+	assert.PanicsWithValue(t, `is: Phone called with an unsupported region "XX"`, func() { Phone("xx") })
+}
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		tag   string
+		rule  validation.Rule
+		value string
+		err   string
+	}{
+		{"t1", AbsolutePath, "/etc/app.conf", ""},
+		{"t2", AbsolutePath, "etc/app.conf", "must be an absolute path"},
+		{"t3", AbsolutePath, "./app.conf", "must be an absolute path"},
+		{"t4", CleanPath, "templates/mail.html", ""},
+		{"t5", CleanPath, "/var/lib/./app", ""},
+		{"t6", CleanPath, "a..b/c..", ""},
+		{"t7", CleanPath, "../secrets", "must not refer to parent directories"},
+		{"t8", CleanPath, "a/../../b", "must not refer to parent directories"},
+		{"t9", CleanPath, "a/..", "must not refer to parent directories"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	private := IPRange("10.0.0.0/8", "192.168.0.0/16", "fd00::/8")
+
+	tests := []struct {
+		tag   string
+		rule  validation.Rule
+		value string
+		err   string
+	}{
+		{"t1", CIDR, "10.0.0.0/8", ""},
+		{"t2", CIDR, "2001:db8::/32", ""},
+		{"t3", CIDR, "10.0.0.1/24", ""},
+		{"t4", CIDR, "10.0.0.0", "must be a valid CIDR notation"},
+		{"t5", CIDR, "10.0.0.0/33", "must be a valid CIDR notation"},
+		{"t6", private, "10.1.2.3", ""},
+		{"t7", private, "192.168.1.1", ""},
+		{"t8", private, "::ffff:192.168.1.1", ""},
+		{"t9", private, "fd12::1", ""},
+		{"t10", private, "8.8.8.8", "must be an IP address within 10.0.0.0/8, 192.168.0.0/16, fd00::/8"},
+		{"t11", private, "example.com", "must be an IP address within 10.0.0.0/8, 192.168.0.0/16, fd00::/8"},
+		{"t12", PortRange, "8080", ""},
+		{"t13", PortRange, "30000-32767", ""},
+		{"t14", PortRange, "80-80", ""},
+		{"t15", PortRange, "443-80", "must be a valid port range"},
+		{"t16", PortRange, "0-80", "must be a valid port range"},
+		{"t17", PortRange, "80-65536", "must be a valid port range"},
+		{"t18", PortRange, "80-", "must be a valid port range"},
+		{"t19", PortRange, "+80", "must be a valid port range"},
+		{"t20", HostPort, "example.com:443", ""},
+		{"t21", HostPort, "10.0.0.1:8080", ""},
+		{"t22", HostPort, "[::1]:8080", ""},
+		{"t23", HostPort, ":8080", ""},
+		{"t24", HostPort, "example.com", "must be a valid host and port"},
+		{"t25", HostPort, "example.com:http", "must be a valid host and port"},
+		{"t26", HostPort, "example.com:0", "must be a valid host and port"},
+		{"t27", HostPort, "exa mple.com:80", "must be a valid host and port"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	assert.Panics(t, func() { IPRange("10.0.0.0") })
+}
+
+func TestTokens(t *testing.T) {
+	const (
+		header  = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"
+		payload = "eyJzdWIiOiIxMjM0NTY3ODkwIiwibmFtZSI6IkpvaG4gRG9lIiwiaWF0IjoxNTE2MjM5MDIyfQ"
+		sig     = "SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c"
+	)
+
+	tests := []struct {
+		tag   string
+		rule  validation.Rule
+		value string
+		err   string
+	}{
+		{"t1", Base64URL, "SflKxwRJSMeKKF2QT4fwpMeJf36POk6yJV_adQssw5c", ""},
+		{"t2", Base64URL, "YWJjZA==", ""},
+		{"t3", Base64URL, "YWJjZA", ""},
+		{"t4", Base64URL, "YWJjZA=", "must be encoded in Base64URL"},
+		{"t5", Base64URL, "a+b/", "must be encoded in Base64URL"},
+		{"t6", JWT, header + "." + payload + "." + sig, ""},
+		{"t7", JWT, header + "." + payload + ".", ""},
+		{"t8", JWT, header + "." + payload, "must be a valid JWT"},
+		{"t9", JWT, header + "." + payload + "." + sig + ".x", "must be a valid JWT"},
+		{"t10", JWT, header + ".WzFd." + sig, "must be a valid JWT"},
+		{"t11", JWT, header + ".bnVsbA." + sig, "must be a valid JWT"},
+		{"t12", JWT, header + "." + payload + ".a+b", "must be a valid JWT"},
+		{"t13", JWT, "abc." + payload + "." + sig, "must be a valid JWT"},
+		{"t14", JWT, header + "=." + payload + "." + sig, "must be a valid JWT"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
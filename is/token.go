@@ -0,0 +1,57 @@
+package is
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/rockcookies/go-validation"
+)
+
+var (
+	// ErrBase64URL is the error that returns in case of an invalid base64url value.
+	ErrBase64URL = validation.NewError("validation_is_base64_url", "must be encoded in Base64URL")
+	// ErrJWT is the error that returns in case of an invalid JWT.
+	ErrJWT = validation.NewError("validation_is_jwt", "must be a valid JWT")
+)
+
+var (
+	// Base64URL validates if a string is encoded in the URL and filename safe Base64 alphabet (RFC 4648),
+	// with or without padding
+	Base64URL = validation.NewStringRuleWithError(isBase64URL, ErrBase64URL)
+	// JWT validates if a string is structurally a JSON Web Token in the compact serialization, i.e. three
+	// Base64URL segments separated by dots, whose header and payload are JSON objects.
+	// It does not verify the signature, which may be empty for an unsecured token
+	JWT = validation.NewStringRuleWithError(isJWT, ErrJWT)
+)
+
+func isBase64URL(value string) bool {
+	enc := base64.RawURLEncoding
+	if strings.HasSuffix(value, "=") {
+		enc = base64.URLEncoding
+	}
+	_, err := enc.Strict().DecodeString(value)
+	return err == nil
+}
+
+func isJWT(value string) bool {
+	header, rest, _ := strings.Cut(value, ".")
+	payload, signature, found := strings.Cut(rest, ".")
+	if !found || strings.Contains(signature, ".") {
+		return false
+	}
+	if _, err := base64.RawURLEncoding.Strict().DecodeString(signature); err != nil {
+		return false
+	}
+	return isJSONObject(header) && isJSONObject(payload)
+}
+
+// isJSONObject reports whether segment is a JSON object encoded in Base64URL without padding.
+func isJSONObject(segment string) bool {
+	b, err := base64.RawURLEncoding.Strict().DecodeString(segment)
+	if err != nil {
+		return false
+	}
+	var obj map[string]json.RawMessage
+	return json.Unmarshal(b, &obj) == nil && obj != nil
+}
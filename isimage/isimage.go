@@ -0,0 +1,206 @@
+// Package isimage provides validation rules for uploaded images, such as avatars and banners.
+//
+// The rules decode the image header only, with image.DecodeConfig, to get the format and dimensions of an image,
+// which can be a *multipart.FileHeader, an io.Reader or a byte slice. The content read from an io.Reader is rewound
+// if it is an io.Seeker, and consumed otherwise. The GIF, JPEG and PNG formats are registered by this package.
+// Register other formats by importing their packages, e.g. golang.org/x/image/webp.
+package isimage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	_ "image/gif"  // register the GIF format
+	_ "image/jpeg" // register the JPEG format
+	_ "image/png"  // register the PNG format
+	"io"
+	"mime/multipart"
+	"reflect"
+
+	"github.com/rockcookies/go-validation"
+)
+
+var _ validation.Rule = (*ImageRule)(nil)
+
+var (
+	// ErrImageInvalid is the error that returns when a value is not an image of a registered format.
+	ErrImageInvalid = validation.NewError("validation_image_invalid", "must be a valid image")
+	// ErrImageTooLarge is the error that returns when an image is wider or taller than allowed.
+	ErrImageTooLarge = validation.NewError("validation_image_too_large", "the image must be no larger than {{.width}}x{{.height}} pixels")
+	// ErrImageTooSmall is the error that returns when an image is narrower or shorter than allowed.
+	ErrImageTooSmall = validation.NewError("validation_image_too_small", "the image must be at least {{.width}}x{{.height}} pixels")
+	// ErrImageAspectRatio is the error that returns when an image does not have the required aspect ratio.
+	ErrImageAspectRatio = validation.NewError("validation_image_aspect_ratio", "the image aspect ratio must be {{.width}}:{{.height}}")
+	// ErrImageFormat is the error that returns when an image is not of an allowed format.
+	ErrImageFormat = validation.NewError("validation_image_format", "must be an image of an allowed format")
+)
+
+// MaxDimensions returns a validation rule that checks if an image is no wider than width and no taller than height
+// pixels. A zero width or height means the dimension is not limited. An empty value is considered valid.
+func MaxDimensions(width, height int) ImageRule {
+	params := map[string]interface{}{"width": width, "height": height}
+	return ImageRule{
+		kind:   "image_max_dimensions",
+		params: params,
+		check: func(c image.Config, _ string) bool {
+			return (width == 0 || c.Width <= width) && (height == 0 || c.Height <= height)
+		},
+		err: ErrImageTooLarge.SetParams(params),
+	}
+}
+
+// MinDimensions returns a validation rule that checks if an image is at least width pixels wide and height pixels
+// tall. An empty value is considered valid.
+func MinDimensions(width, height int) ImageRule {
+	params := map[string]interface{}{"width": width, "height": height}
+	return ImageRule{
+		kind:   "image_min_dimensions",
+		params: params,
+		check: func(c image.Config, _ string) bool {
+			return c.Width >= width && c.Height >= height
+		},
+		err: ErrImageTooSmall.SetParams(params),
+	}
+}
+
+// AspectRatio returns a validation rule that checks if the aspect ratio of an image is exactly width:height,
+// e.g. AspectRatio(1, 1) for a square avatar or AspectRatio(16, 9) for a banner. An empty value is considered valid.
+func AspectRatio(width, height int) ImageRule {
+	params := map[string]interface{}{"width": width, "height": height}
+	return ImageRule{
+		kind:   "image_aspect_ratio",
+		params: params,
+		check: func(c image.Config, _ string) bool {
+			return c.Width*height == c.Height*width
+		},
+		err: ErrImageAspectRatio.SetParams(params),
+	}
+}
+
+// Format returns a validation rule that checks if an image is of one of the given formats, as named by
+// image.DecodeConfig, e.g. "png", "jpeg" and "gif". An empty value is considered valid.
+func Format(formats ...string) ImageRule {
+	return ImageRule{
+		kind:   "image_format",
+		params: map[string]interface{}{"formats": formats},
+		check: func(_ image.Config, format string) bool {
+			for _, f := range formats {
+				if f == format {
+					return true
+				}
+			}
+			return false
+		},
+		err: ErrImageFormat,
+	}
+}
+
+// ImageRule is a validation rule that checks the format or the dimensions of an image.
+// A value that cannot be decoded as an image of a registered format is invalid with ErrImageInvalid.
+// An error opening a *multipart.FileHeader or reading an io.Reader is returned as an InternalError.
+type ImageRule struct {
+	kind   string
+	params map[string]interface{}
+	check  func(c image.Config, format string) bool
+	err    validation.Error
+}
+
+// Validate checks if the given value is valid or not.
+func (r ImageRule) Validate(ctx context.Context, value interface{}) error {
+	var src io.Reader
+	switch v := value.(type) {
+	case *multipart.FileHeader:
+		if v == nil {
+			return nil
+		}
+		f, err := v.Open()
+		if err != nil {
+			return validation.NewInternalError(err)
+		}
+		defer f.Close()
+		src = f
+	case io.Reader:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+		src = v
+	default:
+		value, isNil := validation.Indirect(value)
+		if isNil || validation.IsEmpty(value) {
+			return nil
+		}
+		b, ok := value.([]byte)
+		if !ok {
+			return errors.New("must be a *multipart.FileHeader, an io.Reader or a byte slice")
+		}
+		src = bytes.NewReader(b)
+	}
+
+	c, format, readErr, err := decodeConfig(src)
+	if readErr != nil {
+		return validation.NewInternalError(readErr)
+	} else if err != nil {
+		return ErrImageInvalid
+	}
+	if !r.check(c, format) {
+		return r.err
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r ImageRule) Error(message string) ImageRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r ImageRule) ErrorObject(err validation.Error) ImageRule {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "image_max_dimensions", "image_min_dimensions" or "image_aspect_ratio" with the params "width" and
+// "height", or "image_format" with the param "formats".
+func (r ImageRule) Metadata() validation.RuleInfo {
+	return validation.RuleInfo{Kind: r.kind, Params: r.params}
+}
+
+// errorReader records the first error of a reader other than io.EOF, which image.DecodeConfig may report
+// as an unknown format.
+type errorReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *errorReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
+
+// decodeConfig decodes the header of the image read from src, and rewinds src if it is an io.Seeker.
+// The error reading src, if any, is returned as readErr, and the error decoding the image as err.
+func decodeConfig(src io.Reader) (c image.Config, format string, readErr, err error) {
+	s, seeker := src.(io.Seeker)
+	var pos int64
+	if seeker {
+		if pos, readErr = s.Seek(0, io.SeekCurrent); readErr != nil {
+			return
+		}
+	}
+
+	er := &errorReader{r: src}
+	c, format, err = image.DecodeConfig(er)
+	readErr = er.err
+	if seeker {
+		if _, serr := s.Seek(pos, io.SeekStart); readErr == nil {
+			readErr = serr
+		}
+	}
+	return
+}
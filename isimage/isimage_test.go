@@ -0,0 +1,116 @@
+package isimage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePNG(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+func encodeGIF(width, height int) []byte {
+	var buf bytes.Buffer
+	_ = gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, width, height), nil), nil)
+	return buf.Bytes()
+}
+
+func newFileHeader(t *testing.T, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fw, err := w.CreateFormFile("file", "image.png")
+	assert.NoError(t, err)
+	_, _ = fw.Write(content)
+	assert.NoError(t, w.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	_, fh, err := r.FormFile("file")
+	assert.NoError(t, err)
+	return fh
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+func TestImageRule(t *testing.T) {
+	square := encodePNG(100, 100)
+	banner := encodePNG(160, 90)
+	var nilHeader *multipart.FileHeader
+
+	tests := []struct {
+		tag   string
+		rule  ImageRule
+		value interface{}
+		err   string
+	}{
+		{"t1", MaxDimensions(100, 100), square, ""},
+		{"t2", MaxDimensions(50, 0), square, "the image must be no larger than 50x0 pixels"},
+		{"t3", MaxDimensions(0, 100), banner, ""},
+		{"t4", MinDimensions(100, 100), square, ""},
+		{"t5", MinDimensions(100, 100), banner, "the image must be at least 100x100 pixels"},
+		{"t6", AspectRatio(1, 1), square, ""},
+		{"t7", AspectRatio(16, 9), banner, ""},
+		{"t8", AspectRatio(16, 9), square, "the image aspect ratio must be 16:9"},
+		{"t9", Format("png", "jpeg"), square, ""},
+		{"t10", Format("jpeg"), square, "must be an image of an allowed format"},
+		{"t11", Format("gif"), encodeGIF(10, 10), ""},
+		{"t12", MaxDimensions(100, 100), []byte("not an image"), "must be a valid image"},
+		{"t13", MaxDimensions(100, 100), nil, ""},
+		{"t14", MaxDimensions(100, 100), []byte{}, ""},
+		{"t15", MaxDimensions(100, 100), nilHeader, ""},
+		{"t16", MaxDimensions(100, 100), newFileHeader(t, square), ""},
+		{"t17", AspectRatio(1, 1), newFileHeader(t, banner), "the image aspect ratio must be 1:1"},
+		{"t18", AspectRatio(16, 9), bytes.NewReader(banner), ""},
+		{"t19", AspectRatio(16, 9), "image.png", "must be a *multipart.FileHeader, an io.Reader or a byte slice"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		if test.err == "" {
+			assert.NoError(t, err, test.tag)
+		} else if assert.Error(t, err, test.tag) {
+			assert.Equal(t, test.err, err.Error(), test.tag)
+		}
+	}
+}
+
+func TestImageRule_Reader(t *testing.T) {
+	content := encodePNG(10, 10)
+	r := bytes.NewReader(content)
+	assert.NoError(t, validation.Validate(r, Format("png"), MaxDimensions(10, 10)))
+	read, _ := io.ReadAll(r)
+	assert.Equal(t, content, read)
+
+	err := MaxDimensions(10, 10).Validate(context.Background(), failingReader{})
+	var ie validation.InternalError
+	assert.True(t, errors.As(err, &ie))
+}
+
+func TestImageRule_Error(t *testing.T) {
+	r := Format("png").Error("123")
+	assert.Equal(t, "123", r.err.Message())
+	assert.Equal(t, ErrImageFormat.Code(), r.err.Code())
+
+	err := validation.NewError("code", "abc")
+	r = r.ErrorObject(err)
+	assert.Equal(t, err, r.err)
+
+	assert.Equal(t, validation.RuleInfo{Kind: "image_aspect_ratio", Params: map[string]interface{}{"width": 16, "height": 9}}, AspectRatio(16, 9).Metadata())
+}
@@ -0,0 +1,106 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+var _ Rule = (*ItemsRule)(nil)
+
+var (
+	// ErrItemsTooFew is the error that returns when a collection has fewer items than the minimum.
+	ErrItemsTooFew = NewError("validation_items_too_few", "must have at least {{.min}} items")
+	// ErrItemsTooMany is the error that returns when a collection has more items than the maximum.
+	ErrItemsTooMany = NewError("validation_items_too_many", "must have at most {{.max}} items")
+	// ErrItemsInvalid is the error that returns when a collection does not have the exact number of items.
+	ErrItemsInvalid = NewError("validation_items_invalid", "must have exactly {{.min}} items")
+	// ErrItemsOutOfRange is the error that returns when the number of items of a collection is out of range.
+	ErrItemsOutOfRange = NewError("validation_items_out_of_range", "must have between {{.min}} and {{.max}} items")
+)
+
+// MinItems returns a validation rule that checks if a slice, array or map has at least min items.
+// Unlike Length, an empty collection is checked, so that MinItems(1) rejects an empty list in a payload.
+// A nil value is considered valid. Use the Required rule to make sure a value is not nil.
+func MinItems(min int) ItemsRule {
+	return ItemsBetween(min, -1)
+}
+
+// MaxItems returns a validation rule that checks if a slice, array or map has at most max items.
+// MaxItems(0) requires the collection to be empty. A nil value is considered valid.
+func MaxItems(max int) ItemsRule {
+	return ItemsBetween(0, max)
+}
+
+// ItemsBetween returns a validation rule that checks if the number of items of a slice, array or map
+// is between min and max, inclusive. A negative max means no upper bound. Unlike Length, an empty collection
+// is checked. A nil value is considered valid. Use the Required rule to make sure a value is not nil.
+func ItemsBetween(min, max int) ItemsRule {
+	r := ItemsRule{min: min, max: max, params: true}
+	switch {
+	case max < 0:
+		r.err = ErrItemsTooFew
+	case min <= 0:
+		r.err = ErrItemsTooMany
+	case min == max:
+		r.err = ErrItemsInvalid
+	default:
+		r.err = ErrItemsOutOfRange
+	}
+	return r
+}
+
+// ItemsRule is a validation rule that checks if the number of items of a collection is within the specified range.
+type ItemsRule struct {
+	err Error
+	// params indicates that the min and max params are set on err when the validation fails.
+	params bool
+
+	min, max int
+}
+
+// Validate checks if the given value is valid or not.
+func (r ItemsRule) Validate(ctx context.Context, value interface{}) error {
+	value, isNil := indirectWithOptions(value, GetOptions(ctx))
+	if isNil {
+		return nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return errors.New("must be a slice, an array or a map")
+	}
+
+	if l := v.Len(); l < r.min || r.max >= 0 && l > r.max {
+		if r.params {
+			return r.err.SetParams(map[string]interface{}{"min": r.min, "max": r.max})
+		}
+		return r.err
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r ItemsRule) Error(message string) ItemsRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r ItemsRule) ErrorObject(err Error) ItemsRule {
+	r.err = err
+	r.params = false
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "items", with the param "min", and the param "max" unless there is no upper bound.
+func (r ItemsRule) Metadata() RuleInfo {
+	params := map[string]interface{}{"min": r.min}
+	if r.max >= 0 {
+		params["max"] = r.max
+	}
+	return RuleInfo{Kind: "items", Params: params}
+}
@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItems(t *testing.T) {
+	var nilSlice []int
+	tests := []struct {
+		tag   string
+		rule  ItemsRule
+		value interface{}
+		err   string
+	}{
+		{"t1", MinItems(1), nil, ""},
+		{"t2", MinItems(1), nilSlice, ""},
+		{"t3", MinItems(1), []int{}, "must have at least 1 items"},
+		{"t4", MinItems(2), []int{1, 2}, ""},
+		{"t5", MaxItems(2), []int{1, 2, 3}, "must have at most 2 items"},
+		{"t6", MaxItems(0), map[string]int{"a": 1}, "must have at most 0 items"},
+		{"t7", MaxItems(0), map[string]int{}, ""},
+		{"t8", ItemsBetween(2, 2), [1]int{1}, "must have exactly 2 items"},
+		{"t9", ItemsBetween(1, 3), &[]int{1, 2, 3, 4}, "must have between 1 and 3 items"},
+		{"t10", ItemsBetween(1, 3), []int{1, 2, 3}, ""},
+		{"t11", MinItems(1), "abc", "must be a slice, an array or a map"},
+		{"t12", MinItems(1).Error("needs {{.min}} tags"), []int{}, "needs 1 tags"},
+		{"t13", MinItems(1).ErrorObject(NewError("code", "abc")), []int{}, "abc"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := MinItems(2).Validate(context.Background(), []int{1})
+	if assert.Error(t, err) {
+		e := err.(Error)
+		assert.Equal(t, "validation_items_too_few", e.Code())
+		assert.Equal(t, map[string]interface{}{"min": 2, "max": -1}, e.Params())
+	}
+}
@@ -0,0 +1,35 @@
+package validation
+
+import "encoding/json"
+
+// ErrJSONInvalid is the error that returns when a string is not valid JSON.
+var ErrJSONInvalid = NewError("validation_json_invalid", "must be valid JSON")
+
+// JSONString returns a validation rule that checks if a string or a byte slice contains valid JSON,
+// and validates the decoded value with the given rules. The value is decoded in the same way
+// as json.Unmarshal into an interface{}, so a JSON object is validated as a map[string]interface{} and
+// numbers as float64. For example,
+//
+//	validation.Field(&p.Metadata, validation.JSONString(
+//	    validation.Map(
+//	        validation.Key("owner", validation.Required, is.EmailFormat),
+//	        validation.Key("priority", validation.Min(1.0)).Optional(),
+//	    ).AllowExtraKeys(),
+//	))
+//
+// The errors of the decoded content are nested under the field, e.g. "metadata.owner" when flattened.
+// Note that the values of json.RawMessage are already decoded by DefaultValuer, so they can be validated
+// with the rules directly.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func JSONString(rules ...Rule) CoerceRule {
+	return CoerceRule{
+		kind: "json_string",
+		parse: func(s string) (interface{}, error) {
+			var v interface{}
+			err := json.Unmarshal([]byte(s), &v)
+			return v, err
+		},
+		rules: rules,
+		err:   ErrJSONInvalid,
+	}
+}
@@ -0,0 +1,44 @@
+package validation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestJSONString(t *testing.T) {
+	meta := JSONString(Map(
+		Key("owner", Required),
+		Key("priority", Min(1.0)).Optional(),
+	).AllowExtraKeys())
+
+	tests := []struct {
+		tag   string
+		rule  Rule
+		value interface{}
+		err   string
+	}{
+		{"t1", JSONString(), "", ""},
+		{"t2", JSONString(), `{"a": [1, 2]}`, ""},
+		{"t3", JSONString(), `"abc"`, ""},
+		{"t4", JSONString(), `{"a": `, "must be valid JSON"},
+		{"t5", JSONString(), []byte(`[1,]`), "must be valid JSON"},
+		{"t6", meta, `{"owner": "a", "priority": 2, "extra": true}`, ""},
+		{"t7", meta, `{"owner": "", "priority": 0.5}`, "owner: cannot be blank; priority: must be no less than 1."},
+		{"t8", meta, []byte(`{"priority": 2}`), "owner: required key is missing."},
+		{"t9", meta, `[1]`, "only a map can be validated"},
+		{"t10", JSONString(Each(Min(2.0))), `[1, 2, 3]`, "0: must be no less than 2."},
+		{"t11", JSONString().Error("must be a JSON document"), `x`, "must be a JSON document"},
+		{"t12", JSONString(), 123, "must be either a string or byte slice"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	var s struct {
+		Metadata string `json:"metadata"`
+	}
+	s.Metadata = `{"owner": ""}`
+	err := ValidateStruct(&s, Field(&s.Metadata, meta))
+	assertError(t, "metadata: (owner: cannot be blank.).", err, "t13")
+}
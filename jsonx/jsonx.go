@@ -0,0 +1,102 @@
+// Package jsonx provides JSON helpers for validating partial updates.
+package jsonx
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/rockcookies/go-validation"
+)
+
+// PresenceSet records the keys present in a JSON document by their paths, which are the keys
+// and array indexes joined by dots, e.g. "name", "address.street" or "items.0.sku".
+// The paths follow the same format as validation.FieldPath, so a PresenceSet can enable the
+// partial validation mode with Partial.
+type PresenceSet struct {
+	// paths maps the present paths to whether their values are null.
+	paths map[string]bool
+}
+
+// Has reports whether the key at the given path is present, including when its value is null.
+func (s PresenceSet) Has(path string) bool {
+	_, ok := s.paths[path]
+	return ok
+}
+
+// IsNull reports whether the key at the given path is present with an explicit null value.
+func (s PresenceSet) IsNull(path string) bool {
+	return s.paths[path]
+}
+
+// Paths returns the present paths, which can be passed to validation.WithPartial.
+func (s PresenceSet) Paths() map[string]bool {
+	paths := make(map[string]bool, len(s.paths))
+	for path := range s.paths {
+		paths[path] = true
+	}
+	return paths
+}
+
+// Partial returns the option that enables the partial validation mode for the present paths.
+// The fields absent from the JSON document are not validated, while a field that is explicitly null
+// is validated, so Required reports it as blank and NilOrNotEmpty accepts it if it is a pointer.
+func (s PresenceSet) Partial() validation.Option {
+	return validation.WithPartial(s.Paths())
+}
+
+// RejectUnknown returns the option that reports the present fields not covered by the field rules of the
+// validated structs, which is useful for strict APIs that reject unexpected properties.
+// It can be combined with Partial.
+func (s PresenceSet) RejectUnknown() validation.Option {
+	return validation.WithRejectUnknownFields(s.Paths())
+}
+
+// UnmarshalTracked decodes the JSON data into v like json.Unmarshal, and records the keys present in the data.
+// It is typically used to validate partial updates:
+//
+//	present, err := jsonx.UnmarshalTracked(body, &user)
+//	if err != nil {
+//	    return err
+//	}
+//	ctx = validation.WithOptions(ctx, present.Partial())
+//	err = user.Validate(ctx)
+func UnmarshalTracked(data []byte, v interface{}) (PresenceSet, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return PresenceSet{}, err
+	}
+
+	var doc interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&doc); err != nil {
+		return PresenceSet{}, err
+	}
+
+	s := PresenceSet{paths: map[string]bool{}}
+	s.record("", doc)
+	return s, nil
+}
+
+// record records the paths of the keys and elements within value, which is found at prefix.
+func (s PresenceSet) record(prefix string, value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			s.add(prefix, key, v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			s.add(prefix, strconv.Itoa(i), v)
+		}
+	}
+}
+
+func (s PresenceSet) add(prefix, key string, value interface{}) {
+	path := key
+	if prefix != "" {
+		path = prefix + "." + key
+	}
+	s.paths[path] = value == nil
+	s.record(path, value)
+}
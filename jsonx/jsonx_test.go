@@ -0,0 +1,129 @@
+package jsonx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type user struct {
+	Name     *string   `json:"name"`
+	Email    string    `json:"email"`
+	Address  *address  `json:"address"`
+	Tags     []string  `json:"tags"`
+	Contacts []address `json:"contacts"`
+}
+
+func (u *user) Validate(ctx context.Context) error {
+	return validation.ValidateStructWithContext(ctx, u,
+		validation.Field(&u.Name, validation.NilOrNotEmpty),
+		validation.Field(&u.Email, validation.Required),
+		validation.Field(&u.Address, validation.Required),
+		validation.Field(&u.Contacts, validation.Dive(
+			validation.NamedField("Street", validation.Required),
+		)),
+	)
+}
+
+func TestUnmarshalTracked(t *testing.T) {
+	var u user
+	s, err := UnmarshalTracked([]byte(`{"name": null, "email": "", "address": {"city": "x"}, "tags": ["a"], "contacts": [{"street": null}]}`), &u)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "x", u.Address.City)
+
+	tests := []struct {
+		tag  string
+		path string
+		has  bool
+		null bool
+	}{
+		{"t1", "name", true, true},
+		{"t2", "email", true, false},
+		{"t3", "address", true, false},
+		{"t4", "address.city", true, false},
+		{"t5", "address.street", false, false},
+		{"t6", "tags.0", true, false},
+		{"t7", "contacts.0.street", true, true},
+		{"t8", "missing", false, false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.has, s.Has(test.path), test.tag)
+		assert.Equal(t, test.null, s.IsNull(test.path), test.tag)
+	}
+	assert.Equal(t, map[string]bool{
+		"name": true, "email": true, "address": true, "address.city": true,
+		"tags": true, "tags.0": true, "contacts": true, "contacts.0": true, "contacts.0.street": true,
+	}, s.Paths())
+}
+
+func TestUnmarshalTracked_Errors(t *testing.T) {
+	var u user
+	_, err := UnmarshalTracked([]byte(`{"name": `), &u)
+	assert.Error(t, err)
+
+	_, err = UnmarshalTracked([]byte(`{"email": 1}`), &u)
+	assert.Error(t, err)
+}
+
+func TestPresenceSet_Partial(t *testing.T) {
+	tests := []struct {
+		tag  string
+		data string
+		err  string
+	}{
+		{"t1", `{}`, ""},
+		{"t2", `{"name": null}`, ""},
+		{"t3", `{"name": ""}`, "name: cannot be blank."},
+		{"t4", `{"email": null}`, "email: cannot be blank."},
+		{"t5", `{"address": null}`, "address: cannot be blank."},
+		{"t6", `{"contacts": [{"street": "a"}, {"street": null}]}`, "contacts: (1: (street: cannot be blank.).)."},
+		{"t7", `{"contacts": [{"street": "a"}, {}]}`, ""},
+	}
+	for _, test := range tests {
+		var u user
+		s, err := UnmarshalTracked([]byte(test.data), &u)
+		if !assert.NoError(t, err, test.tag) {
+			continue
+		}
+		err = u.Validate(validation.WithOptions(context.Background(), s.Partial()))
+		if test.err == "" {
+			assert.NoError(t, err, test.tag)
+		} else {
+			assert.EqualError(t, err, test.err, test.tag)
+		}
+	}
+}
+
+func TestPresenceSet_RejectUnknown(t *testing.T) {
+	tests := []struct {
+		tag  string
+		data string
+		err  string
+	}{
+		{"t1", `{"email": "a", "address": {"city": "x"}}`, ""},
+		{"t2", `{"email": "a", "address": {"city": "x"}, "tags": [], "admin": true}`, "_unknown: (admin: key not expected; tags: key not expected.)."},
+		{"t3", `{"email": "a", "address": {"city": "x"}, "contacts": [{"street": "a", "city": "b"}]}`, "contacts: (0: (_unknown: (city: key not expected.).).)."},
+	}
+	for _, test := range tests {
+		var u user
+		s, err := UnmarshalTracked([]byte(test.data), &u)
+		if !assert.NoError(t, err, test.tag) {
+			continue
+		}
+		err = u.Validate(validation.WithOptions(context.Background(), s.Partial(), s.RejectUnknown()))
+		if test.err == "" {
+			assert.NoError(t, err, test.tag)
+		} else {
+			assert.EqualError(t, err, test.err, test.tag)
+		}
+	}
+}
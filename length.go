@@ -29,7 +29,7 @@ var (
 // This rule should only be used for validating strings, slices, maps, and arrays.
 // An empty value is considered valid. Use the Required rule to make sure a value is not empty.
 func Length(min, max int) LengthRule {
-	return LengthRule{min: min, max: max, err: buildLengthRuleError(min, max)}
+	return LengthRule{min: min, max: max, err: buildLengthRuleError(min, max), params: true}
 }
 
 // RuneLength returns a validation rule that checks if a string's rune length is within the specified range.
@@ -44,18 +44,40 @@ func RuneLength(min, max int) LengthRule {
 	return r
 }
 
+// GraphemeLength returns a validation rule that checks if the number of user-perceived characters
+// (grapheme clusters) of a string is within the specified range, so that an emoji made of several runes,
+// such as a flag or a family emoji, or a letter followed by combining accents counts as one character.
+// It is useful for validating display names. If max is 0, it means there is no upper bound for the length.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+// If the value being validated is not a string, the rule works the same as Length.
+func GraphemeLength(min, max int) LengthRule {
+	r := Length(min, max)
+	r.grapheme = true
+
+	return r
+}
+
 // LengthRule is a validation rule that checks if a value's length is within the specified range.
 type LengthRule struct {
 	err Error
+	// params indicates that the min and max params are set on err when the validation fails,
+	// so that creating the rule does not allocate the params.
+	params bool
 
 	min, max int
 	rune     bool
+	grapheme bool
+	trim     bool
 }
 
 // Validate checks if the given value is valid or not.
 func (r LengthRule) Validate(ctx context.Context, value interface{}) error {
-	value, isNil := indirectWithOptions(value, GetOptions(ctx))
-	if isNil || IsEmpty(value) {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if r.trim {
+		value = trimSpace(value)
+	}
+	if isNil || isEmptyWithOptions(value, opts) {
 		return nil
 	}
 
@@ -63,19 +85,31 @@ func (r LengthRule) Validate(ctx context.Context, value interface{}) error {
 		l   int
 		err error
 	)
-	if s, ok := value.(string); ok && r.rune {
+	if s, ok := value.(string); ok && r.grapheme {
+		l = graphemeCount(s)
+	} else if s, ok := value.(string); ok && r.rune {
 		l = utf8.RuneCountInString(s)
 	} else if l, err = LengthOfValue(value); err != nil {
 		return err
 	}
 
 	if r.min > 0 && l < r.min || r.max > 0 && l > r.max || r.min == 0 && r.max == 0 && l > 0 {
+		if r.params {
+			return r.err.SetParams(map[string]interface{}{"min": r.min, "max": r.max})
+		}
 		return r.err
 	}
 
 	return nil
 }
 
+// TrimSpace makes the rule check the value without its leading and trailing white space.
+// It is useful for free-form input, where a value of spaces only must not pass. See also WithTrimSpace.
+func (r LengthRule) TrimSpace() LengthRule {
+	r.trim = true
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r LengthRule) Error(message string) LengthRule {
 	r.err = r.err.SetMessage(message)
@@ -85,9 +119,22 @@ func (r LengthRule) Error(message string) LengthRule {
 // ErrorObject sets the error struct for the rule.
 func (r LengthRule) ErrorObject(err Error) LengthRule {
 	r.err = err
+	r.params = false
 	return r
 }
 
+// Metadata returns the description of the rule.
+// The kind is "length", with the params "min", "max" and "rune". The param "grapheme" is true
+// if the rule is created by GraphemeLength.
+func (r LengthRule) Metadata() RuleInfo {
+	params := map[string]interface{}{"min": r.min, "max": r.max, "rune": r.rune}
+	if r.grapheme {
+		params["grapheme"] = true
+	}
+	return RuleInfo{Kind: "length", Params: params}
+}
+
+// buildLengthRuleError returns the error of the length range. The params are set when the validation fails.
 func buildLengthRuleError(min, max int) (err Error) {
 	if min == 0 && max > 0 {
 		err = ErrLengthTooLong
@@ -103,5 +150,5 @@ func buildLengthRuleError(min, max int) (err Error) {
 		err = ErrLengthEmptyRequired
 	}
 
-	return err.SetParams(map[string]interface{}{"min": min, "max": max})
+	return err
 }
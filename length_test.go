@@ -79,6 +79,41 @@ func TestRuneLength(t *testing.T) {
 	}
 }
 
+func TestGraphemeLength(t *testing.T) {
+	tests := []struct {
+		tag      string
+		min, max int
+		value    interface{}
+		err      string
+	}{
+		{"t1", 2, 4, "abc", ""},
+		{"t2", 2, 4, "", ""},
+		{"t3", 2, 4, "abcde", "the length must be between 2 and 4"},
+		{"t4", 1, 1, "💥", ""},
+		{"t5", 1, 1, "👍🏽", ""},                  // skin tone modifier
+		{"t6", 1, 1, "👨‍👩‍👧‍👦", ""},             // ZWJ sequence
+		{"t7", 1, 1, "🇯🇵", ""},                  // flag
+		{"t8", 2, 2, "🇯🇵🇫🇷", ""},                // two flags
+		{"t9", 2, 2, "🇯🇵🇫", ""},                 // a flag and a lone indicator
+		{"t10", 1, 1, "e\u0301", ""},            // combining accent
+		{"t11", 1, 1, "❤️", ""},                 // variation selector
+		{"t12", 2, 2, "한국", ""},                 // precomposed Hangul
+		{"t13", 1, 1, "\u1100\u1161\u11A8", ""}, // Hangul jamo
+		{"t14", 1, 1, "\r\n", ""},
+		{"t15", 2, 2, "\n\r", ""},
+		{"t16", 2, 2, "a\u200d👍", ""}, // ZWJ after a letter
+		{"t17", 0, 3, "Zoë 👩‍💻", "the length must be no more than 3"},
+		{"t18", 2, 0, []int{1}, "the length must be no less than 2"},
+		{"t19", 2, 4, &sql.NullString{String: "👍🏽👍🏽", Valid: true}, ""},
+	}
+
+	for _, test := range tests {
+		r := GraphemeLength(test.min, test.max)
+		err := r.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
 func Test_LengthRule_Error(t *testing.T) {
 	r := Length(10, 20)
 	assert.Equal(t, "the length must be between 10 and 20", r.Validate(nil, "abc").Error())
@@ -0,0 +1,161 @@
+package validation
+
+import (
+	"context"
+	"strings"
+)
+
+var (
+	_ Rule = (*AndRule)(nil)
+	_ Rule = (*OrRule)(nil)
+	_ Rule = (*NotRule)(nil)
+)
+
+var (
+	// ErrOrInvalid is the error that returns when none of the rules of Or passes.
+	// Unless a message is set by OrRule.Error, the message is made of the messages of the rules joined by "or".
+	ErrOrInvalid = NewError("validation_or_invalid", "must satisfy at least one of the rules")
+	// ErrNotInvalid is the error that returns when the rule of Not passes.
+	ErrNotInvalid = NewError("validation_not_invalid", "is invalid")
+)
+
+// And returns a validation rule that passes if all the given rules pass. The rules are applied in order,
+// and the error of the first failing rule is returned. It is useful for grouping rules within Or and Not.
+func And(rules ...Rule) AndRule {
+	return AndRule{rules: rules}
+}
+
+// AndRule is a validation rule that passes if all of its rules pass.
+type AndRule struct {
+	rules []Rule
+}
+
+// Validate checks if the value passes all the rules.
+func (r AndRule) Validate(ctx context.Context, value interface{}) error {
+	return ValidateWithContext(ctx, value, r.rules...)
+}
+
+// Metadata returns the description of the rule.
+// The kind is "and", with the param "rules" holding the rules as []Rule.
+func (r AndRule) Metadata() RuleInfo {
+	return RuleInfo{Kind: "and", Params: map[string]interface{}{"rules": r.rules}}
+}
+
+// Or returns a validation rule that passes if any of the given rules passes. For example,
+//
+//	validation.Or(is.Email, is.E164)
+//
+// If none of the rules passes, ErrOrInvalid is returned with the messages of the rules joined by "or",
+// e.g. "must be a valid email address or must be a valid E164 number". Internal errors are returned as they are.
+func Or(rules ...Rule) OrRule {
+	return OrRule{rules: rules, err: ErrOrInvalid}
+}
+
+// OrRule is a validation rule that passes if any of its rules passes.
+type OrRule struct {
+	rules []Rule
+	err   Error
+	// custom indicates that the error is set by Error or ErrorObject and the messages are not joined.
+	custom bool
+}
+
+// Error sets the error message for the rule, replacing the joined messages of the rules.
+func (r OrRule) Error(message string) OrRule {
+	r.err = r.err.SetMessage(message)
+	r.custom = true
+	return r
+}
+
+// ErrorObject sets the error struct for the rule, replacing the joined messages of the rules.
+func (r OrRule) ErrorObject(err Error) OrRule {
+	r.err = err
+	r.custom = true
+	return r
+}
+
+// Validate checks if the value passes any of the rules.
+func (r OrRule) Validate(ctx context.Context, value interface{}) error {
+	if len(r.rules) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(r.rules))
+	for _, rule := range r.rules {
+		err := ValidateWithContext(ctx, value, rule)
+		if err == nil {
+			return nil
+		}
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return err
+		}
+		messages = append(messages, err.Error())
+	}
+
+	if r.custom {
+		return r.err
+	}
+	return r.err.SetMessage(strings.Join(messages, " or "))
+}
+
+// Metadata returns the description of the rule.
+// The kind is "or", with the param "rules" holding the rules as []Rule.
+func (r OrRule) Metadata() RuleInfo {
+	return RuleInfo{Kind: "or", Params: map[string]interface{}{"rules": r.rules}}
+}
+
+// Not returns a validation rule that passes if the given rule fails, and fails with the given message otherwise.
+// For example,
+//
+//	validation.Not(validation.In("admin", "root"), "must not be a reserved name")
+//
+// Internal errors of the rule are returned as they are.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func Not(rule Rule, message string) NotRule {
+	r := NotRule{rule: rule, err: ErrNotInvalid}
+	if message != "" {
+		r.err = r.err.SetMessage(message)
+	}
+	return r
+}
+
+// NotRule is a validation rule that passes if its rule fails.
+type NotRule struct {
+	rule Rule
+	err  Error
+}
+
+// Error sets the error message for the rule.
+func (r NotRule) Error(message string) NotRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r NotRule) ErrorObject(err Error) NotRule {
+	r.err = err
+	return r
+}
+
+// Validate checks if the value fails the rule.
+func (r NotRule) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	v, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(v, opts) {
+		return nil
+	}
+
+	err := ValidateWithContext(ctx, value, r.rule)
+	if err == nil {
+		return r.err
+	}
+	if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+		return err
+	}
+	return nil
+}
+
+// Metadata returns the description of the rule.
+// The kind is "not", with the param "rules" holding the rule as []Rule.
+func (r NotRule) Metadata() RuleInfo {
+	return RuleInfo{Kind: "not", Params: map[string]interface{}{"rules": []Rule{r.rule}}}
+}
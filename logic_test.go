@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicRules(t *testing.T) {
+	internal := By(func(context.Context, interface{}) error { return NewInternalError(errors.New("internal")) })
+	var nilString *string
+
+	tests := []struct {
+		tag   string
+		rule  Rule
+		value interface{}
+		err   string
+	}{
+		{"t1", And(), "abc", ""},
+		{"t2", And(Length(1, 5), In("abc")), "abc", ""},
+		{"t3", And(Length(1, 5), In("xyz")), "abc", "must be a valid value"},
+		{"t4", And(Length(5, 10), In("xyz")), "abc", "the length must be between 5 and 10"},
+		{"t5", Or(), "abc", ""},
+		{"t6", Or(In("xyz"), Length(1, 5)), "abc", ""},
+		{"t7", Or(In("xyz"), Length(5, 10)), "abc", "must be a valid value or the length must be between 5 and 10"},
+		{"t8", Or(In("xyz"), Length(5, 10)).Error("invalid"), "abc", "invalid"},
+		{"t9", Or(In("xyz"), internal), "abc", "internal"},
+		{"t10", Or(And(Length(1, 2), In("ab")), In("abc")), "abc", ""},
+		{"t11", Not(In("admin", "root"), "must not be a reserved name"), "admin", "must not be a reserved name"},
+		{"t12", Not(In("admin", "root"), "must not be a reserved name"), "alice", ""},
+		{"t13", Not(In("admin"), ""), "admin", "is invalid"},
+		{"t14", Not(In("admin"), "reserved"), "", ""},
+		{"t15", Not(In("admin"), "reserved"), nilString, ""},
+		{"t16", Not(internal, "reserved"), "abc", "internal"},
+		{"t17", Not(Or(In("a"), In("b")), "must be neither a nor b").Error("reserved"), "b", "reserved"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := Or(In("xyz"), In("abc")).Validate(context.Background(), "123")
+	assert.ErrorIs(t, err, ErrOrInvalid)
+
+	e := NewError("code", "abc")
+	assert.ErrorIs(t, Or(In("xyz")).ErrorObject(e).Validate(context.Background(), "123"), e)
+	assert.ErrorIs(t, Not(In("xyz"), "").ErrorObject(e).Validate(context.Background(), "xyz"), e)
+}
+
+func TestLogicRules_Metadata(t *testing.T) {
+	assert.Equal(t, RuleInfo{Kind: "and", Params: map[string]interface{}{"rules": []Rule{Required}}}, And(Required).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "or", Params: map[string]interface{}{"rules": []Rule{Required}}}, Or(Required).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "not", Params: map[string]interface{}{"rules": []Rule{Required}}}, Not(Required, "x").Metadata())
+}
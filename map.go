@@ -36,6 +36,7 @@ type (
 	KeyRules struct {
 		key      interface{}
 		optional bool
+		multi    bool
 		rules    []Rule
 	}
 )
@@ -56,6 +57,23 @@ func Map(keys ...*KeyRules) MapRule {
 	return MapRule{distinctKeys: keys}
 }
 
+// Keys returns a validation rule that checks every key of a map with the given rules.
+// It is a shortcut for Map().AllowExtraKeys().Keys(rules...), and can be combined with Values:
+//
+//	validation.Keys(validation.Match(labelRegexp)).Values(validation.Length(1, 63))
+//
+// A nil value is considered valid. Use the Required rule to make sure a map value is present.
+func Keys(rules ...Rule) MapRule {
+	return Map().AllowExtraKeys().Keys(rules...)
+}
+
+// Values returns a validation rule that checks every value of a map with the given rules.
+// It is a shortcut for Map().AllowExtraKeys().Values(rules...).
+// A nil value is considered valid. Use the Required rule to make sure a map value is present.
+func Values(rules ...Rule) MapRule {
+	return Map().AllowExtraKeys().Values(rules...)
+}
+
 // AllowExtraKeys configures the rule to ignore extra keys.
 func (r MapRule) AllowExtraKeys() MapRule {
 	r.allowExtraKeys = true
@@ -74,6 +92,26 @@ func (r MapRule) Values(rules ...Rule) MapRule {
 	return r
 }
 
+// Metadata returns the description of the rule.
+// The kind is "map", with the params "key_rules" and "value_rules" holding the rules for all keys and
+// values as []Rule, "keys" holding the distinct keys as []map[string]interface{} with "key", "optional"
+// and "rules", and "allow_extra_keys".
+func (r MapRule) Metadata() RuleInfo {
+	keys := make([]map[string]interface{}, len(r.distinctKeys))
+	for i, k := range r.distinctKeys {
+		keys[i] = map[string]interface{}{"key": k.key, "optional": k.optional, "rules": k.rules}
+	}
+	return RuleInfo{
+		Kind: "map",
+		Params: map[string]interface{}{
+			"key_rules":        r.keys,
+			"value_rules":      r.values,
+			"keys":             keys,
+			"allow_extra_keys": r.allowExtraKeys,
+		},
+	}
+}
+
 // Validate checks if the given value is valid or not.
 func (r MapRule) Validate(ctx context.Context, m interface{}) error {
 	value := reflect.ValueOf(m)
@@ -166,6 +204,31 @@ func (r MapRule) Validate(ctx context.Context, m interface{}) error {
 	return nil
 }
 
+// ValidateMap validates a map with the given key rules in the same way as ValidateStruct validates a struct.
+// It is the same as ValidateMapWithContext with a background context.
+func ValidateMap(m map[string]interface{}, keys ...*KeyRules) error {
+	return ValidateMapWithContext(context.Background(), m, keys...)
+}
+
+// ValidateMapWithContext validates a map, such as a decoded JSON object, with the given key rules and context.
+// It allows a payload to be validated without decoding it into a struct first, which would lose the unknown keys.
+// For example,
+//
+//	err := validation.ValidateMapWithContext(ctx, payload,
+//	    validation.Key("event", validation.Required, validation.In("created", "deleted")),
+//	    validation.Key("data", validation.Required, validation.Map(
+//	        validation.Key("id", validation.Required),
+//	        validation.Key("note", validation.Length(0, 200)).Optional(),
+//	    )),
+//	)
+//
+// A missing key is reported with ErrKeyMissing unless Optional is called on the key rules, and a key without
+// rules is reported with ErrKeyUnexpected. Nested maps are validated with a Map rule of the key, as shown above.
+// The errors are reported in the same way as Map.
+func ValidateMapWithContext(ctx context.Context, m map[string]interface{}, keys ...*KeyRules) error {
+	return Map(keys...).Validate(ctx, m)
+}
+
 // Key specifies a map key and the corresponding validation rules.
 func Key(key interface{}, rules ...Rule) *KeyRules {
 	return &KeyRules{
@@ -180,6 +243,13 @@ func (r *KeyRules) Optional() *KeyRules {
 	return r
 }
 
+// Multi configures ValidateForm to validate all values of the key as []string instead of only the first value.
+// It has no effect on Map.
+func (r *KeyRules) Multi() *KeyRules {
+	r.multi = true
+	return r
+}
+
 // getErrorKeyName returns the name that should be used to represent the validation error of a map key.
 func getErrorKeyName(key interface{}) string {
 	return fmt.Sprintf("%v", key)
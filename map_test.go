@@ -2,6 +2,7 @@ package validation
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -127,3 +128,70 @@ func TestMapWithContext(t *testing.T) {
 		assert.Equal(t, "Extra: key not expected; Value: the length must be between 5 and 10.", err.Error())
 	}
 }
+
+func TestValidateMap(t *testing.T) {
+	payload := map[string]interface{}{
+		"event": "created",
+		"data":  map[string]interface{}{"id": "", "extra": 1},
+		"debug": true,
+	}
+	tests := []struct {
+		tag   string
+		m     map[string]interface{}
+		rules []*KeyRules
+		err   string
+	}{
+		{"t1", nil, []*KeyRules{Key("event", Required)}, ""},
+		{"t2", payload, []*KeyRules{Key("event", In("created")), Key("debug"), Key("data")}, ""},
+		{"t3", payload, []*KeyRules{Key("event", In("deleted")), Key("data")}, "debug: key not expected; event: must be a valid value."},
+		{"t4", payload, []*KeyRules{Key("event"), Key("debug"), Key("data", Map(Key("id", Required), Key("note").Optional()))}, "data: (extra: key not expected; id: cannot be blank.)."},
+		{"t5", payload, []*KeyRules{Key("event"), Key("debug"), Key("data"), Key("id")}, "id: required key is missing."},
+		{"t6", payload, []*KeyRules{Key("event"), Key("debug"), Key("data"), Key("id").Optional()}, ""},
+	}
+	for _, test := range tests {
+		err := ValidateMap(test.m, test.rules...)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := ValidateMapWithContext(context.Background(), map[string]interface{}{"event": "internal"}, Key("event", &validateInternalError{}))
+	assert.EqualError(t, err, "error internal")
+}
+
+func TestKeysAndValues(t *testing.T) {
+	labels := map[string]string{"app": "web", "Tier": "frontend", "env": ""}
+	counts := map[string]int{"a": 1, "b": 0, "c": 20}
+	var nilMap map[string]string
+
+	tests := []struct {
+		tag   string
+		value interface{}
+		rule  Rule
+		err   string
+	}{
+		{"t1.1", labels, Keys(lowerCaseRule()), "Tier: must be in lower case."},
+		{"t1.2", labels, Keys(Length(1, 5)), ""},
+		{"t1.3", labels, Values(Required), "env: cannot be blank."},
+		{"t1.4", labels, Keys(lowerCaseRule()).Values(Required), "Tier: must be in lower case; env: cannot be blank."},
+		{"t2.1", counts, Values(Min(1), Max(10)), "c: must be no greater than 10."},
+		{"t2.2", counts, Values(Required), "b: cannot be blank."},
+		{"t3.1", nilMap, Keys(Required), ""},
+		{"t3.2", &labels, Values(Length(0, 10)), ""},
+		{"t3.3", "abc", Keys(Required), ErrNotMap.Error()},
+	}
+
+	for _, test := range tests {
+		err := ValidateWithContext(context.Background(), test.value, test.rule)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	s := struct {
+		Labels map[string]string `json:"labels"`
+	}{Labels: labels}
+	err := ValidateStruct(&s, Field(&s.Labels, Values(Required)))
+	assert.EqualError(t, err, "labels: (env: cannot be blank.).")
+}
+
+// lowerCaseRule returns a test rule that checks if a string is in lower case.
+func lowerCaseRule() StringRule {
+	return NewStringRule(func(s string) bool { return strings.ToLower(s) == s }, "must be in lower case")
+}
@@ -24,18 +24,31 @@ func Match(re *regexp.Regexp) MatchRule {
 
 // MatchRule is a validation rule that checks if a value matches the specified regular expression.
 type MatchRule struct {
-	re  *regexp.Regexp
-	err Error
+	re   *regexp.Regexp
+	err  Error
+	trim bool
 }
 
 // Validate checks if the given value is valid or not.
 func (r MatchRule) Validate(ctx context.Context, value interface{}) error {
-	value, isNil := indirectWithOptions(value, GetOptions(ctx))
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
 	if isNil {
 		return nil
 	}
+	if r.trim {
+		value = trimSpace(value)
+	}
 
 	isString, str, isBytes, bs := StringOrBytes(value)
+	if !isString && !isBytes && opts.StringerConversion() {
+		var err error
+		if str, err = stringWithOptions(value, opts); err == nil {
+			isString = true
+		} else if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return err
+		}
+	}
 	if isString && (str == "" || r.re.MatchString(str)) {
 		return nil
 	} else if isBytes && (len(bs) == 0 || r.re.Match(bs)) {
@@ -44,6 +57,13 @@ func (r MatchRule) Validate(ctx context.Context, value interface{}) error {
 	return r.err
 }
 
+// TrimSpace makes the rule check the value without its leading and trailing white space.
+// It is useful for free-form input, where a value of spaces only must not pass. See also WithTrimSpace.
+func (r MatchRule) TrimSpace() MatchRule {
+	r.trim = true
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r MatchRule) Error(message string) MatchRule {
 	r.err = r.err.SetMessage(message)
@@ -55,3 +75,12 @@ func (r MatchRule) ErrorObject(err Error) MatchRule {
 	r.err = err
 	return r
 }
+
+// Metadata returns the description of the rule.
+// The kind is "match", with the param "pattern" holding the regular expression.
+func (r MatchRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "match",
+		Params: map[string]interface{}{"pattern": r.re.String()},
+	}
+}
@@ -0,0 +1,119 @@
+package validation
+
+import (
+	"context"
+	"sync"
+)
+
+var _ Rule = (*MemoizedRule)(nil)
+
+// RuleCacheScope specifies how long the results of the rules wrapped by Memoize are cached.
+type RuleCacheScope int
+
+// Available rule cache scopes.
+const (
+	// RuleCacheNone disables the cache, so that the memoized rules are always evaluated. It is the default.
+	RuleCacheNone RuleCacheScope = iota
+	// RuleCachePass caches the results for one validation pass, i.e. one call of a validation function,
+	// such as ValidateStructWithContext or ValidateAll, including the nested structs and elements it validates.
+	RuleCachePass
+	// RuleCacheContext caches the results for all validations with the context the option is set on,
+	// e.g. the context of a request.
+	RuleCacheContext
+)
+
+type ruleCacheCtxKeyType struct{}
+
+var ruleCacheCtxKey = ruleCacheCtxKeyType{}
+
+// Memoize returns a rule that caches the results of the given rule by value, so that a value validated many times,
+// e.g. the same country code appearing in 500 rows, runs the rule only once. The results are cached in the scope set
+// by the WithRuleCache option, and the rule is always evaluated without it. For example,
+//
+//	country := validation.Memoize(validation.By(lookupCountry))
+//	ctx = validation.WithOptions(ctx, validation.WithRuleCache(validation.RuleCachePass))
+//	result, err := validation.ValidateAll(ctx, rows, validation.NewSchema(validation.Spec("Country", country)))
+//
+// Only the rules whose result depends on the value alone should be memoized, unlike those depending on the context,
+// such as the rules calling Parent or FieldPath. The values must be comparable to be cached; pointers are cached by
+// address. Internal errors are not cached.
+func Memoize(rule Rule) MemoizedRule {
+	return MemoizedRule{rule: rule, id: new(memoID)}
+}
+
+// MemoizedRule is a validation rule that caches the results of another rule by value.
+type MemoizedRule struct {
+	rule Rule
+	// id identifies the rule in the cache.
+	id *memoID
+}
+
+// memoID is the identity of a MemoizedRule, whose copies share the cached results.
+type memoID struct {
+	// make the size non-zero so that every memoID has a distinct address
+	_ byte
+}
+
+// memoKey identifies the result of a memoized rule for a value.
+type memoKey struct {
+	id    *memoID
+	value interface{}
+}
+
+// ruleCache holds the results of the memoized rules.
+type ruleCache struct {
+	results sync.Map
+}
+
+// Validate checks if the given value is valid or not.
+func (r MemoizedRule) Validate(ctx context.Context, value interface{}) error {
+	cache := ruleCacheFrom(ctx)
+	if cache == nil || !isComparable(value) {
+		return r.rule.Validate(ctx, value)
+	}
+
+	key := memoKey{id: r.id, value: value}
+	if err, ok := cache.results.Load(key); ok {
+		if err == nil {
+			return nil
+		}
+		return err.(error)
+	}
+
+	err := r.rule.Validate(ctx, value)
+	if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+		return err
+	}
+	cache.results.Store(key, err)
+	return err
+}
+
+// Metadata returns the description of the memoized rule, or an empty RuleInfo if it does not implement Describer.
+func (r MemoizedRule) Metadata() RuleInfo {
+	if d, ok := r.rule.(Describer); ok {
+		return d.Metadata()
+	}
+	return RuleInfo{}
+}
+
+// startRuleCache returns a context holding a new rule cache if the RuleCachePass scope is set and the context
+// does not hold one yet, i.e. at the start of a validation pass.
+func startRuleCache(ctx context.Context) context.Context {
+	if getOpts(ctx).ruleCacheScope != RuleCachePass || ctx.Value(ruleCacheCtxKey) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ruleCacheCtxKey, &ruleCache{})
+}
+
+// ruleCacheFrom returns the rule cache of the context, or nil if the results must not be cached.
+func ruleCacheFrom(ctx context.Context) *ruleCache {
+	opts := getOpts(ctx)
+	switch opts.ruleCacheScope {
+	case RuleCacheContext:
+		return opts.ruleCache
+	case RuleCachePass:
+		cache, _ := ctx.Value(ruleCacheCtxKey).(*ruleCache)
+		return cache
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoRow struct {
+	Country string
+}
+
+func TestMemoize(t *testing.T) {
+	var calls int
+	country := Memoize(By(func(ctx context.Context, value interface{}) error {
+		calls++
+		if value != "US" && value != "FR" {
+			return errors.New("unknown country")
+		}
+		return nil
+	}))
+	rows := []memoRow{{"US"}, {"FR"}, {"US"}, {"XX"}, {"US"}, {"XX"}}
+	schema := NewSchema(Spec("Country", country))
+
+	tests := []struct {
+		tag   string
+		scope RuleCacheScope
+		calls int
+	}{
+		{"t1", RuleCacheNone, 6},
+		{"t2", RuleCachePass, 3},
+		{"t3", RuleCacheContext, 3},
+	}
+	for _, test := range tests {
+		calls = 0
+		ctx := WithOptions(context.Background(), WithRuleCache(test.scope))
+		result, err := ValidateAll(ctx, rows, schema)
+		assert.NoError(t, err, test.tag)
+		assert.Equal(t, []int{3, 5}, batchIndexes(result), test.tag)
+		assert.Equal(t, test.calls, calls, test.tag)
+
+		// a second pass
+		calls = 0
+		assert.Error(t, ValidateWithContext(ctx, rows, Each(By(func(ctx context.Context, value interface{}) error {
+			return country.Validate(ctx, value.(memoRow).Country)
+		}))), test.tag)
+		switch test.scope {
+		case RuleCacheContext:
+			assert.Equal(t, 0, calls, test.tag)
+		case RuleCachePass:
+			assert.Equal(t, 3, calls, test.tag)
+		default:
+			assert.Equal(t, 6, calls, test.tag)
+		}
+	}
+}
+
+func TestMemoize_InternalError(t *testing.T) {
+	var calls int
+	r := Memoize(By(func(ctx context.Context, value interface{}) error {
+		calls++
+		return NewInternalError(errors.New("db down"))
+	}))
+	ctx := WithOptions(context.Background(), WithRuleCache(RuleCacheContext))
+	assert.Error(t, r.Validate(ctx, "a"))
+	assert.Error(t, r.Validate(ctx, "a"))
+	assert.Equal(t, 2, calls)
+
+	// values that are not comparable are not cached
+	calls = 0
+	r = Memoize(By(func(ctx context.Context, value interface{}) error {
+		calls++
+		return nil
+	}))
+	assert.NoError(t, r.Validate(ctx, []string{"a"}))
+	assert.NoError(t, r.Validate(ctx, []string{"a"}))
+	assert.Equal(t, 2, calls)
+	calls = 0
+	assert.NoError(t, r.Validate(ctx, struct{ V interface{} }{V: []int{1}}))
+	assert.NoError(t, r.Validate(ctx, struct{ V interface{} }{V: []int{1}}))
+	assert.Equal(t, 2, calls)
+	calls = 0
+	assert.NoError(t, r.Validate(ctx, struct{ V interface{} }{V: 1}))
+	assert.NoError(t, r.Validate(ctx, struct{ V interface{} }{V: 1}))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, Length(1, 2).Metadata(), Memoize(Length(1, 2)).Metadata())
+	assert.Equal(t, RuleInfo{}, Memoize(By(nil)).Metadata())
+}
@@ -0,0 +1,236 @@
+package validation
+
+import (
+	"errors"
+	"reflect"
+)
+
+// RuleInfo describes the constraint that a rule imposes on a value.
+type RuleInfo struct {
+	// Kind identifies the constraint, e.g. "required", "length" or "in".
+	// An empty Kind means the rule currently imposes no constraint.
+	Kind string `json:"kind"`
+	// Params holds the parameters of the constraint, e.g. "min" and "max" for "length".
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+// FieldInfo describes the constraints on a struct field.
+type FieldInfo struct {
+	// Name is the name of the field as used in the validation errors.
+	Name string `json:"name"`
+	// Rules describes the rules of the field in order.
+	Rules []RuleInfo `json:"rules"`
+}
+
+// Describer is implemented by rules that can describe their constraints.
+// It is used by tools that generate documents from validation rules, such as JSON Schema.
+type Describer interface {
+	// Metadata returns the description of the constraint imposed by the rule.
+	Metadata() RuleInfo
+}
+
+var (
+	_ Describer = RequiredRule{}
+	_ Describer = LengthRule{}
+	_ Describer = InRule[any]{}
+	_ Describer = NotInRule[any]{}
+	_ Describer = ThresholdRule{}
+	_ Describer = MatchRule{}
+	_ Describer = MultipleOfRule{}
+	_ Describer = UniqueRule{}
+	_ Describer = ItemsRule{}
+	_ Describer = EnumRule[int]{}
+	_ Describer = FileRule{}
+	_ Describer = UploadRule{}
+	_ Describer = LookupRule{}
+	_ Describer = MemoizedRule{}
+	_ Describer = URLRule{}
+	_ Describer = SubsetOfRule[any]{}
+	_ Describer = ContainsAllRule[any]{}
+	_ Describer = DigitsRule{}
+	_ Describer = OrderedRule[int]{}
+	_ Describer = TimeRule{}
+	_ Describer = DurationRule{}
+	_ Describer = AndRule{}
+	_ Describer = OrRule{}
+	_ Describer = NotRule{}
+	_ Describer = NormalizeRule{}
+	_ Describer = DefaultRule{}
+	_ Describer = DateRule{}
+	_ Describer = StringRule{}
+	_ Describer = FieldCompareRule{}
+	_ Describer = RequiredWhenFieldRule{}
+	_ Describer = EachRule{}
+	_ Describer = DiveRule{}
+	_ Describer = MapRule{}
+	_ Describer = WhenRule{}
+	_ Describer = TimeoutRule{}
+	_ Describer = CoerceRule{}
+	_ Describer = absentRule{}
+	_ Describer = notNilRule{}
+	_ Describer = skipRule{}
+	_ Describer = pathRule{}
+	_ Describer = (*structFieldsRule)(nil)
+	_ Describer = (*Schema)(nil)
+)
+
+// Describe returns the description of the constraint imposed by the rule.
+// False is returned if the rule does not implement Describer.
+func Describe(rule Rule) (RuleInfo, bool) {
+	if d, ok := rule.(Describer); ok {
+		return d.Metadata(), true
+	}
+	return RuleInfo{}, false
+}
+
+// Inspect returns a machine-readable description of the constraints on the struct fields.
+// The struct and the field rules are specified in the same way as ValidateStruct, so that pointer
+// field rules can be resolved to field names. A nil pointer to a struct can be used if the
+// field rules do not refer to field pointers (e.g. NamedField or Spec).
+//
+// Unlike Metadata, the nested rules are described recursively: "rules" params hold []RuleInfo,
+// the "fields" param of nested struct rules holds []FieldInfo, and the "field" param of
+// EqualToField, NotEqualToField and RequiredWhenField holds the name of the compared field.
+// Rules that do not implement Describer or impose no constraint are omitted.
+// Struct-level rules are described under the struct error key with the kind "struct_rule".
+func Inspect(structPtr interface{}, fields ...FieldRules) ([]FieldInfo, error) {
+	return inspectStruct(structPtr, fields)
+}
+
+func inspectStruct(structPtr interface{}, fields []FieldRules) ([]FieldInfo, error) {
+	value := reflect.ValueOf(structPtr)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value = reflect.New(value.Type().Elem())
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, NewInternalError(ErrStructPointer)
+	}
+
+	infos := make([]FieldInfo, 0, len(fields))
+	for i, fr := range fields {
+		if _, ok := fr.(structLevelRules); ok {
+			infos = append(infos, FieldInfo{
+				Name:  defaultOptions.Load().structErrorKey,
+				Rules: []RuleInfo{{Kind: "struct_rule"}},
+			})
+			continue
+		}
+
+		ft, fv, err := fr.FindStructField(value, i)
+		if errors.Is(err, ErrSkipFieldNotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		rules, err := inspectRules(value, fv, fr.Rules())
+		if err != nil {
+			return nil, err
+		}
+		_, key := defaultOptions.Load().fieldName(fr, ft)
+		infos = append(infos, FieldInfo{Name: key, Rules: rules})
+	}
+	return infos, nil
+}
+
+// inspectRules describes the rules applied to value, which is a field of the struct sv.
+func inspectRules(sv reflect.Value, value interface{}, rules []Rule) ([]RuleInfo, error) {
+	infos := []RuleInfo{}
+	for _, rule := range rules {
+		info, ok := Describe(rule)
+		if !ok || info.Kind == "" {
+			continue
+		}
+		info, err := inspectRule(sv, value, info)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// inspectRule describes the nested rules and fields in the params of info.
+func inspectRule(sv reflect.Value, value interface{}, info RuleInfo) (RuleInfo, error) {
+	if info.Params == nil {
+		return info, nil
+	}
+	params := make(map[string]interface{}, len(info.Params))
+	for k, v := range info.Params {
+		params[k] = v
+	}
+
+	var err error
+	switch info.Kind {
+	case "struct":
+		params["fields"], err = inspectStruct(value, params["fields"].([]FieldRules))
+	case "equal_to_field", "not_equal_to_field", "required_when_field":
+		if fv := reflect.ValueOf(params["field"]); fv.Kind() == reflect.Ptr {
+			if ft := findStructField(sv, fv); ft != nil {
+				params["field"] = defaultOptions.Load().errorFieldName(ft)
+			}
+		}
+	case "when":
+		if params["rules"], err = inspectRules(sv, value, params["rules"].([]Rule)); err == nil {
+			params["else_rules"], err = inspectRules(sv, value, params["else_rules"].([]Rule))
+		}
+	case "timeout", "and", "or", "not":
+		params["rules"], err = inspectRules(sv, value, params["rules"].([]Rule))
+	case "as_int", "as_float", "json_string", "path":
+		params["rules"], err = inspectRules(sv, nil, params["rules"].([]Rule))
+	case "each":
+		params["rules"], err = inspectRules(sv, elemValue(value), params["rules"].([]Rule))
+	case "dive":
+		params["fields"], err = inspectStruct(elemValue(value), params["fields"].([]FieldRules))
+	case "map":
+		err = inspectMapRule(sv, value, params)
+	}
+	if err != nil {
+		return RuleInfo{}, err
+	}
+
+	info.Params = params
+	return info, nil
+}
+
+func inspectMapRule(sv reflect.Value, value interface{}, params map[string]interface{}) error {
+	var err error
+	if params["key_rules"], err = inspectRules(sv, nil, params["key_rules"].([]Rule)); err != nil {
+		return err
+	}
+	elem := elemValue(value)
+	if params["value_rules"], err = inspectRules(sv, elem, params["value_rules"].([]Rule)); err != nil {
+		return err
+	}
+
+	keys := params["keys"].([]map[string]interface{})
+	inspected := make([]map[string]interface{}, len(keys))
+	for i, k := range keys {
+		rules, err := inspectRules(sv, elem, k["rules"].([]Rule))
+		if err != nil {
+			return err
+		}
+		inspected[i] = map[string]interface{}{"key": k["key"], "optional": k["optional"], "rules": rules}
+	}
+	params["keys"] = inspected
+	return nil
+}
+
+// elemValue returns a pointer to a zero element of the map, slice or array value,
+// which is used to describe the nested struct rules of the elements.
+func elemValue(value interface{}) interface{} {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		return nil
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return reflect.New(t.Elem()).Interface()
+	}
+	return nil
+}
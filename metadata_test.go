@@ -0,0 +1,178 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleMetadata(t *testing.T) {
+	var s struct{ A string }
+	each := Each(Required)
+	fields := []FieldRules{Field(&s.A, Required)}
+
+	tests := []struct {
+		tag      string
+		rule     Describer
+		expected RuleInfo
+	}{
+		{"t1", Required, RuleInfo{Kind: "required"}},
+		{"t2", Required.When(false), RuleInfo{}},
+		{"t3", NilOrNotEmpty, RuleInfo{Kind: "nil_or_not_empty"}},
+		{"t4", Length(1, 10), RuleInfo{Kind: "length", Params: map[string]interface{}{"min": 1, "max": 10, "rune": false}}},
+		{"t5", RuneLength(0, 5), RuleInfo{Kind: "length", Params: map[string]interface{}{"min": 0, "max": 5, "rune": true}}},
+		{"t6", In("a", "b"), RuleInfo{Kind: "in", Params: map[string]interface{}{"values": []interface{}{"a", "b"}}}},
+		{"t7", Min(1), RuleInfo{Kind: "min", Params: map[string]interface{}{"threshold": 1, "exclusive": false}}},
+		{"t8", Min(1).Exclusive(), RuleInfo{Kind: "min", Params: map[string]interface{}{"threshold": 1, "exclusive": true}}},
+		{"t9", Max(2.5), RuleInfo{Kind: "max", Params: map[string]interface{}{"threshold": 2.5, "exclusive": false}}},
+		{"t10", Max(2.5).Exclusive(), RuleInfo{Kind: "max", Params: map[string]interface{}{"threshold": 2.5, "exclusive": true}}},
+		{"t11", Match(regexp.MustCompile("^[a-z]+$")), RuleInfo{Kind: "match", Params: map[string]interface{}{"pattern": "^[a-z]+$"}}},
+		{"t12", each, RuleInfo{Kind: "each", Params: map[string]interface{}{"rules": []Rule{Required}}}},
+		{"t13", &structFieldsRule{fields: fields}, RuleInfo{Kind: "struct", Params: map[string]interface{}{"fields": fields}}},
+		{"t14", NotIn(1, 2), RuleInfo{Kind: "not_in", Params: map[string]interface{}{"values": []interface{}{1, 2}}}},
+		{"t15", MultipleOf(5), RuleInfo{Kind: "multiple_of", Params: map[string]interface{}{"base": 5, "epsilon": DefaultMultipleOfEpsilon}}},
+		{"t16", Date("2006-01-02"), RuleInfo{Kind: "date", Params: map[string]interface{}{"layout": "2006-01-02"}}},
+		{"t17", Date("2006").Min(time.Unix(0, 0)), RuleInfo{Kind: "date", Params: map[string]interface{}{"layout": "2006", "min": time.Unix(0, 0)}}},
+		{"t18", NewStringRuleWithError(func(string) bool { return true }, NewError("code", "msg")), RuleInfo{Kind: "string", Params: map[string]interface{}{"code": "code"}}},
+		{"t19", EqualToField(&s.A), RuleInfo{Kind: "equal_to_field", Params: map[string]interface{}{"field": &s.A}}},
+		{"t20", NotEqualToField(&s.A), RuleInfo{Kind: "not_equal_to_field", Params: map[string]interface{}{"field": &s.A}}},
+		{"t21", When(true, Required).Else(Nil), RuleInfo{Kind: "when", Params: map[string]interface{}{"condition": true, "rules": []Rule{Required}, "else_rules": []Rule{Nil}}}},
+		{"t22", Timeout(time.Second, Required), RuleInfo{Kind: "timeout", Params: map[string]interface{}{"timeout": time.Second, "rules": []Rule{Required}}}},
+		{"t23", Nil, RuleInfo{Kind: "nil"}},
+		{"t24", Empty, RuleInfo{Kind: "empty"}},
+		{"t25", Absent, RuleInfo{Kind: "absent"}},
+		{"t26", Absent.When(false), RuleInfo{}},
+		{"t27", NotNil, RuleInfo{Kind: "not_nil"}},
+		{"t28", NotNil.Unless(true), RuleInfo{}},
+		{"t29", Skip, RuleInfo{Kind: "skip"}},
+		{"t30", Skip.When(false), RuleInfo{}},
+		{"t31", Keys(Required).Values(Length(1, 2)), RuleInfo{Kind: "map", Params: map[string]interface{}{
+			"key_rules":        []Rule{Required},
+			"value_rules":      []Rule{Length(1, 2)},
+			"keys":             []map[string]interface{}{},
+			"allow_extra_keys": true,
+		}}},
+		{"t32", Map(Key("a", Required).Optional()), RuleInfo{Kind: "map", Params: map[string]interface{}{
+			"key_rules":        []Rule(nil),
+			"value_rules":      []Rule(nil),
+			"keys":             []map[string]interface{}{{"key": "a", "optional": true, "rules": []Rule{Required}}},
+			"allow_extra_keys": false,
+		}}},
+		{"t33", MinItems(1), RuleInfo{Kind: "items", Params: map[string]interface{}{"min": 1}}},
+		{"t34", ItemsBetween(1, 3), RuleInfo{Kind: "items", Params: map[string]interface{}{"min": 1, "max": 3}}},
+		{"t35", EnumOf("a", "b"), RuleInfo{Kind: "enum", Params: map[string]interface{}{"values": []interface{}{"a", "b"}}}},
+		{"t36", EnumFunc(func(int) bool { return true }), RuleInfo{Kind: "enum"}},
+		{"t37", FileMaxSize(10), RuleInfo{Kind: "file_max_size", Params: map[string]interface{}{"max": int64(10)}}},
+		{"t38", FileMIMEIn("image/png"), RuleInfo{Kind: "file_mime_in", Params: map[string]interface{}{"types": []string{"image/png"}}}},
+		{"t39", FileExtIn("png"), RuleInfo{Kind: "file_ext_in", Params: map[string]interface{}{"extensions": []string{"png"}}}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.rule.Metadata(), test.tag)
+	}
+
+	spec := Spec("A", Required)
+	info := NewSchema(spec).Metadata()
+	assert.Equal(t, "struct", info.Kind)
+	assert.Equal(t, []FieldRules{spec}, info.Params["fields"])
+
+	info = FieldStruct(&s, fields...).Rules()[0].(Describer).Metadata()
+	assert.Equal(t, RuleInfo{Kind: "struct", Params: map[string]interface{}{"fields": fields}}, info)
+}
+
+func TestDescribe(t *testing.T) {
+	info, ok := Describe(Length(1, 2))
+	assert.True(t, ok)
+	assert.Equal(t, "length", info.Kind)
+
+	info, ok = Describe(By(func(context.Context, interface{}) error { return nil }))
+	assert.False(t, ok)
+	assert.Equal(t, RuleInfo{}, info)
+}
+
+type inspectAddress struct {
+	Street string `json:"street"`
+}
+
+type inspectUser struct {
+	Name     string            `json:"name"`
+	Password string            `json:"password"`
+	Confirm  string            `json:"confirm"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Address  inspectAddress    `json:"address"`
+	Previous []inspectAddress  `json:"previous"`
+}
+
+func TestInspect(t *testing.T) {
+	u := &inspectUser{}
+	addressSchema := NewSchema(Spec("Street", Required))
+	infos, err := Inspect(u,
+		Field(&u.Name, Required, By(func(context.Context, interface{}) error { return nil }), Skip.When(false)),
+		Field(&u.Confirm, EqualToField(&u.Password), When(true, Length(1, 0)).Else(Timeout(time.Second, Nil))),
+		Field(&u.Tags, Each(In("a", "b"))),
+		Field(&u.Labels, Map(Key("x", Required)).Keys(Length(1, 0)).Values(Match(regexp.MustCompile("^a$")))),
+		FieldStruct(&u.Address, Field(&u.Address.Street, Required)),
+		Field(&u.Previous, Each(addressSchema)),
+		StructRule(func(context.Context, *inspectUser) error { return nil }),
+		NamedField("missing", Required).SetSkipIfNotFound(true),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	streetInfos := []FieldInfo{{Name: "street", Rules: []RuleInfo{{Kind: "required"}}}}
+	assert.Equal(t, []FieldInfo{
+		{Name: "name", Rules: []RuleInfo{{Kind: "required"}}},
+		{Name: "confirm", Rules: []RuleInfo{
+			{Kind: "equal_to_field", Params: map[string]interface{}{"field": "password"}},
+			{Kind: "when", Params: map[string]interface{}{
+				"condition":  true,
+				"rules":      []RuleInfo{Length(1, 0).Metadata()},
+				"else_rules": []RuleInfo{{Kind: "timeout", Params: map[string]interface{}{"timeout": time.Second, "rules": []RuleInfo{{Kind: "nil"}}}}},
+			}},
+		}},
+		{Name: "tags", Rules: []RuleInfo{
+			{Kind: "each", Params: map[string]interface{}{"rules": []RuleInfo{In("a", "b").Metadata()}}},
+		}},
+		{Name: "labels", Rules: []RuleInfo{
+			{Kind: "map", Params: map[string]interface{}{
+				"key_rules":        []RuleInfo{Length(1, 0).Metadata()},
+				"value_rules":      []RuleInfo{Match(regexp.MustCompile("^a$")).Metadata()},
+				"keys":             []map[string]interface{}{{"key": "x", "optional": false, "rules": []RuleInfo{{Kind: "required"}}}},
+				"allow_extra_keys": false,
+			}},
+		}},
+		{Name: "address", Rules: []RuleInfo{{Kind: "struct", Params: map[string]interface{}{"fields": streetInfos}}}},
+		{Name: "previous", Rules: []RuleInfo{
+			{Kind: "each", Params: map[string]interface{}{"rules": []RuleInfo{
+				{Kind: "struct", Params: map[string]interface{}{"fields": streetInfos}},
+			}}},
+		}},
+		{Name: DefaultStructErrorKey, Rules: []RuleInfo{{Kind: "struct_rule"}}},
+	}, infos)
+}
+
+func TestInspect_Errors(t *testing.T) {
+	u := &inspectUser{}
+	other := ""
+
+	_, err := Inspect("abc")
+	assert.True(t, errors.Is(err, ErrStructPointer))
+
+	_, err = Inspect(u, Field(&other, Required))
+	assert.EqualError(t, err, ErrFieldNotFound(0).Error())
+
+	_, err = Inspect(u, FieldStruct(&u.Address, Field(&other, Required)))
+	assert.EqualError(t, err, ErrFieldNotFound(0).Error())
+
+	_, err = Inspect(u, Field(&u.Previous, Each(NewSchema(Spec("City")))))
+	assert.EqualError(t, err, ErrFieldNotFound(0).Error())
+
+	infos, err := Inspect((*inspectUser)(nil), NamedField("name", Required))
+	assert.NoError(t, err)
+	assert.Equal(t, []FieldInfo{{Name: "name", Rules: []RuleInfo{{Kind: "required"}}}}, infos)
+}
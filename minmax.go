@@ -80,8 +80,9 @@ func (r ThresholdRule) CmpFunc(fn CmpFunc) ThresholdRule {
 
 // Validate checks if the given value is valid or not.
 func (r ThresholdRule) Validate(ctx context.Context, value interface{}) error {
-	value, isNil := indirectWithOptions(value, GetOptions(ctx))
-	if isNil || IsEmpty(value) {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
 		return nil
 	}
 
@@ -152,6 +153,24 @@ func (r ThresholdRule) ErrorObject(err Error) ThresholdRule {
 	return r
 }
 
+// Metadata returns the description of the rule.
+// The kind is "min" or "max", with the params "threshold" and "exclusive".
+func (r ThresholdRule) Metadata() RuleInfo {
+	kind, exclusive := "min", false
+	switch r.operator {
+	case GreaterThan:
+		exclusive = true
+	case LessThan:
+		kind, exclusive = "max", true
+	case LessEqualThan:
+		kind = "max"
+	}
+	return RuleInfo{
+		Kind:   kind,
+		Params: map[string]interface{}{"threshold": r.threshold, "exclusive": exclusive},
+	}
+}
+
 func (r ThresholdRule) compareInt(threshold, value int64) bool {
 	switch r.operator {
 	case GreaterThan:
@@ -2,28 +2,45 @@ package validation
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
 )
 
 var _ Rule = (*MultipleOfRule)(nil)
 
+var errMultipleOfZero = errors.New("the base of MultipleOf must not be zero")
+
 // ErrMultipleOfInvalid is the error that returns when a value is not multiple of a base.
 var ErrMultipleOfInvalid = NewError("validation_multiple_of_invalid", "must be multiple of {{.base}}")
 
+// DefaultMultipleOfEpsilon is the default tolerance used by MultipleOf when checking float values.
+const DefaultMultipleOfEpsilon = 1e-9
+
 // MultipleOf returns a validation rule that checks if a value is a multiple of the "base" value.
-// Note that "base" should be of integer type.
+// Note that "base" should be of integer or float type, and the value being checked must be of the same kind.
+// Because floats cannot represent most decimal fractions exactly, a float value is considered a multiple
+// of the base if the remainder of the division is within a tolerance. Call Epsilon to change the tolerance.
 func MultipleOf(base interface{}) MultipleOfRule {
 	return MultipleOfRule{
-		base: base,
-		err:  ErrMultipleOfInvalid,
+		base:    base,
+		epsilon: DefaultMultipleOfEpsilon,
+		err:     ErrMultipleOfInvalid,
 	}
 }
 
 // MultipleOfRule is a validation rule that checks if a value is a multiple of the "base" value.
 type MultipleOfRule struct {
-	base interface{}
-	err  Error
+	base    interface{}
+	epsilon float64
+	err     Error
+}
+
+// Epsilon sets the tolerance used when checking float values.
+func (r MultipleOfRule) Epsilon(epsilon float64) MultipleOfRule {
+	r.epsilon = math.Abs(epsilon)
+	return r
 }
 
 // Error sets the error message for the rule.
@@ -38,9 +55,27 @@ func (r MultipleOfRule) ErrorObject(err Error) MultipleOfRule {
 	return r
 }
 
+// Metadata returns the description of the rule.
+// The kind is "multiple_of", with the params "base" and "epsilon".
+func (r MultipleOfRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "multiple_of",
+		Params: map[string]interface{}{"base": r.base, "epsilon": r.epsilon},
+	}
+}
+
 // Validate checks if the value is a multiple of the "base" value.
 func (r MultipleOfRule) Validate(ctx context.Context, value interface{}) error {
 	rv := reflect.ValueOf(r.base)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if rv.IsZero() {
+			return errMultipleOfZero
+		}
+	}
+
 	switch rv.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		v, err := ToInt(value)
@@ -60,6 +95,17 @@ func (r MultipleOfRule) Validate(ctx context.Context, value interface{}) error {
 		if v%rv.Uint() == 0 {
 			return nil
 		}
+
+	case reflect.Float32, reflect.Float64:
+		v, err := ToFloat(value)
+		if err != nil {
+			return err
+		}
+
+		base := math.Abs(rv.Float())
+		if rem := math.Abs(math.Mod(v, base)); rem <= r.epsilon || base-rem <= r.epsilon {
+			return nil
+		}
 	default:
 		return fmt.Errorf("type not supported: %v", rv.Type())
 	}
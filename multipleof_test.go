@@ -25,6 +25,33 @@ func TestMultipleOf(t *testing.T) {
 	assert.Equal(t, "cannot convert float32 to uint64", r3.Validate(nil, float32(20)).Error())
 }
 
+func TestMultipleOfFloat(t *testing.T) {
+	tests := []struct {
+		tag   string
+		rule  MultipleOfRule
+		value interface{}
+		err   string
+	}{
+		{"t1", MultipleOf(0.01), 19.99, ""},
+		{"t2", MultipleOf(0.01), 0.3, ""},
+		{"t3", MultipleOf(0.01), 19.995, "must be multiple of 0.01"},
+		{"t4", MultipleOf(0.5), 2.5, ""},
+		{"t5", MultipleOf(0.5), -2.5, ""},
+		{"t6", MultipleOf(-0.5), 2.5, ""},
+		{"t7", MultipleOf(0.5), 2.4, "must be multiple of 0.5"},
+		{"t8", MultipleOf(0.5).Epsilon(0.2), 2.4, ""},
+		{"t9", MultipleOf(float32(0.25)), float32(0.75), ""},
+		{"t10", MultipleOf(0.5), 2, "cannot convert int to float64"},
+		{"t11", MultipleOf(0.0), 2.0, "the base of MultipleOf must not be zero"},
+		{"t12", MultipleOf(0), 2, "the base of MultipleOf must not be zero"},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Validate(nil, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
 func Test_MultipleOf_Error(t *testing.T) {
 	r := MultipleOf(10)
 	assert.Equal(t, "must be multiple of 10", r.Validate(nil, 3).Error())
@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+var _ Normalizer = NormalizeRule{}
+
+var (
+	// Trim is a normalizer that removes the leading and trailing white space of a string.
+	Trim = NormalizeString(strings.TrimSpace)
+	// Lowercase is a normalizer that converts a string to lower case.
+	Lowercase = NormalizeString(strings.ToLower)
+	// Uppercase is a normalizer that converts a string to upper case.
+	Uppercase = NormalizeString(strings.ToUpper)
+)
+
+// Normalizer is implemented by rules that clean a value before it is validated, such as Trim and Lowercase.
+//
+// The normalizers among the rules of a value are applied in order before the other rules, which validate
+// the normalized value. When validating a struct field specified by Field or NamedField, the normalized value
+// is written back to the field. Otherwise, the value is written back only if it is a pointer.
+type Normalizer interface {
+	Rule
+	// Normalize returns the normalized value. If the value is a pointer, Normalize updates the value it points to
+	// and returns the pointer.
+	Normalize(value interface{}) interface{}
+}
+
+// NormalizeRule is a normalizer that transforms a string.
+type NormalizeRule struct {
+	f func(string) string
+}
+
+// NormalizeString returns a normalizer that transforms a string with f. For example,
+//
+//	collapse := validation.NormalizeString(func(s string) string {
+//	    return strings.Join(strings.Fields(s), " ")
+//	})
+//
+// The value can be a string, a type whose underlying type is string, or a pointer to them.
+// Other values are left unchanged.
+func NormalizeString(f func(string) string) NormalizeRule {
+	return NormalizeRule{f: f}
+}
+
+// Normalize returns the transformed string.
+func (r NormalizeRule) Normalize(value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		return r.f(s)
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return value
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.String {
+		return value
+	}
+	if rv.CanSet() {
+		rv.SetString(r.f(rv.String()))
+		return value
+	}
+	return reflect.ValueOf(r.f(rv.String())).Convert(rv.Type()).Interface()
+}
+
+// Validate does nothing, as the rule only normalizes the value.
+func (r NormalizeRule) Validate(context.Context, interface{}) error {
+	return nil
+}
+
+// Metadata returns the description of the rule, which has an empty kind as the rule imposes no constraint.
+func (r NormalizeRule) Metadata() RuleInfo {
+	return RuleInfo{}
+}
+
+// hasNormalizer reports whether any of the rules is a Normalizer.
+func hasNormalizer(rules []Rule) bool {
+	for _, rule := range rules {
+		if _, ok := rule.(Normalizer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize applies the normalizers among the rules to value in order, and returns the normalized value
+// and the other rules.
+func normalize(value interface{}, rules []Rule) (interface{}, []Rule) {
+	others := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if n, ok := rule.(Normalizer); ok {
+			value = n.Normalize(value)
+		} else {
+			others = append(others, rule)
+		}
+	}
+	return value, others
+}
@@ -0,0 +1,78 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeRule(t *testing.T) {
+	type myString string
+	s := " Abc "
+	ps := &s
+	var nilString *string
+
+	tests := []struct {
+		tag       string
+		normalize Normalizer
+		value     interface{}
+		expected  interface{}
+	}{
+		{"t1", Trim, " abc ", "abc"},
+		{"t2", Lowercase, "ABC", "abc"},
+		{"t3", Uppercase, myString("abc"), myString("ABC")},
+		{"t4", Trim, 123, 123},
+		{"t5", Trim, nilString, nilString},
+		{"t6", NormalizeString(func(s string) string { return strings.Join(strings.Fields(s), " ") }), "a  b\tc", "a b c"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.normalize.Normalize(test.value), test.tag)
+	}
+
+	// the value is updated through the pointer
+	assert.Equal(t, &ps, Trim.Normalize(&ps))
+	assert.Equal(t, "Abc", s)
+}
+
+func TestValidate_Normalize(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value interface{}
+		rules []Rule
+		err   string
+	}{
+		{"t1", "  ", []Rule{Required, Trim}, "cannot be blank"},
+		{"t2", " abc ", []Rule{Trim, Length(3, 3)}, ""},
+		{"t3", " ABC ", []Rule{Trim, Lowercase, In("abc")}, ""},
+		{"t4", "ABC", []Rule{In("abc")}, "must be a valid value"},
+		{"t5", []string{" a", "b "}, []Rule{Each(Trim, Length(1, 1))}, ""},
+	}
+	for _, test := range tests {
+		err := Validate(test.value, test.rules...)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	s := " A "
+	assert.NoError(t, Validate(&s, Trim, Lowercase))
+	assert.Equal(t, "a", s)
+}
+
+func TestValidateStruct_Normalize(t *testing.T) {
+	type user struct {
+		Email    string  `json:"email"`
+		Name     *string `json:"name"`
+		Nickname string  `json:"nickname"`
+	}
+	name := "  Alice "
+	u := user{Email: " Alice@Example.COM ", Name: &name, Nickname: "  "}
+	err := ValidateStruct(&u,
+		Field(&u.Email, Trim, Lowercase, Required, Length(1, 20)),
+		Field(&u.Name, Trim),
+		NamedField("nickname", Trim, Required),
+	)
+	assertError(t, "nickname: cannot be blank.", err, "t1")
+	assert.Equal(t, "alice@example.com", u.Email)
+	assert.Equal(t, "Alice", name)
+	assert.Equal(t, "", u.Nickname)
+}
@@ -28,23 +28,31 @@ func NotIn[T any](values ...T) NotInRule[T] {
 type NotInRule[T any] struct {
 	elements []T
 	err      Error
+	compare  *StringCompare
 }
 
 // Validate checks if the given value is valid or not.
 func (r NotInRule[T]) Validate(ctx context.Context, value interface{}) error {
-	value, isNil := indirectWithOptions(value, GetOptions(ctx))
-	if isNil || IsEmpty(value) {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
 		return nil
 	}
 
 	for _, e := range r.elements {
-		if reflect.DeepEqual(e, value) {
+		if r.compare != nil && r.compare.equal(e, value) || r.compare == nil && reflect.DeepEqual(e, value) {
 			return r.err
 		}
 	}
 	return nil
 }
 
+// StringCompare sets how the strings are compared, e.g. ignoring case.
+func (r NotInRule[T]) StringCompare(c StringCompare) NotInRule[T] {
+	r.compare = &c
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r NotInRule[T]) Error(message string) NotInRule[T] {
 	r.err = r.err.SetMessage(message)
@@ -56,3 +64,21 @@ func (r NotInRule[T]) ErrorObject(err Error) NotInRule[T] {
 	r.err = err
 	return r
 }
+
+// Metadata returns the description of the rule.
+// The kind is "not_in", with the param "values" holding the disallowed values as []interface{}.
+// The param "fold_case" or "normalize" is true if the strings are compared as set by StringCompare.
+func (r NotInRule[T]) Metadata() RuleInfo {
+	values := make([]interface{}, len(r.elements))
+	for i, e := range r.elements {
+		values[i] = e
+	}
+	params := map[string]interface{}{"values": values}
+	if r.compare != nil {
+		params = r.compare.params(params)
+	}
+	return RuleInfo{
+		Kind:   "not_in",
+		Params: params,
+	}
+}
@@ -5,6 +5,7 @@
 package validation
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -79,3 +80,10 @@ func TestNotInRule_ErrorObject(t *testing.T) {
 	assert.Equal(t, err.Code(), r.err.Code())
 	assert.Equal(t, err.Message(), r.err.Message())
 }
+
+func TestNotIn_StringCompare(t *testing.T) {
+	r := NotIn("admin", "root").StringCompare(StringCompare{FoldCase: true})
+	assertError(t, "must not be in list", r.Validate(context.Background(), "Admin"), "t1")
+	assertError(t, "", r.Validate(context.Background(), "alice"), "t2")
+	assert.Equal(t, map[string]interface{}{"values": []interface{}{"admin", "root"}, "fold_case": true}, r.Metadata().Params)
+}
@@ -14,14 +14,18 @@ var ErrNotNilRequired = NewError("validation_not_nil_required", "is required")
 // NotNil is a validation rule that checks if a value is not nil.
 // NotNil only handles types including interface, pointer, slice, and map.
 // All other types are considered valid.
-var NotNil = notNilRule{}
+var NotNil = notNilRule{condition: true}
 
 type notNilRule struct {
-	err Error
+	condition bool
+	err       Error
 }
 
 // Validate checks if the given value is valid or not.
 func (r notNilRule) Validate(ctx context.Context, value interface{}) error {
+	if !r.condition {
+		return nil
+	}
 	_, isNil := indirectWithOptions(value, GetOptions(ctx))
 	if isNil {
 		if r.err != nil {
@@ -32,6 +36,19 @@ func (r notNilRule) Validate(ctx context.Context, value interface{}) error {
 	return nil
 }
 
+// When sets the condition that determines if the validation should be performed.
+func (r notNilRule) When(condition bool) notNilRule {
+	r.condition = condition
+	return r
+}
+
+// Unless sets the condition that determines if the validation should be skipped.
+// It is the opposite of When.
+func (r notNilRule) Unless(condition bool) notNilRule {
+	r.condition = !condition
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r notNilRule) Error(message string) notNilRule {
 	if r.err == nil {
@@ -46,3 +63,12 @@ func (r notNilRule) ErrorObject(err Error) notNilRule {
 	r.err = err
 	return r
 }
+
+// Metadata returns the description of the rule.
+// The kind is "not_nil", or empty if the rule is disabled by When or Unless.
+func (r notNilRule) Metadata() RuleInfo {
+	if !r.condition {
+		return RuleInfo{}
+	}
+	return RuleInfo{Kind: "not_nil"}
+}
@@ -42,6 +42,26 @@ func TestNotNil(t *testing.T) {
 	}
 }
 
+func TestNotNilRule_When(t *testing.T) {
+	var v *int
+	tests := []struct {
+		tag  string
+		rule Rule
+		err  string
+	}{
+		{"t1", NotNil.When(true), "is required"},
+		{"t2", NotNil.When(false), ""},
+		{"t3", NotNil.Unless(true), ""},
+		{"t4", NotNil.Unless(false), "is required"},
+		{"t5", NotNil.When(true).Error("must be set"), "must be set"},
+	}
+
+	for _, test := range tests {
+		err := ValidateWithContext(context.Background(), v, test.rule)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
 func Test_notNilRule_Error(t *testing.T) {
 	r := NotNil
 	assert.Equal(t, "is required", r.Validate(nil, nil).Error())
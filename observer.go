@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RuleObserver is called after a rule is executed by ValidateWithContext with the name of the rule,
+// the time it took, and whether it returned an error.
+//
+// The name of a rule implementing Describer is the kind of its metadata, e.g. "required" or "length".
+// Other rules are named by their Go type, e.g. "*users.uniqueEmailRule", so custom rules that should be
+// told apart are best defined as named types rather than with By.
+type RuleObserver func(rule string, d time.Duration, failed bool)
+
+// WithRuleObserver sets a function that observes the execution of every rule, e.g. to export the latency
+// and the failure rate of the rules as metrics. Rules that only delegate to other rules, such as Each, Map,
+// When and nested struct rules, are not observed, but the rules they delegate to are.
+// A rule wrapped by Timeout is observed by the name of the wrapped rule.
+func WithRuleObserver(f RuleObserver) Option {
+	return func(o *options) {
+		o.ruleObserver = f
+	}
+}
+
+// observeRule validates the value with the rule and reports the execution to the observer.
+func observeRule(ctx context.Context, rule Rule, value interface{}, observer RuleObserver) error {
+	name, ok := ruleName(rule)
+	if !ok {
+		return rule.Validate(ctx, value)
+	}
+
+	start := time.Now()
+	err := rule.Validate(ctx, value)
+	observer(name, time.Since(start), err != nil)
+	return err
+}
+
+// ruleName returns the name of the rule reported to a RuleObserver, or false if the rule only
+// delegates to other rules.
+func ruleName(rule Rule) (string, bool) {
+	switch r := rule.(type) {
+	case TimeoutRule:
+		return ruleName(r.rule)
+	case EachRule, DiveRule, MapRule, WhenRule, CoerceRule, AndRule, OrRule, NotRule, pathRule, *structFieldsRule, *Schema:
+		return "", false
+	}
+	if d, ok := rule.(Describer); ok {
+		return d.Metadata().Kind, true
+	}
+	return fmt.Sprintf("%T", rule), true
+}
@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type slowRule struct{}
+
+func (slowRule) Validate(context.Context, interface{}) error {
+	time.Sleep(10 * time.Millisecond)
+	return errors.New("too slow")
+}
+
+func TestWithRuleObserver(t *testing.T) {
+	type observation struct {
+		rule   string
+		failed bool
+	}
+	var observed []observation
+	var slow time.Duration
+	ctx := WithOptions(context.Background(), WithRuleObserver(func(rule string, d time.Duration, failed bool) {
+		observed = append(observed, observation{rule, failed})
+		if rule == "validation.slowRule" {
+			slow = d
+		}
+	}))
+
+	tests := []struct {
+		tag      string
+		value    interface{}
+		rules    []Rule
+		observed []observation
+	}{
+		{"t1", "abc", []Rule{Required, Length(1, 2)}, []observation{{"required", false}, {"length", true}}},
+		{"t2", "", []Rule{Skip, Required}, nil},
+		{"t3", []string{"a", ""}, []Rule{Each(Required)}, []observation{{"required", false}, {"required", true}}},
+		{"t4", "a", []Rule{When(true, In("a"))}, []observation{{"in", false}}},
+		{"t5", "a", []Rule{Timeout(time.Second, NotNil)}, []observation{{"not_nil", false}}},
+		{"t6", "a", []Rule{slowRule{}}, []observation{{"validation.slowRule", true}}},
+	}
+	for _, test := range tests {
+		observed = nil
+		_ = ValidateWithContext(ctx, test.value, test.rules...)
+		assert.Equal(t, test.observed, observed, test.tag)
+	}
+	assert.GreaterOrEqual(t, slow, 10*time.Millisecond)
+
+	// rules executed with WithRuleTimeout are observed by their own name
+	observed = nil
+	ctx = WithOptions(ctx, WithRuleTimeout(time.Second))
+	_ = ValidateWithContext(ctx, "a", Required)
+	assert.Equal(t, []observation{{"required", false}}, observed)
+}
@@ -2,42 +2,203 @@ package validation
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
 	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type (
 	ValuerFunc            func(any) (any, bool)
 	GetErrorFieldNameFunc func(f *reflect.StructField) string
+	IsEmptyFunc           func(any) bool
+
+	// ElementKeyFunc returns the key of a collection element in the validation errors. The key is the index of
+	// a slice or array element, in which case index is true, or the key of a map element.
+	ElementKeyFunc func(key any, index bool) string
 
 	Options interface {
 		ValuerFunc() ValuerFunc
 		GetErrorFieldNameFunc() GetErrorFieldNameFunc
+		MaxWorkers() int
+		MaxErrors() int
+		RuleTimeout() time.Duration
+		StructErrorKey() string
+		StringerConversion() bool
+		StrictFieldNames() bool
+		IsZeroer() bool
+		IsEmptyFunc() IsEmptyFunc
+		TrimSpace() bool
 	}
 
 	options struct {
 		valuerFunc            ValuerFunc
+		valuers               []typedValuer
 		getErrorFieldNameFunc GetErrorFieldNameFunc
+		fieldNameTransformer  func(string) string
+		elementKeyFunc        ElementKeyFunc
+		maxWorkers            int
+		maxErrors             int
+		ruleTimeout           time.Duration
+		structErrorKey        string
+		stringerConversion    bool
+		strictFieldNames      bool
+		ignoreIsZeroer        bool
+		isEmptyFunc           IsEmptyFunc
+		trimSpace             bool
+		partial               bool
+		deep                  bool
+		maxDepth              int
+		nilStructError        bool
+		fs                    fs.FS
+		lookupCache           *lookupCache
+		ruleCacheScope        RuleCacheScope
+		ruleCache             *ruleCache
+		sourceMap             SourceMap
+		errorReporter         ErrorReporter
+		ruleObserver          RuleObserver
+		present               map[string]bool
+		payload               map[string]bool
+		unknownErrorKey       string
+		schemas               map[reflect.Type]*Schema
 	}
 
 	Option func(*options)
+
+	// typedValuer is a ValuerFunc registered for a type by WithValuer.
+	typedValuer struct {
+		t reflect.Type
+		f ValuerFunc
+	}
 )
 
 var _ Options = (*options)(nil)
 
+// DefaultStructErrorKey is the default key under which the errors of struct-level rules are reported.
+const DefaultStructErrorKey = "_struct"
+
+// DefaultUnknownErrorKey is the default key under which the unknown fields are reported.
+const DefaultUnknownErrorKey = "_unknown"
+
 type optionsCtxKeyType struct{}
 
 var optionsCtxKey = optionsCtxKeyType{}
 
-var defaultOptions = &options{
-	valuerFunc:            DefaultValuer,
-	getErrorFieldNameFunc: DefaultGetErrorFieldName,
+// defaultOptions holds the options used when a context has none, as set by SetDefaultOptions.
+var defaultOptions atomic.Pointer[options]
+
+// setDefaultOptionsMu serializes the updates of defaultOptions.
+var setDefaultOptionsMu sync.Mutex
+
+func init() {
+	defaultOptions.Store(newDefaultOptions())
+}
+
+// newDefaultOptions returns the built-in default options.
+func newDefaultOptions() *options {
+	return &options{
+		valuerFunc:            DefaultValuer,
+		getErrorFieldNameFunc: DefaultGetErrorFieldName,
+		structErrorKey:        DefaultStructErrorKey,
+		unknownErrorKey:       DefaultUnknownErrorKey,
+	}
+}
+
+// SetDefaultOptions applies the options to the default options, which are used when validating with a context
+// that has no options set by WithOptions, so that an application can set its policies, e.g. the ValuerFunc or
+// the error field names, once at startup instead of passing them with every context. For example,
+//
+//	func main() {
+//	    validation.SetDefaultOptions(
+//	        validation.WithGetErrorFieldNameFunc(jsonFieldName),
+//	        validation.WithStringerConversion(true),
+//	    )
+//	    ...
+//	}
+//
+// The contexts created by WithOptions copy the default options at that time, so they are not affected by later calls.
+// SetDefaultOptions is safe for concurrent use with the validation functions.
+//
+// The caches of WithLookupCache and WithRuleCache(RuleCacheContext) are ignored, since they would be shared by
+// all the validations of the process and never cleared; set them with WithOptions on the context of a request instead.
+// WithRuleCache(RuleCachePass) is allowed, as it creates a new cache for every validation pass.
+func SetDefaultOptions(opts ...Option) {
+	setDefaultOptionsMu.Lock()
+	defer setDefaultOptionsMu.Unlock()
+
+	prev := defaultOptions.Load()
+	o := new(options)
+	*o = *prev
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.lookupCache = nil
+	o.ruleCache = nil
+	if o.ruleCacheScope == RuleCacheContext {
+		o.ruleCacheScope = prev.ruleCacheScope
+	}
+	defaultOptions.Store(o)
+}
+
+// ResetDefaults restores the built-in default options, discarding the options set by SetDefaultOptions.
+// It is typically deferred in the tests calling SetDefaultOptions.
+func ResetDefaults() {
+	setDefaultOptionsMu.Lock()
+	defer setDefaultOptionsMu.Unlock()
+	defaultOptions.Store(newDefaultOptions())
+}
+
+func (o *options) GetErrorFieldNameFunc() GetErrorFieldNameFunc {
+	if o.fieldNameTransformer == nil {
+		return o.getErrorFieldNameFunc
+	}
+	return o.errorFieldName
+}
+func (o *options) MaxWorkers() int            { return o.maxWorkers }
+func (o *options) MaxErrors() int             { return o.maxErrors }
+func (o *options) RuleTimeout() time.Duration { return o.ruleTimeout }
+func (o *options) StructErrorKey() string     { return o.structErrorKey }
+func (o *options) StringerConversion() bool   { return o.stringerConversion }
+func (o *options) StrictFieldNames() bool     { return o.strictFieldNames }
+func (o *options) IsZeroer() bool             { return !o.ignoreIsZeroer }
+func (o *options) IsEmptyFunc() IsEmptyFunc   { return o.isEmptyFunc }
+func (o *options) TrimSpace() bool            { return o.trimSpace }
+
+// ValuerFunc returns the function that extracts the values to validate. If valuers are registered by
+// WithValuer, the returned function tries the valuers registered for the type of the value first,
+// and falls back to the function set by WithValuerFunc.
+func (o *options) ValuerFunc() ValuerFunc {
+	if len(o.valuers) == 0 {
+		return o.valuerFunc
+	}
+	return o.value
 }
 
-func (o *options) ValuerFunc() ValuerFunc                       { return o.valuerFunc }
-func (o *options) GetErrorFieldNameFunc() GetErrorFieldNameFunc { return o.getErrorFieldNameFunc }
+// value tries the registered valuers matching the type of v, the latest registered first,
+// until one of them succeeds, and falls back to the function set by WithValuerFunc.
+func (o *options) value(v any) (any, bool) {
+	if t := reflect.TypeOf(v); t != nil {
+		for i := len(o.valuers) - 1; i >= 0; i-- {
+			tv := o.valuers[i]
+			if tv.t != t && (tv.t.Kind() != reflect.Interface || !t.Implements(tv.t)) {
+				continue
+			}
+			if val, ok := tv.f(v); ok {
+				return val, true
+			}
+		}
+	}
+	if o.valuerFunc != nil {
+		return o.valuerFunc(v)
+	}
+	return v, false
+}
 
 func DefaultOptions() Options {
-	return defaultOptions
+	return defaultOptions.Load()
 }
 
 func WithValuerFunc(f ValuerFunc) Option {
@@ -48,6 +209,14 @@ func WithValuerFunc(f ValuerFunc) Option {
 	}
 }
 
+// WithErrorFieldTag names the struct fields in the validation errors by the first of the tags set on them, instead of
+// the json tag, falling back to the Go field names. For example, handlers binding form values use
+// WithErrorFieldTag("form") for the form tag names, or WithErrorFieldTag("form", "json") to try the form tag first,
+// then the json tag. It is a shortcut for WithGetErrorFieldNameFunc(ErrorFieldNameFromTags(tags...)).
+func WithErrorFieldTag(tags ...string) Option {
+	return WithGetErrorFieldNameFunc(ErrorFieldNameFromTags(tags...))
+}
+
 func WithGetErrorFieldNameFunc(f GetErrorFieldNameFunc) Option {
 	return func(o *options) {
 		if f != nil {
@@ -56,6 +225,376 @@ func WithGetErrorFieldNameFunc(f GetErrorFieldNameFunc) Option {
 	}
 }
 
+// WithFieldNameTransformer transforms the names of the struct fields in the validation errors with f, which is applied
+// after the names are resolved from the tags, so that the error keys follow the casing convention of an API even for
+// the fields without tags. For example, WithFieldNameTransformer(validation.SnakeCase) reports the field UserID
+// as "user_id". The keys set explicitly by Key are used as is. FieldPath and the matching of the fields present
+// in a partial update or in the payload set by WithRejectUnknownFields use the names before the transformation.
+func WithFieldNameTransformer(f func(string) string) Option {
+	return func(o *options) {
+		o.fieldNameTransformer = f
+	}
+}
+
+// WithElementKeyFunc sets the function that formats the keys of the collection elements in the validation errors,
+// which are the bare indexes or map keys by default. It applies to the elements validated by Each, Dive and the
+// deep validation, to the Validatable elements of the slices and maps, and to the items in BatchResult.Err.
+// For example, WithElementKeyFunc(validation.OneBasedElementKey) reports the first element of a slice as "1"
+// instead of "0".
+// FieldPath keeps the bare indexes and map keys, so that the fields present in a partial update are still matched.
+func WithElementKeyFunc(f ElementKeyFunc) Option {
+	return func(o *options) {
+		o.elementKeyFunc = f
+	}
+}
+
+// DefaultElementKey formats the key of a collection element as is, e.g. "0" for the first element of a slice.
+func DefaultElementKey(key any, index bool) string {
+	return fmt.Sprintf("%v", key)
+}
+
+// OneBasedElementKey formats the index of a slice or array element as a 1-based index, e.g. "1" for the first
+// element, which reads better in the messages shown to users. The keys of map elements are formatted as is.
+func OneBasedElementKey(key any, index bool) string {
+	if i, ok := key.(int); ok && index {
+		return strconv.Itoa(i + 1)
+	}
+	return DefaultElementKey(key, index)
+}
+
+// elementKey returns the key of a collection element in the validation errors, which is def
+// unless set otherwise by WithElementKeyFunc.
+func (o *options) elementKey(def string, key any, index bool) string {
+	if o.elementKeyFunc == nil {
+		return def
+	}
+	return o.elementKeyFunc(key, index)
+}
+
+// errorFieldName returns the name of the struct field f in the validation errors.
+func (o *options) errorFieldName(f *reflect.StructField) string {
+	return o.transformFieldName(o.getErrorFieldNameFunc(f))
+}
+
+// transformFieldName returns the key in the validation errors of a field named name in FieldPath.
+func (o *options) transformFieldName(name string) string {
+	if o.fieldNameTransformer != nil {
+		return o.fieldNameTransformer(name)
+	}
+	return name
+}
+
+// WithValuer registers a ValuerFunc that extracts the values to validate from the values of type t.
+// If t is an interface type, f is used for all values implementing t. This allows independent packages
+// to register converters for their own types, for example,
+//
+//	ctx = validation.WithOptions(ctx,
+//	    validation.WithValuer(reflect.TypeOf(decimal.Decimal{}), func(v any) (any, bool) {
+//	        f, _ := v.(decimal.Decimal).Float64()
+//	        return f, true
+//	    }),
+//	)
+//
+// The valuers are chained: the valuers matching a value are tried from the latest registered one
+// until one of them returns true, and then the function set by WithValuerFunc is used as the fallback.
+func WithValuer(t reflect.Type, f ValuerFunc) Option {
+	return func(o *options) {
+		if t == nil || f == nil {
+			return
+		}
+		// copy the valuers so that the options of the parent context are not modified
+		valuers := make([]typedValuer, len(o.valuers), len(o.valuers)+1)
+		copy(valuers, o.valuers)
+		o.valuers = append(valuers, typedValuer{t: t, f: f})
+	}
+}
+
+// WithMaxWorkers sets the maximum number of fields that ValidateStructParallel validates concurrently,
+// and the maximum number of shards that ValidateAllParallel validates concurrently.
+// A value less than or equal to zero means runtime.GOMAXPROCS(0).
+func WithMaxWorkers(n int) Option {
+	return func(o *options) {
+		o.maxWorkers = n
+	}
+}
+
+// WithMaxErrors sets the maximum number of invalid items reported by ValidateAll and ValidateAllParallel,
+// as well as the invalid rows reported by csvvalidate, which stop validating once it is reached.
+// A value less than or equal to zero means no limit.
+func WithMaxErrors(n int) Option {
+	return func(o *options) {
+		o.maxErrors = n
+	}
+}
+
+// WithLookupCache memoizes the results of the lookups of UniqueIn rules in the context, so that a value is looked up
+// once per rule, however many times it is validated with the context. Set it on the context of a request to memoize
+// the lookups for the request, since the results are not refreshed.
+func WithLookupCache() Option {
+	return func(o *options) {
+		o.lookupCache = &lookupCache{}
+	}
+}
+
+// WithRuleCache sets the scope in which the results of the rules wrapped by Memoize are cached: for one validation
+// pass with RuleCachePass, or for all validations with the context with RuleCacheContext, which creates a new cache.
+// RuleCacheNone disables the cache.
+func WithRuleCache(scope RuleCacheScope) Option {
+	return func(o *options) {
+		o.ruleCacheScope = scope
+		o.ruleCache = nil
+		if scope == RuleCacheContext {
+			o.ruleCache = &ruleCache{}
+		}
+	}
+}
+
+// WithRuleTimeout sets the timeout applied to every rule executed by ValidateWithContext,
+// as if each rule was wrapped with Timeout(d, rule). Rules that only delegate to other rules,
+// such as Each, Map, When and nested struct rules, are not wrapped.
+// A non-positive d means no timeout.
+func WithRuleTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.ruleTimeout = d
+	}
+}
+
+// WithStructErrorKey sets the key under which the errors of struct-level rules are reported.
+// The default key is DefaultStructErrorKey.
+func WithStructErrorKey(key string) Option {
+	return func(o *options) {
+		if key != "" {
+			o.structErrorKey = key
+		}
+	}
+}
+
+// WithStringerConversion enables converting values that implement encoding.TextMarshaler or fmt.Stringer
+// into strings in all the rules accepting strings, such as the rules created by NewStringRule, Match, Date,
+// MaxDigits, DurationBetween, BeforeTime and AsInt. If a value implements both interfaces, MarshalText is used,
+// and an error returned by it is an InternalError. Values of string kinds are never converted.
+func WithStringerConversion(enabled bool) Option {
+	return func(o *options) {
+		o.stringerConversion = enabled
+	}
+}
+
+// WithIsZeroer sets whether the rules consider a value implementing IsZeroer empty if its IsZero method
+// returns true, e.g. so that Required rejects a zero decimal or a zero custom ID. It is enabled by default.
+// When disabled, such values are empty only if all their fields are zero.
+// The zero time.Time is always considered empty.
+func WithIsZeroer(enabled bool) Option {
+	return func(o *options) {
+		o.ignoreIsZeroer = !enabled
+	}
+}
+
+// WithIsEmptyFunc sets the function that decides whether a value is empty, instead of IsEmpty.
+// It affects Required, NilOrNotEmpty and Empty, and the other rules, such as Length and the string rules,
+// which skip empty values. The function receives the value with pointers and valuers resolved, and can
+// fall back to IsEmpty, for example, to treat whitespace-only strings as empty:
+//
+//	ctx = validation.WithOptions(ctx, validation.WithIsEmptyFunc(func(v any) bool {
+//	    if s, ok := v.(string); ok {
+//	        return strings.TrimSpace(s) == ""
+//	    }
+//	    return validation.IsEmpty(v)
+//	}))
+//
+// WithIsZeroer has no effect on the function. A nil function restores IsEmpty.
+func WithIsEmptyFunc(f IsEmptyFunc) Option {
+	return func(o *options) {
+		o.isEmptyFunc = f
+	}
+}
+
+// WithTrimSpace enables trimming the leading and trailing white space of strings and byte slices before
+// they are checked by the rules, so that e.g. Required rejects "   " and Length counts the trimmed characters.
+// The values themselves are not modified; use the Trim normalizer to clean the values.
+// A single rule can trim the value with the TrimSpace method of Required, Length, Match and the string rules.
+func WithTrimSpace(enabled bool) Option {
+	return func(o *options) {
+		o.trimSpace = enabled
+	}
+}
+
+// WithStrictFieldNames disables converting the first letter of the names given to NamedField to uppercase,
+// so that the names must match the Go field names exactly. It does not apply to the fields whose
+// match mode is set by NamedFieldRules.MatchBy.
+func WithStrictFieldNames(enabled bool) Option {
+	return func(o *options) {
+		o.strictFieldNames = enabled
+	}
+}
+
+// ErrorReporter is called with the path of a field, joined by dots as returned by FieldPath, and its error.
+// Errors that do not implement Error are reported as an Error with an empty code and the error string as the message.
+type ErrorReporter func(path string, err Error)
+
+// WithErrorReporter sets a function that is called for every leaf error returned by ValidateStructWithContext
+// and ValidateStructParallel, e.g. to count which rules fail most without walking the returned Errors.
+// The errors of nested structs are reported once, with their full paths, by the outermost call.
+// Internal errors are not reported.
+func WithErrorReporter(f ErrorReporter) Option {
+	return func(o *options) {
+		o.errorReporter = f
+	}
+}
+
+// WithPartial enables the partial validation mode, which is useful for sharing one rule set between
+// create and partial update (e.g. PATCH) requests. In this mode, only the struct fields present in the payload
+// are validated, so that rules such as Required do not apply to the fields left out of a partial update.
+//
+// The present fields are specified by their paths as returned by FieldPath and joined by dots,
+// e.g. "name", "address.street" or "items.0.sku". Anonymous struct fields are always validated,
+// and struct-level rules are not affected.
+func WithPartial(present map[string]bool) Option {
+	return func(o *options) {
+		o.partial = true
+		o.present = present
+	}
+}
+
+// WithDeepValidation enables walking the exported fields of structs and the elements of maps, slices and arrays
+// recursively, so that the Validatable values and the values with a Schema registered by WithSchema are validated
+// at every level, even if they are not listed in any field rules. It is useful for validating large nested values,
+// such as configurations, without listing every nested field, for example,
+//
+//	ctx = validation.WithOptions(ctx, validation.WithDeepValidation(true))
+//	err := validation.ValidateWithContext(ctx, &config)
+//
+// The walk stops at the values implementing Validatable and the values with a registered Schema,
+// which validate their own fields. Reference cycles are reported as an InternalError wrapping ErrCycle.
+func WithDeepValidation(enabled bool) Option {
+	return func(o *options) {
+		o.deep = enabled
+	}
+}
+
+// WithMaxDepth sets the maximum length of the path of a nested struct being validated, e.g. 2 for "address.geo".
+// Validating a struct nested deeper returns an InternalError wrapping ErrMaxDepth, which protects against
+// deeply nested tree-shaped payloads. A value less than or equal to zero means no limit.
+//
+// Regardless of this option, validating a struct that references itself through a pointer cycle returns
+// an InternalError wrapping ErrCycle.
+func WithMaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// WithNilStructError makes ValidateStructWithContext and ValidateStructParallel return ErrNotNilRequired
+// for a nil struct pointer, instead of treating it as valid. It is useful for validating decoded inputs,
+// where a nil pointer means that the input is missing, for example,
+//
+//	ctx = validation.WithOptions(ctx, validation.WithNilStructError(true))
+//	err := req.Validate(ctx) // "is required" if req is nil
+//
+// The nil struct pointers in fields are still considered valid, unless they are rejected by rules such as NotNil.
+func WithNilStructError(enabled bool) Option {
+	return func(o *options) {
+		o.nilStructError = enabled
+	}
+}
+
+// WithFS sets the file system in which FileExists and DirExists look up the paths, instead of the file system
+// of the operating system. It allows testing the validation of configurations that refer to files,
+// e.g. with an fstest.MapFS.
+func WithFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.fs = fsys
+	}
+}
+
+// WithSourceMap sets the positions of the validated values in their source file, e.g. a config file,
+// for example,
+//
+//	sm, _ := validation.SourceMapFromJSON("config.json", data)
+//	ctx = validation.WithOptions(ctx, validation.WithSourceMap(sm))
+//	err := validation.ValidateWithContext(ctx, &config)
+//
+// The errors of the struct fields reported by ValidateStructWithContext and ValidateStructParallel
+// carry the positions of the offending values, which are returned by ErrorPosition and printed by FprintErrors.
+// Only the errors implementing Error are located.
+func WithSourceMap(sm SourceMap) Option {
+	return func(o *options) {
+		o.sourceMap = sm
+	}
+}
+
+// WithRejectUnknownFields enables reporting the fields present in the payload that are not covered by any field
+// rules, which is useful for strict APIs that reject unexpected properties. The present fields are specified
+// in the same way as WithPartial, e.g. by jsonx.PresenceSet.Paths.
+//
+// For every struct validated by ValidateStructWithContext or ValidateStructParallel, the present fields directly
+// under the path of the struct that are not specified by its field rules are reported with ErrKeyUnexpected,
+// nested under the key set by WithUnknownErrorKey, e.g. "_unknown: (nickname: key not expected.)".
+// The fields of an anonymous struct field are covered by the field rules of the anonymous field.
+// Only the structs that are validated are checked, so the fields of a nested struct without its own
+// field rules are not reported.
+func WithRejectUnknownFields(present map[string]bool) Option {
+	return func(o *options) {
+		o.payload = present
+	}
+}
+
+// WithUnknownErrorKey sets the key under which the unknown fields are reported by WithRejectUnknownFields.
+// The default key is DefaultUnknownErrorKey.
+func WithUnknownErrorKey(key string) Option {
+	return func(o *options) {
+		if key != "" {
+			o.unknownErrorKey = key
+		}
+	}
+}
+
+// WithSchema registers a Schema that validates the values of the same struct type as value, which may be
+// a struct or a pointer to a struct. It allows validating struct types that do not implement Validatable,
+// which is especially useful for struct fields declared as interface types, for example,
+//
+//	type Order struct {
+//	    Payment PaymentMethod // an interface implemented by *Card, *BankTransfer, ...
+//	}
+//
+//	ctx = validation.WithOptions(ctx,
+//	    validation.WithSchema(&Card{}, cardSchema),
+//	    validation.WithSchema(&BankTransfer{}, bankTransferSchema),
+//	)
+//
+// ValidateWithContext validates a value with its registered Schema after the value passes the given rules,
+// instead of calling the Validate method of the value.
+func WithSchema(value interface{}, s *Schema) Option {
+	return func(o *options) {
+		t := reflect.TypeOf(value)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct || s == nil {
+			return
+		}
+		// copy the schemas so that the options of the parent context are not modified
+		schemas := make(map[reflect.Type]*Schema, len(o.schemas)+1)
+		for k, v := range o.schemas {
+			schemas[k] = v
+		}
+		schemas[t] = s
+		o.schemas = schemas
+	}
+}
+
+// schema returns the Schema registered by WithSchema for the type of value or the type it points to.
+func (o *options) schema(value interface{}) (*Schema, bool) {
+	if len(o.schemas) == 0 {
+		return nil, false
+	}
+	t := reflect.TypeOf(value)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	s, ok := o.schemas[t]
+	return s, ok
+}
+
 func getOpts(ctx context.Context) *options {
 	if ctx != nil {
 		if opts, ok := ctx.Value(optionsCtxKey).(*options); ok {
@@ -63,7 +602,7 @@ func getOpts(ctx context.Context) *options {
 		}
 	}
 
-	return defaultOptions
+	return defaultOptions.Load()
 }
 
 func GetOptions(ctx context.Context) Options {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +16,13 @@ func TestDefaultOptions(t *testing.T) {
 	assert.NotNil(t, opts)
 	assert.NotNil(t, opts.ValuerFunc())
 	assert.NotNil(t, opts.GetErrorFieldNameFunc())
+	assert.Equal(t, 0, opts.MaxWorkers())
+}
+
+func TestWithMaxWorkers(t *testing.T) {
+	ctx := WithOptions(context.Background(), WithMaxWorkers(4))
+	assert.Equal(t, 4, GetOptions(ctx).MaxWorkers())
+	assert.Equal(t, 0, DefaultOptions().MaxWorkers())
 }
 
 func TestWithValuerFunc(t *testing.T) {
@@ -35,6 +44,75 @@ func TestWithValuerFunc(t *testing.T) {
 	assert.Equal(t, "test", value)
 }
 
+type money struct {
+	cents int64
+}
+
+type moneyValuer interface {
+	Cents() int64
+}
+
+type wrappedMoney struct {
+	money
+}
+
+func (m wrappedMoney) Cents() int64 {
+	return m.cents
+}
+
+func TestWithValuer(t *testing.T) {
+	toCents := func(v any) (any, bool) {
+		return v.(money).cents, true
+	}
+	skip := func(v any) (any, bool) {
+		return v, false
+	}
+	viaInterface := func(v any) (any, bool) {
+		return v.(moneyValuer).Cents(), true
+	}
+	rule := Min(int64(100))
+
+	ctx := WithOptions(context.Background(),
+		WithValuer(reflect.TypeOf(money{}), toCents),
+		WithValuer(reflect.TypeOf(money{}), skip), // falls back to toCents
+		WithValuer(reflect.TypeOf((*moneyValuer)(nil)).Elem(), viaInterface),
+		WithValuer(nil, toCents),
+		WithValuer(reflect.TypeOf(money{}), nil),
+	)
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		value interface{}
+		err   string
+	}{
+		{"t1", ctx, money{cents: 150}, ""},
+		{"t2", ctx, money{cents: 50}, "must be no less than 100"},
+		{"t3", ctx, &money{cents: 50}, "must be no less than 100"},
+		{"t4", ctx, wrappedMoney{money{cents: 50}}, "must be no less than 100"},
+		{"t5", ctx, int64(50), "must be no less than 100"},
+		{"t6", context.Background(), money{cents: 50}, "cannot convert struct to int64"},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, test.value, rule)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// falls back to the ValuerFunc
+	ctx = WithOptions(ctx, WithValuerFunc(func(v any) (any, bool) {
+		if s, ok := v.(string); ok && s == "fallback" {
+			return int64(50), true
+		}
+		return v, false
+	}))
+	assertError(t, "must be no less than 100", ValidateWithContext(ctx, "fallback", rule), "t7")
+
+	// the parent options are not modified
+	parent := WithOptions(context.Background(), WithValuer(reflect.TypeOf(money{}), toCents))
+	_ = WithOptions(parent, WithValuer(reflect.TypeOf(money{}), func(v any) (any, bool) { return int64(0), true }))
+	assertError(t, "must be no less than 100", ValidateWithContext(parent, money{cents: 50}, rule), "t8")
+}
+
 func TestWithGetErrorFieldNameFunc(t *testing.T) {
 	type TestStruct struct {
 		Name  string `json:"name"`
@@ -88,34 +166,34 @@ func TestGetOptions(t *testing.T) {
 	// Test nil context, should return defaultOptions
 	opts1 := GetOptions(nil)
 	assert.NotNil(t, opts1)
-	assert.Equal(t, defaultOptions, opts1)
+	assert.Equal(t, defaultOptions.Load(), opts1)
 
 	// Test context without options, should return defaultOptions
 	ctx := context.Background()
 	opts2 := GetOptions(ctx)
 	assert.NotNil(t, opts2)
-	assert.Equal(t, defaultOptions, opts2)
+	assert.Equal(t, defaultOptions.Load(), opts2)
 
 	// Test context with options
 	customCtx := WithOptions(context.Background(), WithValuerFunc(DefaultValuer))
 	opts3 := GetOptions(customCtx)
 	assert.NotNil(t, opts3)
-	assert.NotEqual(t, defaultOptions, opts3)
+	assert.NotEqual(t, defaultOptions.Load(), opts3)
 }
 
 func TestGetOpts(t *testing.T) {
 	// Test getOpts function
 	opts1 := getOpts(nil)
-	assert.Equal(t, defaultOptions, opts1)
+	assert.Equal(t, defaultOptions.Load(), opts1)
 
 	ctx := context.Background()
 	opts2 := getOpts(ctx)
-	assert.Equal(t, defaultOptions, opts2)
+	assert.Equal(t, defaultOptions.Load(), opts2)
 
 	customCtx := WithOptions(context.Background(), WithValuerFunc(DefaultValuer))
 	opts3 := getOpts(customCtx)
 	assert.NotNil(t, opts3)
-	assert.NotEqual(t, defaultOptions, opts3)
+	assert.NotEqual(t, defaultOptions.Load(), opts3)
 }
 
 func TestOptionsInterface(t *testing.T) {
@@ -263,3 +341,71 @@ func TestGetErrorFieldNameFuncIntegration(t *testing.T) {
 		assert.True(t, hasLastName, "Expected lastName (from XML tag) in errors")
 	}
 }
+
+func TestSetDefaultOptions(t *testing.T) {
+	defer ResetDefaults()
+
+	type user struct {
+		Name string `json:"name"`
+	}
+	u := user{}
+	validate := func(ctx context.Context) error {
+		return ValidateStructWithContext(ctx, &u, Field(&u.Name, Required))
+	}
+	upperName := func(f *reflect.StructField) string { return strings.ToUpper(f.Name) }
+
+	SetDefaultOptions(WithGetErrorFieldNameFunc(upperName))
+	ctx := WithOptions(context.Background(), WithStringerConversion(true))
+	assert.EqualError(t, validate(context.Background()), "NAME: cannot be blank.")
+	assert.EqualError(t, validate(ctx), "NAME: cannot be blank.")
+
+	// the options are applied on top of the current defaults
+	SetDefaultOptions(WithStructErrorKey("_"))
+	assert.Equal(t, "_", DefaultOptions().StructErrorKey())
+	assert.EqualError(t, validate(nil), "NAME: cannot be blank.")
+
+	ResetDefaults()
+	assert.EqualError(t, validate(context.Background()), "name: cannot be blank.")
+	assert.Equal(t, DefaultStructErrorKey, DefaultOptions().StructErrorKey())
+	// the contexts created before keep the defaults they copied
+	assert.EqualError(t, validate(ctx), "NAME: cannot be blank.")
+}
+
+func TestSetDefaultOptions_Caches(t *testing.T) {
+	defer ResetDefaults()
+
+	// the per-context caches are not shared by all the validations
+	SetDefaultOptions(WithLookupCache(), WithRuleCache(RuleCacheContext))
+	opts := getOpts(context.Background())
+	assert.Nil(t, opts.lookupCache)
+	assert.Nil(t, opts.ruleCache)
+	assert.Equal(t, RuleCacheNone, opts.ruleCacheScope)
+
+	SetDefaultOptions(WithRuleCache(RuleCachePass))
+	SetDefaultOptions(WithRuleCache(RuleCacheContext))
+	assert.Equal(t, RuleCachePass, getOpts(context.Background()).ruleCacheScope)
+
+	// they can still be set on a context
+	ctx := WithOptions(context.Background(), WithLookupCache(), WithRuleCache(RuleCacheContext))
+	assert.NotNil(t, getOpts(ctx).lookupCache)
+	assert.NotNil(t, ruleCacheFrom(ctx))
+}
+
+func TestSetDefaultOptions_Concurrent(t *testing.T) {
+	defer ResetDefaults()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefaultOptions(WithStringerConversion(true))
+		}()
+		go func() {
+			defer wg.Done()
+			_ = Validate("a", Required)
+		}()
+	}
+	wg.Wait()
+	assert.True(t, DefaultOptions().StringerConversion())
+}
@@ -0,0 +1,131 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+)
+
+var _ Rule = (*OrderedRule[int])(nil)
+
+// ErrBetweenInvalid is the error that returns when a value is not within a specified range.
+var ErrBetweenInvalid = NewError("validation_between_invalid", "must be between {{.min}} and {{.max}}")
+
+// Ordered is a constraint that permits any type that supports the operators < <= >= >.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// OrderedRule is a validation rule that compares a value of type T with the specified bounds.
+// Unlike Min and Max, the comparison uses the operators of T directly instead of reflection.
+type OrderedRule[T Ordered] struct {
+	min, max         T
+	hasMin, hasMax   bool
+	minExcl, maxExcl bool
+	err              Error
+}
+
+// GT returns a validation rule that checks if a value is strictly greater than v.
+// The value being checked must be of type T or *T.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func GT[T Ordered](v T) OrderedRule[T] {
+	return OrderedRule[T]{min: v, hasMin: true, minExcl: true, err: ErrMinGreaterThanRequired}
+}
+
+// GTE returns a validation rule that checks if a value is greater than or equal to v.
+// The value is handled in the same way as GT.
+func GTE[T Ordered](v T) OrderedRule[T] {
+	return OrderedRule[T]{min: v, hasMin: true, err: ErrMinGreaterEqualThanRequired}
+}
+
+// LT returns a validation rule that checks if a value is strictly less than v.
+// The value is handled in the same way as GT.
+func LT[T Ordered](v T) OrderedRule[T] {
+	return OrderedRule[T]{max: v, hasMax: true, maxExcl: true, err: ErrMaxLessThanRequired}
+}
+
+// LTE returns a validation rule that checks if a value is less than or equal to v.
+// The value is handled in the same way as GT.
+func LTE[T Ordered](v T) OrderedRule[T] {
+	return OrderedRule[T]{max: v, hasMax: true, err: ErrMaxLessEqualThanRequired}
+}
+
+// Between returns a validation rule that checks if a value is within the range [min, max], bounds included.
+// The value is handled in the same way as GT.
+func Between[T Ordered](min, max T) OrderedRule[T] {
+	return OrderedRule[T]{min: min, max: max, hasMin: true, hasMax: true, err: ErrBetweenInvalid}
+}
+
+// Error sets the error message for the rule.
+func (r OrderedRule[T]) Error(message string) OrderedRule[T] {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r OrderedRule[T]) ErrorObject(err Error) OrderedRule[T] {
+	r.err = err
+	return r
+}
+
+// Validate checks if the given value is within the bounds of the rule.
+func (r OrderedRule[T]) Validate(ctx context.Context, value interface{}) error {
+	v, ok := value.(T)
+	if !ok {
+		if p, isPtr := value.(*T); isPtr {
+			if p == nil {
+				return nil
+			}
+			v = *p
+		} else {
+			// fall back to the valuer, e.g. for sql.NullInt64
+			value, isNil := indirectWithOptions(value, GetOptions(ctx))
+			if isNil {
+				return nil
+			}
+			if v, ok = value.(T); !ok {
+				return fmt.Errorf("cannot convert %T to %T", value, v)
+			}
+		}
+	}
+
+	var zero T
+	if v == zero || r.inRange(v) {
+		return nil
+	}
+
+	switch {
+	case r.hasMin && r.hasMax:
+		return r.err.SetParams(map[string]interface{}{"min": r.min, "max": r.max})
+	case r.hasMin:
+		return r.err.SetParams(map[string]interface{}{"threshold": r.min})
+	default:
+		return r.err.SetParams(map[string]interface{}{"threshold": r.max})
+	}
+}
+
+func (r OrderedRule[T]) inRange(v T) bool {
+	if r.hasMin && (v < r.min || r.minExcl && v == r.min) {
+		return false
+	}
+	if r.hasMax && (v > r.max || r.maxExcl && v == r.max) {
+		return false
+	}
+	return true
+}
+
+// Metadata returns the description of the rule.
+// GT and GTE are described as "min", and LT and LTE as "max", with the params "threshold" and "exclusive",
+// like Min and Max. Between is described as "between", with the params "min" and "max".
+func (r OrderedRule[T]) Metadata() RuleInfo {
+	switch {
+	case r.hasMin && r.hasMax:
+		return RuleInfo{Kind: "between", Params: map[string]interface{}{"min": r.min, "max": r.max}}
+	case r.hasMin:
+		return RuleInfo{Kind: "min", Params: map[string]interface{}{"threshold": r.min, "exclusive": r.minExcl}}
+	default:
+		return RuleInfo{Kind: "max", Params: map[string]interface{}{"threshold": r.max, "exclusive": r.maxExcl}}
+	}
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type myInt int
+
+func TestOrderedRules(t *testing.T) {
+	var nilInt *int
+	five := 5
+
+	tests := []struct {
+		tag   string
+		rule  Rule
+		value interface{}
+		err   string
+	}{
+		{"t1", GT(2), 3, ""},
+		{"t2", GT(2), 2, "must be greater than 2"},
+		{"t3", GT(2), 0, ""},
+		{"t4", GT(2), nilInt, ""},
+		{"t5", GT(2), &five, ""},
+		{"t6", GTE(5), 5, ""},
+		{"t7", GTE(5), 4, "must be no less than 5"},
+		{"t8", LT(5), 5, "must be less than 5"},
+		{"t9", LT(5), -1, ""},
+		{"t10", LTE(5), 5, ""},
+		{"t11", LTE(5), 6, "must be no greater than 5"},
+		{"t12", Between(1.5, 2.5), 2.5, ""},
+		{"t13", Between(1.5, 2.5), 2.6, "must be between 1.5 and 2.5"},
+		{"t14", Between("b", "d"), "c", ""},
+		{"t15", Between("b", "d"), "a", "must be between b and d"},
+		{"t16", GT(myInt(2)), myInt(1), "must be greater than 2"},
+		{"t17", GT(uint8(2)), uint8(3), ""},
+		{"t18", GT(int64(2)), sql.NullInt64{Int64: 1, Valid: true}, "must be greater than 2"},
+		{"t19", GT(int64(2)), sql.NullInt64{}, ""},
+		{"t20", GT(2), int64(3), "cannot convert int64 to int"},
+		{"t21", GT(2).Error("too small"), 1, "too small"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := NewError("code", "abc")
+	r := Between(1, 2).ErrorObject(err)
+	assert.True(t, errors.Is(r.Validate(context.Background(), 3), err))
+}
+
+func TestOrderedRule_Metadata(t *testing.T) {
+	assert.Equal(t, RuleInfo{Kind: "min", Params: map[string]interface{}{"threshold": 1, "exclusive": true}}, GT(1).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "min", Params: map[string]interface{}{"threshold": 1, "exclusive": false}}, GTE(1).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "max", Params: map[string]interface{}{"threshold": 1, "exclusive": true}}, LT(1).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "max", Params: map[string]interface{}{"threshold": 1, "exclusive": false}}, LTE(1).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "between", Params: map[string]interface{}{"min": 1, "max": 2}}, Between(1, 2).Metadata())
+}
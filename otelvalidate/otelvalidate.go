@@ -0,0 +1,97 @@
+// Package otelvalidate provides validation functions that record OpenTelemetry spans,
+// so that slow or failing validation shows up in distributed traces.
+//
+// It is a separate module so that the validation package does not depend on OpenTelemetry.
+package otelvalidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rockcookies/go-validation"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name of the tracer.
+const ScopeName = "github.com/rockcookies/go-validation/otelvalidate"
+
+// Attribute keys set on the spans.
+const (
+	// TypeKey is the Go type of the value being validated.
+	TypeKey = attribute.Key("validation.type")
+	// FieldsKey lists the paths of the failing fields, e.g. "address.street".
+	FieldsKey = attribute.Key("validation.error.fields")
+	// CodesKey lists the error codes of the failing fields, in the same order as FieldsKey.
+	// Errors without a code are listed as empty strings.
+	CodesKey = attribute.Key("validation.error.codes")
+)
+
+// ValidateStruct validates a struct like validation.ValidateStructWithContext within a span named
+// "validation.ValidateStruct", using the global TracerProvider.
+func ValidateStruct(ctx context.Context, structPtr interface{}, fields ...validation.FieldRules) error {
+	ctx, span := start(ctx, "validation.ValidateStruct", structPtr)
+	defer span.End()
+
+	err := validation.ValidateStructWithContext(ctx, structPtr, fields...)
+	record(span, err)
+	return err
+}
+
+// Validate validates a value like validation.ValidateWithContext within a span named
+// "validation.Validate", using the global TracerProvider.
+func Validate(ctx context.Context, value interface{}, rules ...validation.Rule) error {
+	ctx, span := start(ctx, "validation.Validate", value)
+	defer span.End()
+
+	err := validation.ValidateWithContext(ctx, value, rules...)
+	record(span, err)
+	return err
+}
+
+func start(ctx context.Context, name string, value interface{}) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(ScopeName).Start(ctx, name, trace.WithAttributes(TypeKey.String(fmt.Sprintf("%T", value))))
+}
+
+// record annotates the span with the result of the validation. Validation errors set the attributes
+// FieldsKey and CodesKey, and internal errors are recorded as span errors.
+func record(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	var ie validation.InternalError
+	if errors.As(err, &ie) && ie.InternalError() != nil {
+		span.RecordError(ie.InternalError())
+		span.SetStatus(codes.Error, ie.InternalError().Error())
+		return
+	}
+
+	var paths, errCodes []string
+	var errs validation.Errors
+	if errors.As(err, &errs) {
+		for _, fe := range errs.Flatten() {
+			paths = append(paths, fe.Path.String())
+			errCodes = append(errCodes, code(fe.Err))
+		}
+	} else {
+		paths, errCodes = []string{""}, []string{code(err)}
+	}
+	span.SetAttributes(FieldsKey.StringSlice(paths), CodesKey.StringSlice(errCodes))
+	span.SetStatus(codes.Error, "validation failed")
+}
+
+// code returns the code of the validation error, or an empty string if the error has no code.
+func code(err error) string {
+	var e validation.Error
+	if errors.As(err, &e) {
+		return e.Code()
+	}
+	return ""
+}
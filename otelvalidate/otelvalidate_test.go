@@ -0,0 +1,74 @@
+package otelvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type address struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip"`
+}
+
+type user struct {
+	Name    string  `json:"name"`
+	Address address `json:"address"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	u := user{Address: address{Zip: "1"}}
+	err := ValidateStruct(context.Background(), &u,
+		validation.Field(&u.Name, validation.Required),
+		validation.FieldStruct(&u.Address,
+			validation.Field(&u.Address.Street, validation.Required),
+			validation.Field(&u.Address.Zip, validation.By(func(context.Context, interface{}) error {
+				return errors.New("invalid zip")
+			})),
+		),
+	)
+	assert.Error(t, err)
+
+	u.Name = "John"
+	err = Validate(context.Background(), u.Name, validation.Required)
+	assert.NoError(t, err)
+
+	err = Validate(context.Background(), "", validation.By(func(context.Context, interface{}) error {
+		return validation.NewInternalError(errors.New("boom"))
+	}))
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	if !assert.Len(t, spans, 3) {
+		return
+	}
+
+	assert.Equal(t, "validation.ValidateStruct", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		TypeKey.String("*otelvalidate.user"),
+		FieldsKey.StringSlice([]string{"address.street", "address.zip", "name"}),
+		CodesKey.StringSlice([]string{"validation_required", "", "validation_required"}),
+	}, spans[0].Attributes())
+
+	assert.Equal(t, "validation.Validate", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	assert.Equal(t, []attribute.KeyValue{TypeKey.String("string")}, spans[1].Attributes())
+
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+	assert.Equal(t, "boom", spans[2].Status().Description)
+	if assert.Len(t, spans[2].Events(), 1) {
+		assert.Equal(t, "exception", spans[2].Events()[0].Name)
+	}
+}
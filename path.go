@@ -0,0 +1,294 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var _ Rule = (*pathRule)(nil)
+
+// pathSegment is a segment of a field path, which is either a struct field name or
+// a slice index or map key written in brackets.
+type pathSegment struct {
+	name  string
+	index string
+}
+
+// parsePath parses a field path such as "Address.Street" or "Items[0].SKU" into segments.
+// The path must start with a field name.
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	rest := path
+	for i := 0; rest != ""; i++ {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid field path %q: missing ]", path)
+			}
+			if end == 1 || i == 0 {
+				return nil, fmt.Errorf("invalid field path %q", path)
+			}
+			segments = append(segments, pathSegment{index: rest[1:end]})
+			rest = rest[end+1:]
+		default:
+			if i > 0 {
+				if rest[0] != '.' {
+					return nil, fmt.Errorf("invalid field path %q", path)
+				}
+				rest = rest[1:]
+			}
+			end := strings.IndexAny(rest, ".[]")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid field path %q", path)
+			}
+			segments = append(segments, pathSegment{name: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("invalid field path %q", path)
+	}
+	return segments, nil
+}
+
+// pathString returns the path of the segments in the same notation as parsePath.
+func pathString(segments []pathSegment) string {
+	var sb strings.Builder
+	for _, seg := range segments {
+		if seg.name != "" {
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(seg.name)
+		} else {
+			sb.WriteString("[" + seg.index + "]")
+		}
+	}
+	return sb.String()
+}
+
+// pathRule validates the value found by following a path from the value being validated.
+// It is used by NamedField for the segments after the first one.
+type pathRule struct {
+	path           []pathSegment
+	rules          []Rule
+	ptrValue       bool
+	skipIfNotFound bool
+	match          FieldMatch
+	tag            string
+}
+
+// Validate follows the path from the value and validates the value found with the rules.
+// The errors are nested by the path segments, e.g. Errors{"street": err} for the path ".Street".
+// A "*" index applies the rest of the path to every element of a slice, array or map.
+// If the path goes through a nil pointer, a missing slice element or a missing map key,
+// the rules are applied to nil.
+func (r pathRule) Validate(ctx context.Context, value interface{}) error {
+	return r.validate(ctx, reflect.ValueOf(value), false, r.path)
+}
+
+func (r pathRule) validate(ctx context.Context, v reflect.Value, missing bool, path []pathSegment) error {
+	if len(path) == 0 {
+		return ValidateWithContext(ctx, r.leafValue(v, missing), r.rules...)
+	}
+
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			missing = true
+			if v.Kind() == reflect.Interface {
+				v = reflect.Value{}
+				break
+			}
+			v = reflect.Zero(v.Type().Elem())
+			continue
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		// the type of the remaining path is unknown
+		return ValidateWithContext(ctx, nil, r.rules...)
+	}
+
+	seg := path[0]
+	var (
+		key  string
+		next reflect.Value
+	)
+	nextMissing := missing
+	if seg.name != "" {
+		if v.Kind() != reflect.Struct {
+			return NewInternalError(fmt.Errorf("cannot look up field %q in %v", seg.name, v.Type()))
+		}
+		sf, ok := lookupField(v.Type(), seg.name, r.match, r.tag, getOpts(ctx).strictFieldNames)
+		if !ok {
+			if r.skipIfNotFound {
+				return nil
+			}
+			return ErrFieldRequired.SetParams(map[string]any{"field_name": seg.name})
+		}
+		next, ok = fieldByIndex(v, sf)
+		nextMissing = missing || !ok
+		opts := getOpts(ctx)
+		name := opts.getErrorFieldNameFunc(&sf)
+		key = opts.transformFieldName(name)
+		parent, _ := structElemPtr(v)
+		ctx = withField(ctx, parent, &sf, name, nil)
+	} else if seg.index == "*" {
+		return r.validateEach(ctx, v, missing, path[1:])
+	} else {
+		var err error
+		next, err = indexValue(v, seg.index)
+		if err != nil {
+			return NewInternalError(err)
+		}
+		if !next.IsValid() {
+			next, nextMissing = reflect.Zero(v.Type().Elem()), true
+		}
+		key = seg.index
+		ctx = withPathSegment(ctx, key)
+	}
+
+	if err := r.validate(ctx, next, nextMissing, path[1:]); err != nil {
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return err
+		}
+		return Errors{key: err}
+	}
+	return nil
+}
+
+// validateEach validates every element of the slice, array or map v with the remaining path.
+// The errors are keyed by the element indexes or map keys.
+func (r pathRule) validateEach(ctx context.Context, v reflect.Value, missing bool, path []pathSegment) error {
+	errs := Errors{}
+	opts := getOpts(ctx)
+	validate := func(key string, elemKey interface{}, index bool, elem reflect.Value) error {
+		err := r.validate(withElement(ctx, key, elemKey), elem, missing, path)
+		if err == nil {
+			return nil
+		}
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return err
+		}
+		errs[opts.elementKey(key, elemKey, index)] = err
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validate(strconv.Itoa(i), i, true, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if err := validate(fmt.Sprintf("%v", k.Interface()), k.Interface(), false, v.MapIndex(k)); err != nil {
+				return err
+			}
+		}
+	default:
+		return NewInternalError(fmt.Errorf("cannot index %v with %q", v.Type(), "*"))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// leafValue returns the value found by the path. A pointer to the value is returned if the rules
+// validate nested struct fields, in which case a missing value is a nil pointer.
+func (r pathRule) leafValue(v reflect.Value, missing bool) interface{} {
+	switch {
+	case !v.IsValid():
+		return nil
+	case !r.ptrValue:
+		if missing {
+			return nil
+		}
+		return v.Interface()
+	case v.Kind() == reflect.Ptr:
+		return v.Interface()
+	case missing:
+		return reflect.Zero(reflect.PtrTo(v.Type())).Interface()
+	}
+	ptr, _ := structElemPtr(v)
+	return ptr
+}
+
+// Metadata returns the description of the rule.
+// The kind is "path", with the param "path" holding the path after the first field name, e.g. "Street"
+// for "Address.Street", and the param "rules" holding the rules of the value found as []Rule.
+func (r pathRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "path",
+		Params: map[string]interface{}{"path": pathString(r.path), "rules": r.rules},
+	}
+}
+
+// fieldByIndex returns the value of the struct field. If the field is promoted through a nil embedded
+// struct pointer, the zero value of the field and false are returned.
+func fieldByIndex(v reflect.Value, sf reflect.StructField) (reflect.Value, bool) {
+	fv, err := v.FieldByIndexErr(sf.Index)
+	if err != nil {
+		return reflect.Zero(sf.Type), false
+	}
+	return fv, true
+}
+
+// indexValue returns the element of the slice, array or map at the given index or key.
+// An invalid reflect.Value is returned if the index is out of range or the key does not exist.
+func indexValue(v reflect.Value, index string) (reflect.Value, error) {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid index %q for %v", index, v.Type())
+		}
+		if i < 0 || i >= v.Len() {
+			return reflect.Value{}, nil
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		k, err := mapKey(v.Type().Key(), index)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return v.MapIndex(k), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot index %v with %q", v.Type(), index)
+}
+
+// mapKey converts the key written in a field path to a map key of type t.
+func mapKey(t reflect.Type, key string) (reflect.Value, error) {
+	k := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		k.SetString(key)
+		return k, nil
+	case reflect.Interface:
+		if reflect.TypeOf(key).Implements(t) {
+			k.Set(reflect.ValueOf(key))
+			return k, nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(key, 10, t.Bits()); err == nil {
+			k.SetInt(i)
+			return k, nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i, err := strconv.ParseUint(key, 10, t.Bits()); err == nil {
+			k.SetUint(i)
+			return k, nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("invalid key %q for %v", key, t)
+}
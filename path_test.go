@@ -0,0 +1,246 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		tag      string
+		path     string
+		segments []pathSegment
+		err      string
+	}{
+		{"t1", "Name", []pathSegment{{name: "Name"}}, ""},
+		{"t2", "Address.Street", []pathSegment{{name: "Address"}, {name: "Street"}}, ""},
+		{"t3", "Items[0].SKU", []pathSegment{{name: "Items"}, {index: "0"}, {name: "SKU"}}, ""},
+		{"t4", "Labels[env][1]", []pathSegment{{name: "Labels"}, {index: "env"}, {index: "1"}}, ""},
+		{"t4.1", "Items[*].SKU", []pathSegment{{name: "Items"}, {index: "*"}, {name: "SKU"}}, ""},
+		{"t5", "", nil, `invalid field path ""`},
+		{"t6", ".Name", nil, `invalid field path ".Name"`},
+		{"t7", "Name.", nil, `invalid field path "Name."`},
+		{"t8", "A..B", nil, `invalid field path "A..B"`},
+		{"t9", "[0]", nil, `invalid field path "[0]"`},
+		{"t10", "Items[]", nil, `invalid field path "Items[]"`},
+		{"t11", "Items[0", nil, `invalid field path "Items[0": missing ]`},
+		{"t12", "Items[0]SKU", nil, `invalid field path "Items[0]SKU"`},
+		{"t13", "Items]", nil, `invalid field path "Items]"`},
+	}
+	for _, test := range tests {
+		segments, err := parsePath(test.path)
+		assertError(t, test.err, err, test.tag)
+		assert.Equal(t, test.segments, segments, test.tag)
+		if err == nil {
+			assert.Equal(t, test.path, pathString(segments), test.tag)
+		}
+	}
+}
+
+type pathAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type pathItem struct {
+	SKU string `json:"sku"`
+}
+
+type pathOrder struct {
+	Address  *pathAddress           `json:"address"`
+	Items    []pathItem             `json:"items"`
+	Labels   map[string]string      `json:"labels"`
+	Shipping map[int]pathAddress    `json:"shipping"`
+	Extra    interface{}            `json:"extra"`
+	Meta     map[string]interface{} `json:"meta"`
+}
+
+func TestNamedField_Path(t *testing.T) {
+	fields := []FieldRules{
+		NamedField("Address.Street", Required),
+		NamedField("address.city", Length(2, 10)),
+		NamedField("Items[0].SKU", Required),
+		NamedField("Labels[env]", In("dev", "prod")),
+		NamedField("Shipping[1].City", Required),
+	}
+
+	tests := []struct {
+		tag   string
+		value pathOrder
+		err   string
+	}{
+		{"t1", pathOrder{
+			Address:  &pathAddress{Street: "main", City: "Paris"},
+			Items:    []pathItem{{SKU: "a"}},
+			Labels:   map[string]string{"env": "dev"},
+			Shipping: map[int]pathAddress{1: {City: "Rome"}},
+		}, ""},
+		{"t2", pathOrder{}, "address: (street: cannot be blank.); items: (0: (sku: cannot be blank.).); shipping: (1: (city: cannot be blank.).)."},
+		{"t3", pathOrder{
+			Address:  &pathAddress{City: "X"},
+			Items:    []pathItem{{}, {SKU: "b"}},
+			Labels:   map[string]string{"env": "test"},
+			Shipping: map[int]pathAddress{1: {City: "Rome"}},
+		}, "address: (city: the length must be between 2 and 10; street: cannot be blank.); items: (0: (sku: cannot be blank.).); labels: (env: must be a valid value.)."},
+	}
+	for _, test := range tests {
+		o := test.value
+		err := ValidateStructWithContext(context.Background(), &o, fields...)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	o := pathOrder{Items: []pathItem{{}}}
+	err := ValidateStructWithContext(context.Background(), &o, NamedField("Items[0].SKU", Required))
+	if assert.Error(t, err) {
+		flat := err.(Errors).Flatten()
+		if assert.Len(t, flat, 1) {
+			assert.Equal(t, "items.0.sku", flat[0].Path.String())
+		}
+	}
+
+	infos, err := Inspect(&o, NamedField("Items[0].SKU", Required))
+	assert.NoError(t, err)
+	assert.Equal(t, []FieldInfo{{Name: "items", Rules: []RuleInfo{{
+		Kind:   "path",
+		Params: map[string]interface{}{"path": "[0].SKU", "rules": []RuleInfo{{Kind: "required"}}},
+	}}}}, infos)
+}
+
+func TestNamedField_Wildcard(t *testing.T) {
+	type line struct {
+		Quantity int `json:"quantity"`
+	}
+	type cart struct {
+		Lines  []*line          `json:"lines"`
+		Groups [][]line         `json:"groups"`
+		ByName map[string]line  `json:"by_name"`
+		Tags   []string         `json:"tags"`
+		Codes  map[int][]string `json:"codes"`
+		Count  int              `json:"count"`
+	}
+
+	tests := []struct {
+		tag   string
+		value cart
+		field FieldRules
+		err   string
+	}{
+		{"t1", cart{}, NamedField("Lines[*].Quantity", Min(1)), ""},
+		{"t2", cart{Lines: []*line{{1}, {-1}, {2}, {-2}}}, NamedField("Lines[*].Quantity", Min(1)),
+			"lines: (1: (quantity: must be no less than 1.); 3: (quantity: must be no less than 1.).)."},
+		{"t3", cart{Lines: []*line{nil}}, NamedField("Lines[*].Quantity", Required), "lines: (0: (quantity: cannot be blank.).)."},
+		{"t4", cart{Groups: [][]line{{{1}}, {{1}, {-1}}}}, NamedField("Groups[*][*].Quantity", Min(1)),
+			"groups: (1: (1: (quantity: must be no less than 1.).).)."},
+		{"t5", cart{ByName: map[string]line{"a": {1}, "b": {-1}}}, NamedField("ByName[*].Quantity", Min(1)),
+			"by_name: (b: (quantity: must be no less than 1.).)."},
+		{"t6", cart{Tags: []string{"a", ""}}, NamedField("Tags[*]", Required), "tags: (1: cannot be blank.)."},
+		{"t7", cart{Codes: map[int][]string{3: {"", "x"}}}, NamedField("Codes[*][0]", Required), "codes: (3: (0: cannot be blank.).)."},
+		{"t8", cart{Count: 1}, NamedField("Count[*]", Required), `cannot index int with "*"`},
+		{"t9", cart{Lines: []*line{{1}}}, NamedField("Lines[*].Qty", Required), `lines: (0: missing required field: Qty.).`},
+		{"t10", cart{Groups: [][]line{{{1}}}}, NamedField("Groups[*].Quantity", Required), `cannot look up field "Quantity" in []validation.line`},
+	}
+	for _, test := range tests {
+		c := test.value
+		err := ValidateStructWithContext(context.Background(), &c, test.field)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// wildcard and indexed paths sharing the same prefix are merged
+	c := cart{Lines: []*line{{-1}, {0}}}
+	err := ValidateStructWithContext(context.Background(), &c,
+		NamedField("Lines[*].Quantity", Min(1)),
+		NamedField("Lines[1].Quantity", Required),
+	)
+	assertError(t, "lines: (0: (quantity: must be no less than 1.); 1: (quantity: cannot be blank.).).", err, "t11")
+}
+
+func TestNamedField_PathContext(t *testing.T) {
+	o := pathOrder{Address: &pathAddress{}, Items: []pathItem{{}}}
+	var paths []string
+	var parents []interface{}
+	record := By(func(ctx context.Context, value interface{}) error {
+		paths = append(paths, FieldPath(ctx).String())
+		parent, _ := Parent(ctx)
+		parents = append(parents, parent)
+		return nil
+	})
+	err := ValidateStructWithContext(context.Background(), &o,
+		NamedField("Address.Street", record),
+		NamedField("Items[0].SKU", record),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"address.street", "items.0.sku"}, paths)
+	assert.Equal(t, []interface{}{o.Address, &o.Items[0]}, parents)
+}
+
+func TestNamedField_PathErrors(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value pathOrder
+		field FieldRules
+		err   string
+	}{
+		{"t1", pathOrder{}, NamedField("Items[", Required), `invalid field path "Items[": missing ]`},
+		{"t2", pathOrder{}, NamedField("Address.Zip", Required), "address: missing required field: Zip."},
+		{"t3", pathOrder{}, NamedField("Address.Zip", Required).SetSkipIfNotFound(true), ""},
+		{"t4", pathOrder{Items: []pathItem{{}}}, NamedField("Items[x].SKU"), `invalid index "x" for []validation.pathItem`},
+		{"t5", pathOrder{Shipping: map[int]pathAddress{}}, NamedField("Shipping[x].City"), `invalid key "x" for int`},
+		{"t6", pathOrder{Labels: map[string]string{}}, NamedField("Labels.env"), `cannot look up field "env" in map[string]string`},
+		{"t7", pathOrder{Labels: map[string]string{"a": "b"}}, NamedField("Labels[a][0]"), `cannot index string with "0"`},
+		{"t8", pathOrder{Extra: nil}, NamedField("Extra.Name", Required), "extra: cannot be blank."},
+		{"t9", pathOrder{Extra: &pathAddress{}}, NamedField("Extra.Street", Required), "extra: (street: cannot be blank.)."},
+		{"t10", pathOrder{Meta: map[string]interface{}{"a": 1}}, NamedField("Meta[a]", Min(2)), "meta: (a: must be no less than 2.)."},
+		{"t11", pathOrder{Meta: map[string]interface{}{}}, NamedField("Meta[a]", Required), "meta: (a: cannot be blank.)."},
+	}
+	for _, test := range tests {
+		o := test.value
+		err := ValidateStructWithContext(context.Background(), &o, test.field)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestNamedStructField_Path(t *testing.T) {
+	type wrapper struct {
+		Order  pathOrder    `json:"order"`
+		Orders []*pathOrder `json:"orders"`
+	}
+	street := NamedField("Street", Required)
+
+	w := wrapper{}
+	err := ValidateStructWithContext(context.Background(), &w,
+		NamedStructField("Order.Address", street),
+		NamedStructField("Orders[0].Address", street),
+	)
+	assert.NoError(t, err)
+
+	w = wrapper{Order: pathOrder{Address: &pathAddress{}}, Orders: []*pathOrder{{Address: &pathAddress{}}}}
+	err = ValidateStructWithContext(context.Background(), &w,
+		NamedStructField("Order.Address", street),
+		NamedStructField("Orders[0].Address", street),
+	)
+	assertError(t, "order: (address: (street: cannot be blank.).); orders: (0: (address: (street: cannot be blank.).).).", err, "t1")
+
+	type nested struct {
+		Inner struct {
+			Address pathAddress `json:"address"`
+		} `json:"inner"`
+	}
+	n := nested{}
+	err = ValidateStructWithContext(context.Background(), &n, NamedStructField("Inner.Address", street))
+	assertError(t, "inner: (address: (street: cannot be blank.).).", err, "t2")
+}
+
+func TestErrors_merge(t *testing.T) {
+	es := Errors{}
+	es.merge("a", Errors{"b": ErrRequired})
+	es.merge("a", Errors{"c": ErrNil, "d": Errors{"e": ErrRequired}})
+	es.merge("a", Errors{"d": Errors{"f": ErrNil}})
+	es.merge("g", ErrRequired)
+	es.merge("g", Errors{"h": ErrRequired})
+	assert.Equal(t, Errors{
+		"a": Errors{"b": ErrRequired, "c": ErrNil, "d": Errors{"e": ErrRequired, "f": ErrNil}},
+		"g": Errors{"h": ErrRequired},
+	}, es)
+}
@@ -0,0 +1,17 @@
+module github.com/rockcookies/go-validation/pbvalidate
+
+go 1.21
+
+replace github.com/rockcookies/go-validation => ../
+
+require (
+	github.com/rockcookies/go-validation v0.0.0
+	github.com/stretchr/testify v1.8.1
+	google.golang.org/protobuf v1.34.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
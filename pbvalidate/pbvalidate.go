@@ -0,0 +1,181 @@
+// Package pbvalidate validates protobuf messages with rules registered by message full name,
+// so that the generated structs can be validated without field pointers into the generated code.
+//
+// It is a separate module so that the validation package does not depend on protobuf.
+package pbvalidate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/rockcookies/go-validation"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var registry = struct {
+	sync.RWMutex
+	messages map[protoreflect.FullName][]*validation.FieldSpec
+}{
+	messages: map[protoreflect.FullName][]*validation.FieldSpec{},
+}
+
+// Register registers the rules of the fields of the messages with the given full name, e.g. "acme.user.v1.User".
+// The fields are specified by their names in the .proto file or their JSON names. For example,
+//
+//	pbvalidate.Register("acme.user.v1.User",
+//		validation.Spec("name", validation.Required, validation.Length(1, 100)),
+//		validation.Spec("email", validation.Required, is.Email),
+//		validation.Spec("status", validation.EnumOf(pb.Status_ACTIVE, pb.Status_INACTIVE)),
+//	)
+//
+// Register is typically called from an init function.
+// It panics if the name is empty or the rules of the message are already registered.
+func Register(fullName protoreflect.FullName, fields ...*validation.FieldSpec) {
+	if fullName == "" {
+		panic("pbvalidate: Register message with an empty name")
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.messages[fullName]; dup {
+		panic(fmt.Sprintf("pbvalidate: Register called twice for message %q", fullName))
+	}
+	registry.messages[fullName] = fields
+}
+
+func lookup(fullName protoreflect.FullName) []*validation.FieldSpec {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.messages[fullName]
+}
+
+// ValidateProto validates a message with the rules registered for its full name, and the messages
+// it contains, including those in repeated and map fields, with theirs.
+// The field values are passed to the rules as follows:
+//   - a field with presence, such as a message or an optional field, that is not set: nil
+//   - an enum: the generated Go enum value, e.g. pb.Status_ACTIVE, if the enum type is registered
+//   - a message: the generated Go message, e.g. *pb.Address
+//   - a repeated field: a []interface{} of the element values
+//   - a map field: a map[interface{}]interface{} of the entry values
+//   - other fields: the Go values, e.g. string, int32 and []byte
+//
+// The errors are keyed by the JSON names of the fields, e.g. "displayName", and the errors of a repeated
+// or map field are keyed by the indexes or the map keys. Messages without registered rules are considered valid.
+func ValidateProto(ctx context.Context, msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	m := msg.ProtoReflect()
+	if !m.IsValid() {
+		return nil
+	}
+
+	errs := validation.Errors{}
+	fields := m.Descriptor().Fields()
+	for _, spec := range lookup(m.Descriptor().FullName()) {
+		fd := fields.ByName(protoreflect.Name(spec.Name()))
+		if fd == nil {
+			fd = fields.ByJSONName(spec.Name())
+		}
+		if fd == nil {
+			return validation.NewInternalError(fmt.Errorf("field %q not found in message %s", spec.Name(), m.Descriptor().FullName()))
+		}
+		if err := validation.ValidateWithContext(ctx, fieldValue(m, fd), spec.Rules()...); err != nil {
+			var ie validation.InternalError
+			if errors.As(err, &ie) {
+				return err
+			}
+			errs[fd.JSONName()] = err
+		}
+	}
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if _, failed := errs[fd.JSONName()]; failed || fd.Message() == nil || !m.Has(fd) {
+			continue
+		}
+		if err := validateNested(ctx, m.Get(fd), fd); err != nil {
+			var ie validation.InternalError
+			if errors.As(err, &ie) {
+				return err
+			}
+			errs[fd.JSONName()] = err
+		}
+	}
+
+	return errs.Filter()
+}
+
+// validateNested validates the messages held by a message, repeated or map field.
+func validateNested(ctx context.Context, v protoreflect.Value, fd protoreflect.FieldDescriptor) error {
+	switch {
+	case fd.IsList():
+		errs := validation.Errors{}
+		list := v.List()
+		for i := 0; i < list.Len(); i++ {
+			if err := ValidateProto(ctx, list.Get(i).Message().Interface()); err != nil {
+				errs[strconv.Itoa(i)] = err
+			}
+		}
+		return errs.Filter()
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return nil
+		}
+		errs := validation.Errors{}
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			if err := ValidateProto(ctx, v.Message().Interface()); err != nil {
+				errs[k.String()] = err
+			}
+			return true
+		})
+		return errs.Filter()
+	default:
+		return ValidateProto(ctx, v.Message().Interface())
+	}
+}
+
+// fieldValue returns the Go value of a field to be passed to the rules.
+func fieldValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) interface{} {
+	if fd.HasPresence() && !m.Has(fd) {
+		return nil
+	}
+
+	v := m.Get(fd)
+	switch {
+	case fd.IsList():
+		list := v.List()
+		values := make([]interface{}, list.Len())
+		for i := range values {
+			values[i] = scalarValue(list.Get(i), fd)
+		}
+		return values
+	case fd.IsMap():
+		values := make(map[interface{}]interface{}, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			values[k.Interface()] = scalarValue(v, fd.MapValue())
+			return true
+		})
+		return values
+	}
+	return scalarValue(v, fd)
+}
+
+// scalarValue returns the Go value of a single value of a field, i.e. not a list or a map.
+func scalarValue(v protoreflect.Value, fd protoreflect.FieldDescriptor) interface{} {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if et, err := protoregistry.GlobalTypes.FindEnumByName(fd.Enum().FullName()); err == nil {
+			return et.New(v.Enum())
+		}
+		return v.Enum()
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return v.Message().Interface()
+	}
+	return v.Interface()
+}
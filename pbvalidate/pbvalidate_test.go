@@ -0,0 +1,90 @@
+package pbvalidate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+func init() {
+	Register("google.protobuf.Api",
+		validation.Spec("name", validation.Required),
+		validation.Spec("source_context", validation.NotNil),
+		validation.Spec("syntax", validation.EnumOf(typepb.Syntax_SYNTAX_PROTO3)),
+		validation.Spec("methods", validation.MaxItems(2)),
+	)
+	Register("google.protobuf.Method",
+		validation.Spec("requestTypeUrl", validation.Required),
+	)
+	Register("google.protobuf.SourceContext",
+		validation.Spec("file_name", validation.Length(0, 20)),
+	)
+	Register("google.protobuf.Value",
+		validation.Spec("string_value", validation.Required),
+	)
+	Register("google.protobuf.Type",
+		validation.Spec("unknown", validation.Required),
+	)
+}
+
+func TestValidateProto(t *testing.T) {
+	valid := func() *apipb.Api {
+		return &apipb.Api{
+			Name:          "users",
+			SourceContext: &sourcecontextpb.SourceContext{FileName: "users.proto"},
+			Methods:       []*apipb.Method{{Name: "Get", RequestTypeUrl: "type.googleapis.com/Get"}},
+		}
+	}
+
+	tests := []struct {
+		tag  string
+		msg  func() *apipb.Api
+		want string
+	}{
+		{"t1", valid, ""},
+		{"t2", func() *apipb.Api { return nil }, ""},
+		{"t3", func() *apipb.Api { m := valid(); m.Name = ""; return m }, "name: cannot be blank."},
+		{"t4", func() *apipb.Api { m := valid(); m.SourceContext = nil; return m }, "sourceContext: is required."},
+		{"t5", func() *apipb.Api { m := valid(); m.SourceContext.FileName = "acme/users/v1/users.proto"; return m }, "sourceContext: (fileName: the length must be no more than 20.)."},
+		{"t6", func() *apipb.Api { m := valid(); m.Syntax = typepb.Syntax_SYNTAX_EDITIONS; return m }, "syntax: must be a valid value."},
+		{"t7", func() *apipb.Api { m := valid(); m.Methods[0].RequestTypeUrl = ""; return m }, "methods: (0: (requestTypeUrl: cannot be blank.).)."},
+		{"t8", func() *apipb.Api { m := valid(); m.Methods = append(m.Methods, m.Methods[0], m.Methods[0]); return m }, "methods: must have at most 2 items."},
+	}
+	for _, test := range tests {
+		err := ValidateProto(context.Background(), test.msg())
+		if test.want == "" {
+			assert.NoError(t, err, test.tag)
+		} else if assert.Error(t, err, test.tag) {
+			assert.Equal(t, test.want, err.Error(), test.tag)
+		}
+	}
+}
+
+func TestValidateProto_Map(t *testing.T) {
+	s, err := structpb.NewStruct(map[string]interface{}{"a": "x", "b": 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+	err = ValidateProto(context.Background(), s)
+	if assert.Error(t, err) {
+		assert.Equal(t, "fields: (b: (stringValue: cannot be blank.).).", err.Error())
+	}
+}
+
+func TestValidateProto_FieldNotFound(t *testing.T) {
+	err := ValidateProto(context.Background(), &typepb.Type{})
+	var ie validation.InternalError
+	assert.True(t, errors.As(err, &ie))
+}
+
+func TestRegister_Panics(t *testing.T) {
+	assert.Panics(t, func() { Register("") })
+	assert.Panics(t, func() { Register("google.protobuf.Api") })
+}
@@ -0,0 +1,105 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+var _ structLevelRules = (*PresenceRule)(nil)
+
+var (
+	// ErrExactlyOneOf is the error that returns when none or more than one of the fields of ExactlyOneOf are set.
+	ErrExactlyOneOf = NewError("validation_exactly_one_of", "exactly one of {{.fields}} must be set")
+	// ErrAtLeastOneOf is the error that returns when none of the fields of AtLeastOneOf is set.
+	ErrAtLeastOneOf = NewError("validation_at_least_one_of", "at least one of {{.fields}} must be set")
+	// ErrMutuallyExclusive is the error that returns when more than one of the fields of MutuallyExclusive are set.
+	ErrMutuallyExclusive = NewError("validation_mutually_exclusive", "only one of {{.fields}} can be set")
+)
+
+// PresenceRule is a struct-level rule that checks how many of the specified fields are set.
+type PresenceRule struct {
+	fieldPtrs []interface{}
+	min, max  int
+	err       Error
+}
+
+// ExactlyOneOf returns a struct-level rule that checks if exactly one of the given fields is set, which can be
+// passed to ValidateStruct together with the field rules. The fields must be specified as pointers to them.
+// For example,
+//
+//	err := validation.ValidateStruct(&r,
+//	    validation.Field(&r.Email, is.Email),
+//	    validation.ExactlyOneOf(&r.Email, &r.Phone),
+//	)
+//
+// A field is set unless it is absent as determined by the Absent rule, i.e. a nil pointer or a non-pointer zero value.
+// Unlike StructRule, the rule runs even if the field rules fail, and its error is reported under the key
+// joining the names of the fields with commas, e.g. "email,phone". The error params hold the names
+// in "fields", joined by ", ".
+func ExactlyOneOf(fieldPtrs ...interface{}) *PresenceRule {
+	return &PresenceRule{fieldPtrs: fieldPtrs, min: 1, max: 1, err: ErrExactlyOneOf}
+}
+
+// AtLeastOneOf returns a struct-level rule that checks if at least one of the given fields is set.
+// It is used in the same way as ExactlyOneOf.
+func AtLeastOneOf(fieldPtrs ...interface{}) *PresenceRule {
+	return &PresenceRule{fieldPtrs: fieldPtrs, min: 1, max: len(fieldPtrs), err: ErrAtLeastOneOf}
+}
+
+// MutuallyExclusive returns a struct-level rule that checks if at most one of the given fields is set.
+// It is used in the same way as ExactlyOneOf.
+func MutuallyExclusive(fieldPtrs ...interface{}) *PresenceRule {
+	return &PresenceRule{fieldPtrs: fieldPtrs, max: 1, err: ErrMutuallyExclusive}
+}
+
+// Error sets the error message for the rule.
+func (r *PresenceRule) Error(message string) *PresenceRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r *PresenceRule) ErrorObject(err Error) *PresenceRule {
+	r.err = err
+	return r
+}
+
+// Rules returns nil, as the rule validates the struct instead of a single field.
+func (r *PresenceRule) Rules() []Rule {
+	return nil
+}
+
+// FindStructField always returns ErrSkipFieldNotFound, as the rule validates the struct instead of a single field.
+func (r *PresenceRule) FindStructField(reflect.Value, int) (*reflect.StructField, any, error) {
+	return nil, nil, ErrSkipFieldNotFound
+}
+
+// validateStruct returns the error of the rule as Errors under the combined key of the fields.
+func (r *PresenceRule) validateStruct(ctx context.Context, structPtr interface{}) error {
+	sv := reflect.ValueOf(structPtr).Elem()
+	opts := getOpts(ctx)
+	names := make([]string, len(r.fieldPtrs))
+	set := 0
+	for i, ptr := range r.fieldPtrs {
+		fv := reflect.ValueOf(ptr)
+		if fv.Kind() != reflect.Ptr {
+			return NewInternalError(ErrFieldPointer(i))
+		}
+		ft := findStructField(sv, fv)
+		if ft == nil {
+			return NewInternalError(ErrFieldNotFound(i))
+		}
+		names[i] = opts.errorFieldName(ft)
+		if Absent.Validate(ctx, fv.Elem().Interface()) != nil {
+			set++
+		}
+	}
+
+	if set >= r.min && set <= r.max {
+		return nil
+	}
+	return Errors{
+		strings.Join(names, ","): r.err.SetParams(map[string]interface{}{"fields": strings.Join(names, ", ")}),
+	}
+}
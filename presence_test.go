@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresenceRule(t *testing.T) {
+	type contact struct {
+		Email string  `json:"email"`
+		Phone *string `json:"phone"`
+		Fax   string  `json:"fax"`
+	}
+	empty := ""
+
+	tests := []struct {
+		tag   string
+		value contact
+		rule  func(c *contact) *PresenceRule
+		err   string
+	}{
+		{"t1", contact{Email: "a"}, func(c *contact) *PresenceRule { return ExactlyOneOf(&c.Email, &c.Phone) }, ""},
+		{"t2", contact{Phone: &empty}, func(c *contact) *PresenceRule { return ExactlyOneOf(&c.Email, &c.Phone) }, ""},
+		{"t3", contact{}, func(c *contact) *PresenceRule { return ExactlyOneOf(&c.Email, &c.Phone) }, "email,phone: exactly one of email, phone must be set."},
+		{"t4", contact{Email: "a", Phone: &empty}, func(c *contact) *PresenceRule { return ExactlyOneOf(&c.Email, &c.Phone) }, "email,phone: exactly one of email, phone must be set."},
+		{"t5", contact{}, func(c *contact) *PresenceRule { return AtLeastOneOf(&c.Email, &c.Phone, &c.Fax) }, "email,phone,fax: at least one of email, phone, fax must be set."},
+		{"t6", contact{Email: "a", Fax: "b"}, func(c *contact) *PresenceRule { return AtLeastOneOf(&c.Email, &c.Phone, &c.Fax) }, ""},
+		{"t7", contact{}, func(c *contact) *PresenceRule { return MutuallyExclusive(&c.Email, &c.Fax) }, ""},
+		{"t8", contact{Email: "a", Fax: "b"}, func(c *contact) *PresenceRule { return MutuallyExclusive(&c.Email, &c.Fax) }, "email,fax: only one of email, fax can be set."},
+		{"t9", contact{}, func(c *contact) *PresenceRule { return AtLeastOneOf(&c.Email, &c.Fax).Error("a contact is required") }, "email,fax: a contact is required."},
+	}
+	for _, test := range tests {
+		c := test.value
+		err := ValidateStruct(&c, test.rule(&c))
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// the rule runs together with the field rules, before the struct rules
+	c := contact{Email: "a", Fax: "b"}
+	err := ValidateStructWithContext(context.Background(), &c,
+		Field(&c.Email, Length(2, 10)),
+		MutuallyExclusive(&c.Email, &c.Fax),
+		StructRule(func(context.Context, *contact) error { panic("not reached") }),
+	)
+	assertError(t, "email: the length must be between 2 and 10; email,fax: only one of email, fax can be set.", err, "t10")
+
+	other := contact{}
+	err = ValidateStruct(&c, ExactlyOneOf(&c.Email, &other.Email))
+	assert.EqualError(t, err, ErrFieldNotFound(1).Error())
+	err = ValidateStruct(&c, ExactlyOneOf(&c.Email, c.Fax))
+	assert.EqualError(t, err, ErrFieldPointer(1).Error())
+}
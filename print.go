@@ -0,0 +1,96 @@
+package validation
+
+import (
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ANSI escape sequences used by FprintErrors when colors are enabled.
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiFaint = "\x1b[2m"
+)
+
+// PrintOptions configures how FprintErrors renders errors.
+type PrintOptions struct {
+	// Indent is the indentation of each nesting level, two spaces by default.
+	Indent string
+	// Color enables ANSI colors: keys are bold, messages red and source positions faint.
+	Color bool
+	// SourceMap maps error paths to the positions of their values. The positions are printed after
+	// the errors as hints. Errors located by WithSourceMap are printed with their positions as well.
+	SourceMap SourceMap
+}
+
+// FprintErrors writes err to w as an indented tree for command-line tools, e.g.
+//
+//	server:
+//	  port: must be no greater than 65535 (config.yaml:3)
+//	name: cannot be blank (config.yaml:1)
+//
+// Nested Errors are written below their keys, one error per line. Any other error is written
+// on a single line. Nothing is written if err is nil.
+func FprintErrors(w io.Writer, err error, opts PrintOptions) error {
+	if err == nil {
+		return nil
+	}
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+
+	var s strings.Builder
+	var es Errors
+	if errors.As(err, &es) {
+		printErrors(&s, es, nil, opts)
+	} else {
+		s.WriteString(colorize(err.Error(), ansiRed, opts) + "\n")
+	}
+	_, err = io.WriteString(w, s.String())
+	return err
+}
+
+func printErrors(s *strings.Builder, es Errors, prefix ErrorPath, opts PrintOptions) {
+	keys := make([]string, 0, len(es))
+	for key, err := range es {
+		if err != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat(opts.Indent, len(prefix))
+	for _, key := range keys {
+		path := make(ErrorPath, len(prefix)+1)
+		copy(path, prefix)
+		path[len(prefix)] = key
+
+		s.WriteString(indent + colorize(key, ansiBold, opts) + ":")
+		if nested, ok := es[key].(Errors); ok {
+			s.WriteString(sourceHint(path, nil, opts) + "\n")
+			printErrors(s, nested, path, opts)
+			continue
+		}
+		s.WriteString(" " + colorize(es[key].Error(), ansiRed, opts) + sourceHint(path, es[key], opts) + "\n")
+	}
+}
+
+func sourceHint(path ErrorPath, err error, opts PrintOptions) string {
+	pos, ok := opts.SourceMap[path.String()]
+	if !ok {
+		if pos, ok = ErrorPosition(err); !ok {
+			return ""
+		}
+	}
+	return " " + colorize("("+pos.String()+")", ansiFaint, opts)
+}
+
+func colorize(text, color string, opts PrintOptions) string {
+	if !opts.Color {
+		return text
+	}
+	return color + text + ansiReset
+}
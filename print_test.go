@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestFprintErrors(t *testing.T) {
+	errs := Errors{
+		"name": ErrRequired,
+		"server": Errors{
+			"port": errors.New("must be no greater than 65535"),
+			"host": nil,
+		},
+	}
+	sources := map[string]SourcePosition{
+		"name":        {File: "config.yaml", Line: 1},
+		"server":      {File: "config.yaml", Line: 2},
+		"server.port": {File: "config.yaml", Line: 3},
+	}
+
+	tests := []struct {
+		tag  string
+		err  error
+		opts PrintOptions
+		out  string
+	}{
+		{"t1", nil, PrintOptions{}, ""},
+		{"t2", errs, PrintOptions{}, "name: cannot be blank\nserver:\n  port: must be no greater than 65535\n"},
+		{"t3", errs, PrintOptions{Indent: "\t", SourceMap: sources},
+			"name: cannot be blank (config.yaml:1)\nserver: (config.yaml:2)\n\tport: must be no greater than 65535 (config.yaml:3)\n"},
+		{"t4", Errors{"name": ErrRequired}, PrintOptions{Color: true, SourceMap: sources},
+			"\x1b[1mname\x1b[0m: \x1b[31mcannot be blank\x1b[0m \x1b[2m(config.yaml:1)\x1b[0m\n"},
+		{"t5", errors.New("cannot read config"), PrintOptions{}, "cannot read config\n"},
+		{"t6", fmt.Errorf("config: %w", Errors{"name": ErrRequired}), PrintOptions{}, "name: cannot be blank\n"},
+		{"t7", Errors{"name": ErrRequired}, PrintOptions{SourceMap: map[string]SourcePosition{"name": {Line: 4}}},
+			"name: cannot be blank (line 4)\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		assert.NoError(t, FprintErrors(&buf, test.err, test.opts), test.tag)
+		assert.Equal(t, test.out, buf.String(), test.tag)
+	}
+
+	assert.EqualError(t, FprintErrors(failingWriter{}, errs, PrintOptions{}), "write failed")
+}
@@ -0,0 +1,249 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// RuleFactory creates a rule from the params, e.g. {"min": 1, "max": 10} for "length".
+// Numeric params can be of any integer or float type, or json.Number.
+type RuleFactory func(params map[string]interface{}) (Rule, error)
+
+var registry = struct {
+	sync.RWMutex
+	rules     map[string]Rule
+	factories map[string]RuleFactory
+}{
+	rules: map[string]Rule{
+		"required":         Required,
+		"nil_or_not_empty": NilOrNotEmpty,
+		"not_nil":          NotNil,
+		"nil":              Nil,
+		"empty":            Empty,
+		"absent":           Absent,
+		"unique":           Unique(),
+		"trim":             Trim,
+		"lowercase":        Lowercase,
+		"uppercase":        Uppercase,
+	},
+	factories: map[string]RuleFactory{
+		"length": newLengthRule,
+		"items":  newItemsRule,
+		"min":    newThresholdRule(Min),
+		"max":    newThresholdRule(Max),
+		"match":  newMatchRule,
+		"in":     newInRule,
+		"not_in": newNotInRule,
+	},
+}
+
+// Register makes a rule available by name, so that it can be referenced by a string, e.g. in a configuration
+// file, and resolved with Lookup. For example,
+//
+//	validation.Register("slug", validation.Match(regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)))
+//
+// The rules without parameters, such as Required and NotNil, are registered by the names of their kinds,
+// e.g. "required", "not_nil" and "unique", and the normalizers Trim, Lowercase and Uppercase by "trim", "lowercase"
+// and "uppercase". Register is typically called from an init function.
+// It panics if the name is empty, the rule is nil, or a rule is already registered with the name.
+func Register(name string, rule Rule) {
+	if name == "" {
+		panic("validation: Register rule with an empty name")
+	}
+	if rule == nil {
+		panic("validation: Register rule is nil")
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.rules[name]; dup {
+		panic(fmt.Sprintf("validation: Register called twice for rule %q", name))
+	}
+	registry.rules[name] = rule
+}
+
+// Lookup returns the rule registered with the name. The boolean result is false if no rule is registered with the name.
+func Lookup(name string) (Rule, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	rule, ok := registry.rules[name]
+	return rule, ok
+}
+
+// RegisteredRules returns the sorted names of the registered rules.
+func RegisteredRules() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	names := make([]string, 0, len(registry.rules))
+	for name := range registry.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterFactory makes a parameterized rule available by name, so that it can be created from the params
+// resolved at runtime, e.g. from a configuration file, with LookupFactory. The following factories are registered
+// with the same kinds and params as described by the Metadata of the rules:
+//   - "length": Length, or RuneLength if "rune" is true, or GraphemeLength if "grapheme" is true, with the params "min" and "max"
+//   - "items": ItemsBetween with the params "min" and "max", where a missing "max" means no upper bound
+//   - "min" and "max": Min and Max with the param "threshold", and Exclusive if "exclusive" is true.
+//     The threshold is a number that is compared with a value of any integer or float type.
+//   - "match": Match with the param "pattern"
+//   - "in" and "not_in": In and NotIn with the param "values"
+//
+// It panics if the name is empty, the factory is nil, or a factory is already registered with the name.
+func RegisterFactory(name string, factory RuleFactory) {
+	if name == "" {
+		panic("validation: RegisterFactory with an empty name")
+	}
+	if factory == nil {
+		panic("validation: RegisterFactory factory is nil")
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.factories[name]; dup {
+		panic(fmt.Sprintf("validation: RegisterFactory called twice for factory %q", name))
+	}
+	registry.factories[name] = factory
+}
+
+// LookupFactory returns the rule factory registered with the name.
+// The boolean result is false if no factory is registered with the name.
+func LookupFactory(name string) (RuleFactory, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	factory, ok := registry.factories[name]
+	return factory, ok
+}
+
+func newLengthRule(params map[string]interface{}) (Rule, error) {
+	min, err := intParam(params, "min")
+	if err != nil {
+		return nil, err
+	}
+	max, err := intParam(params, "max")
+	if err != nil {
+		return nil, err
+	}
+	if isGrapheme, _ := params["grapheme"].(bool); isGrapheme {
+		return GraphemeLength(min, max), nil
+	}
+	if isRune, _ := params["rune"].(bool); isRune {
+		return RuneLength(min, max), nil
+	}
+	return Length(min, max), nil
+}
+
+func newItemsRule(params map[string]interface{}) (Rule, error) {
+	min, err := intParam(params, "min")
+	if err != nil {
+		return nil, err
+	}
+	max := -1
+	if _, ok := params["max"]; ok {
+		if max, err = intParam(params, "max"); err != nil {
+			return nil, err
+		}
+	}
+	return ItemsBetween(min, max), nil
+}
+
+func newThresholdRule(build func(interface{}) ThresholdRule) RuleFactory {
+	return func(params map[string]interface{}) (Rule, error) {
+		threshold, ok := toNumber(params["threshold"])
+		if !ok {
+			return nil, errors.New(`the param "threshold" must be a number`)
+		}
+		rule := build(threshold).CmpFunc(compareNumbers)
+		if exclusive, _ := params["exclusive"].(bool); exclusive {
+			rule = rule.Exclusive()
+		}
+		return rule, nil
+	}
+}
+
+func newMatchRule(params map[string]interface{}) (Rule, error) {
+	pattern, ok := params["pattern"].(string)
+	if !ok {
+		return nil, errors.New(`the param "pattern" must be a string`)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return Match(re), nil
+}
+
+func newInRule(params map[string]interface{}) (Rule, error) {
+	values, ok := params["values"].([]interface{})
+	if !ok {
+		return nil, errors.New(`the param "values" must be a list`)
+	}
+	return In(values...), nil
+}
+
+func newNotInRule(params map[string]interface{}) (Rule, error) {
+	values, ok := params["values"].([]interface{})
+	if !ok {
+		return nil, errors.New(`the param "values" must be a list`)
+	}
+	return NotIn(values...), nil
+}
+
+// intParam returns the integer param of the given name. A missing param is zero.
+func intParam(params map[string]interface{}, name string) (int, error) {
+	v, ok := params[name]
+	if !ok {
+		return 0, nil
+	}
+	n, ok := toNumber(v)
+	if !ok || n != float64(int(n)) {
+		return 0, fmt.Errorf("the param %q must be an integer", name)
+	}
+	return int(n), nil
+}
+
+// toNumber converts a value of any integer or float type, or a json.Number, to a float64.
+func toNumber(value interface{}) (float64, bool) {
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// compareNumbers is the CmpFunc of the threshold rules created by the factories,
+// which compares numbers of any type.
+func compareNumbers(op CmpOperator, threshold, value interface{}) bool {
+	t, _ := toNumber(threshold)
+	v, ok := toNumber(value)
+	if !ok {
+		return false
+	}
+	switch op {
+	case GreaterThan:
+		return v > t
+	case GreaterEqualThan:
+		return v >= t
+	case LessThan:
+		return v < t
+	default:
+		return v <= t
+	}
+}
@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	Register("test_slug", Match(regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)))
+	defer func() {
+		registry.Lock()
+		delete(registry.rules, "test_slug")
+		registry.Unlock()
+	}()
+
+	tests := []struct {
+		tag   string
+		name  string
+		value interface{}
+		found bool
+		err   string
+	}{
+		{"t1", "test_slug", "hello-world", true, ""},
+		{"t2", "test_slug", "Hello World", true, "must be in a valid format"},
+		{"t3", "required", "", true, "cannot be blank"},
+		{"t4", "not_nil", nil, true, "is required"},
+		{"t5", "nil", "abc", true, "must be blank"},
+		{"t6", "unknown", "abc", false, ""},
+	}
+	for _, test := range tests {
+		rule, ok := Lookup(test.name)
+		assert.Equal(t, test.found, ok, test.tag)
+		if ok {
+			assertError(t, test.err, rule.Validate(context.Background(), test.value), test.tag)
+		}
+	}
+
+	assert.Contains(t, RegisteredRules(), "test_slug")
+	assert.Panics(t, func() { Register("test_slug", Required) })
+	assert.Panics(t, func() { Register("", Required) })
+	assert.Panics(t, func() { Register("test_nil", nil) })
+}
+
+func TestRegisterFactory(t *testing.T) {
+	tests := []struct {
+		tag    string
+		name   string
+		params map[string]interface{}
+		value  interface{}
+		err    string
+	}{
+		{"t1", "length", map[string]interface{}{"min": 2, "max": 3}, "abcd", "the length must be between 2 and 3"},
+		{"t2", "length", map[string]interface{}{"max": 3, "rune": true}, "äöü", ""},
+		{"t3", "min", map[string]interface{}{"threshold": 10}, 5, "must be no less than 10"},
+		{"t4", "min", map[string]interface{}{"threshold": 10, "exclusive": true}, 10.0, "must be greater than 10"},
+		{"t5", "max", map[string]interface{}{"threshold": uint8(10)}, uint(5), ""},
+		{"t6", "max", map[string]interface{}{"threshold": 10}, "abc", "must be no greater than 10"},
+		{"t7", "match", map[string]interface{}{"pattern": "^[a-z]+$"}, "ABC", "must be in a valid format"},
+		{"t8", "in", map[string]interface{}{"values": []interface{}{"a", "b"}}, "c", "must be a valid value"},
+		{"t9", "not_in", map[string]interface{}{"values": []interface{}{"a", "b"}}, "a", "must not be in list"},
+		{"t10", "items", map[string]interface{}{"min": 2}, []int{1}, "must have at least 2 items"},
+		{"t11", "items", map[string]interface{}{"min": 1, "max": 2}, []int{1, 2, 3}, "must have between 1 and 2 items"},
+	}
+	for _, test := range tests {
+		factory, ok := LookupFactory(test.name)
+		if !assert.True(t, ok, test.tag) {
+			continue
+		}
+		rule, err := factory(test.params)
+		if assert.NoError(t, err, test.tag) {
+			assertError(t, test.err, rule.Validate(context.Background(), test.value), test.tag)
+		}
+	}
+
+	_, ok := LookupFactory("unknown")
+	assert.False(t, ok)
+	assert.Panics(t, func() { RegisterFactory("length", newLengthRule) })
+	assert.Panics(t, func() { RegisterFactory("", newLengthRule) })
+	assert.Panics(t, func() { RegisterFactory("test_nil", nil) })
+}
@@ -32,14 +32,19 @@ var NilOrNotEmpty = RequiredRule{skipNil: true, condition: true}
 type RequiredRule struct {
 	condition bool
 	skipNil   bool
+	trim      bool
 	err       Error
 }
 
 // Validate checks if the given value is valid or not.
 func (r RequiredRule) Validate(ctx context.Context, value interface{}) error {
 	if r.condition {
-		value, isNil := indirectWithOptions(value, GetOptions(ctx))
-		if r.skipNil && !isNil && IsEmpty(value) || !r.skipNil && (isNil || IsEmpty(value)) {
+		opts := GetOptions(ctx)
+		value, isNil := indirectWithOptions(value, opts)
+		if r.trim {
+			value = trimSpace(value)
+		}
+		if r.skipNil && !isNil && isEmptyWithOptions(value, opts) || !r.skipNil && (isNil || isEmptyWithOptions(value, opts)) {
 			if r.err != nil {
 				return r.err
 			}
@@ -58,6 +63,20 @@ func (r RequiredRule) When(condition bool) RequiredRule {
 	return r
 }
 
+// Unless sets the condition that determines if the validation should be skipped.
+// It is the opposite of When.
+func (r RequiredRule) Unless(condition bool) RequiredRule {
+	r.condition = !condition
+	return r
+}
+
+// TrimSpace makes the rule check the value without its leading and trailing white space.
+// It is useful for free-form input, where a value of spaces only must not pass. See also WithTrimSpace.
+func (r RequiredRule) TrimSpace() RequiredRule {
+	r.trim = true
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r RequiredRule) Error(message string) RequiredRule {
 	if r.err == nil {
@@ -76,3 +95,16 @@ func (r RequiredRule) ErrorObject(err Error) RequiredRule {
 	r.err = err
 	return r
 }
+
+// Metadata returns the description of the rule.
+// The kind is "required" for Required and "nil_or_not_empty" for NilOrNotEmpty.
+// The kind is empty if the rule is disabled by When or Unless.
+func (r RequiredRule) Metadata() RuleInfo {
+	if !r.condition {
+		return RuleInfo{}
+	}
+	if r.skipNil {
+		return RuleInfo{Kind: "nil_or_not_empty"}
+	}
+	return RuleInfo{Kind: "required"}
+}
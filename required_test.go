@@ -56,6 +56,20 @@ func TestRequiredRule_When(t *testing.T) {
 	assert.Equal(t, ErrRequired, err)
 }
 
+func TestRequiredRule_Unless(t *testing.T) {
+	r := Required.Unless(true)
+	err := ValidateWithContext(nil, nil, r)
+	assert.Nil(t, err)
+
+	r = Required.Unless(false)
+	err = ValidateWithContext(nil, nil, r)
+	assert.Equal(t, ErrRequired, err)
+
+	r = NilOrNotEmpty.Unless(false)
+	err = ValidateWithContext(nil, "", r)
+	assert.Equal(t, ErrNilOrNotEmpty, err)
+}
+
 func TestNilOrNotEmpty(t *testing.T) {
 	s1 := "123"
 	s2 := ""
@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+)
+
+var _ Rule = (*RequiredWhenFieldRule)(nil)
+
+// RequiredWhenFieldRule is a validation rule that checks if a value is not empty when another struct field
+// has one of the specified values.
+type RequiredWhenFieldRule struct {
+	fieldPtr interface{}
+	values   []interface{}
+	err      Error
+}
+
+// RequiredWhenField returns a validation rule that checks if a value is not empty, like Required, when the field
+// referenced by fieldPtr is equal to one of the given values. Like EqualToField, the field is read when the rule
+// is validated, and reflect.DeepEqual() is used to compare the values. For example,
+//
+//	validation.ValidateStruct(&p,
+//	    validation.Field(&p.Type, validation.In("card", "bank")),
+//	    validation.Field(&p.CardNumber, validation.RequiredWhenField(&p.Type, "card")),
+//	    validation.Field(&p.IBAN, validation.RequiredWhenField(&p.Type, "bank")),
+//	)
+//
+// The error is ErrRequired unless it is set by Error or ErrorObject.
+func RequiredWhenField(fieldPtr interface{}, values ...interface{}) RequiredWhenFieldRule {
+	return RequiredWhenFieldRule{fieldPtr: fieldPtr, values: values, err: ErrRequired}
+}
+
+// Error sets the error message for the rule.
+func (r RequiredWhenFieldRule) Error(message string) RequiredWhenFieldRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r RequiredWhenFieldRule) ErrorObject(err Error) RequiredWhenFieldRule {
+	r.err = err
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r RequiredWhenFieldRule) Validate(ctx context.Context, value interface{}) error {
+	fv := reflect.ValueOf(r.fieldPtr)
+	if fv.Kind() != reflect.Ptr || fv.IsNil() {
+		return NewInternalError(ErrCompareFieldPointer)
+	}
+
+	opts := GetOptions(ctx)
+	other, _ := indirectWithOptions(fv.Elem().Interface(), opts)
+	for _, v := range r.values {
+		if reflect.DeepEqual(other, v) {
+			if value, isNil := indirectWithOptions(value, opts); isNil || isEmptyWithOptions(value, opts) {
+				return r.err
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// Metadata returns the description of the rule.
+// The kind is "required_when_field", with the param "field" holding the pointer to the compared field and
+// "values" holding the values that make the value required. Inspect resolves the pointer to the name of
+// the compared field.
+func (r RequiredWhenFieldRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "required_when_field",
+		Params: map[string]interface{}{"field": r.fieldPtr, "values": r.values},
+	}
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredWhenField(t *testing.T) {
+	type payment struct {
+		Type       string         `json:"type"`
+		Method     sql.NullString `json:"method"`
+		CardNumber string         `json:"card_number"`
+		IBAN       *string        `json:"iban"`
+	}
+	iban := "DE00"
+
+	tests := []struct {
+		tag   string
+		value payment
+		err   string
+	}{
+		{"t1", payment{Type: "card", CardNumber: "4111"}, ""},
+		{"t2", payment{Type: "card"}, "card_number: cannot be blank."},
+		{"t3", payment{Type: "bank"}, "iban: IBAN is required for bank transfers."},
+		{"t4", payment{Type: "bank", IBAN: &iban}, ""},
+		{"t5", payment{Type: "cash"}, ""},
+		{"t6", payment{Method: sql.NullString{String: "sepa", Valid: true}}, "iban: IBAN is required for bank transfers."},
+	}
+	for _, test := range tests {
+		p := test.value
+		err := ValidateStruct(&p,
+			Field(&p.CardNumber, RequiredWhenField(&p.Type, "card")),
+			Field(&p.IBAN, RequiredWhenField(&p.Type, "bank", "sepa").Error("IBAN is required for bank transfers")),
+			Field(&p.IBAN, RequiredWhenField(&p.Method, "sepa").Error("IBAN is required for bank transfers")),
+		)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := Validate("", RequiredWhenField("card", "card"))
+	assert.Equal(t, NewInternalError(ErrCompareFieldPointer), err)
+
+	p := payment{}
+	infos, err := Inspect(&p, Field(&p.CardNumber, RequiredWhenField(&p.Type, "card")))
+	assert.NoError(t, err)
+	assert.Equal(t, []FieldInfo{{Name: "card_number", Rules: []RuleInfo{
+		{Kind: "required_when_field", Params: map[string]interface{}{"field": "type", "values": []interface{}{"card"}}},
+	}}}, infos)
+}
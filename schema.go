@@ -0,0 +1,157 @@
+package validation
+
+import (
+	"context"
+	"reflect"
+	"sync"
+)
+
+var (
+	_ Rule       = (*Schema)(nil)
+	_ FieldRules = (*FieldSpec)(nil)
+)
+
+// FieldSpec specifies a struct field by its name and the corresponding validation rules.
+// Unlike Field, a FieldSpec does not refer to a particular struct value, so it can be used to build
+// a Schema that validates any value of a struct type.
+type FieldSpec struct {
+	name  string
+	rules []Rule
+}
+
+// Spec specifies a struct field by name and the corresponding validation rules.
+// If the name starts with a lowercase letter, the first letter is converted to uppercase
+// to look for the struct field, the same as NamedField does by default. Unlike NamedField, the lookup does not
+// depend on WithStrictFieldNames and cannot be changed by MatchBy, since the fields are resolved once per struct
+// type. Unexported fields are never found.
+func Spec(name string, rules ...Rule) *FieldSpec {
+	return &FieldSpec{
+		name:  name,
+		rules: rules,
+	}
+}
+
+// Name returns the name of the struct field.
+func (f *FieldSpec) Name() string {
+	return f.name
+}
+
+// Rules returns the validation rules of the struct field.
+func (f *FieldSpec) Rules() []Rule {
+	return f.rules
+}
+
+// FindStructField looks for the struct field by name.
+// An InternalError is returned if the field cannot be found.
+func (f *FieldSpec) FindStructField(structValue reflect.Value, idx int) (*reflect.StructField, any, error) {
+	sf, ok := lookupField(structValue.Type(), f.name, MatchDefault, "", false)
+	if !ok {
+		return nil, nil, NewInternalError(ErrFieldNotFound(idx))
+	}
+	return resolvedFieldSpec{spec: f, field: sf}.FindStructField(structValue, idx)
+}
+
+// resolvedFieldSpec is a FieldSpec whose struct field has been resolved for a struct type.
+type resolvedFieldSpec struct {
+	spec  *FieldSpec
+	field reflect.StructField
+}
+
+func (f resolvedFieldSpec) Rules() []Rule {
+	return f.spec.rules
+}
+
+func (f resolvedFieldSpec) FindStructField(structValue reflect.Value, _ int) (*reflect.StructField, any, error) {
+	fv, err := structValue.FieldByIndexErr(f.field.Index)
+	if err != nil {
+		// the field is promoted through a nil embedded struct pointer
+		return nil, nil, ErrSkipFieldNotFound
+	}
+	ft := f.field
+	return &ft, fv.Interface(), nil
+}
+
+// Schema is a reusable set of field specifications that can validate any value of a struct type.
+// The struct fields are resolved once per struct type and cached, which avoids building
+// []FieldRules with field pointers and looking up the fields for every validation.
+// A Schema is safe for concurrent use.
+type Schema struct {
+	fields []*FieldSpec
+	// types caches the resolved fields by struct type.
+	types sync.Map
+}
+
+// NewSchema creates a Schema from the given field specifications.
+// For example,
+//
+//	var userSchema = validation.NewSchema(
+//	    validation.Spec("Name", validation.Required, validation.Length(1, 50)),
+//	    validation.Spec("Email", validation.Required, is.Email),
+//	)
+//
+//	err := userSchema.Validate(ctx, &user)
+//
+// A Schema is also a Rule, so it can be used to validate nested struct fields:
+//
+//	validation.Spec("Address", addressSchema)
+func NewSchema(fields ...*FieldSpec) *Schema {
+	return &Schema{fields: fields}
+}
+
+// Fields returns the field specifications of the schema.
+func (s *Schema) Fields() []*FieldSpec {
+	return s.fields
+}
+
+// Metadata returns the description of the schema.
+// The kind is "struct", with the param "fields" holding the field specifications as []FieldRules.
+func (s *Schema) Metadata() RuleInfo {
+	fields := make([]FieldRules, len(s.fields))
+	for i, f := range s.fields {
+		fields[i] = f
+	}
+	return RuleInfo{
+		Kind:   "struct",
+		Params: map[string]interface{}{"fields": fields},
+	}
+}
+
+// Validate validates a struct or a pointer to a struct against the schema.
+// A nil pointer is considered valid. The validation errors are reported in the same way as ValidateStruct.
+func (s *Schema) Validate(ctx context.Context, value interface{}) error {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Struct {
+		// make an addressable copy so that the struct can be validated the same way as a pointer
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		rv = ptr
+	}
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ValidateStructWithContext(ctx, value)
+	}
+
+	fields, err := s.resolve(rv.Elem().Type())
+	if err != nil {
+		return err
+	}
+	return ValidateStructWithContext(ctx, rv.Interface(), fields...)
+}
+
+// resolve returns the fields of the schema resolved for the given struct type.
+func (s *Schema) resolve(t reflect.Type) ([]FieldRules, error) {
+	if cached, ok := s.types.Load(t); ok {
+		return cached.([]FieldRules), nil
+	}
+
+	fields := make([]FieldRules, len(s.fields))
+	for i, spec := range s.fields {
+		sf, ok := lookupField(t, spec.name, MatchDefault, "", false)
+		if !ok {
+			return nil, NewInternalError(ErrFieldNotFound(i))
+		}
+		fields[i] = resolvedFieldSpec{spec: spec, field: sf}
+	}
+
+	s.types.Store(t, fields)
+	return fields, nil
+}
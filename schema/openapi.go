@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"sort"
+
+	"github.com/rockcookies/go-validation"
+)
+
+// Parameter is an OpenAPI 3 parameter object.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is an OpenAPI 3 request body object.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType is an OpenAPI 3 media type object.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// OpenAPI generates the OpenAPI 3.0 schema object of a struct from the field rules used to validate it.
+// It differs from Generate in that the document has no "$schema" keyword and the exclusive bounds are
+// expressed in the OpenAPI 3.0 form, i.e. "minimum" with "exclusiveMinimum": true.
+// Please refer to Generate for the requirements of structPtr and the supported rules.
+func OpenAPI(structPtr interface{}, fields ...validation.FieldRules) (*Schema, error) {
+	s, err := generate(structPtr, fields)
+	if err != nil {
+		return nil, err
+	}
+	return s.toOpenAPI(), nil
+}
+
+// OpenAPIParameters generates the OpenAPI 3 parameter objects of a struct from the field rules used to validate it.
+// Each validated field becomes a parameter located in in, e.g. "query", "path" or "header".
+// The parameters are sorted by name.
+func OpenAPIParameters(in string, structPtr interface{}, fields ...validation.FieldRules) ([]Parameter, error) {
+	s, err := OpenAPI(structPtr, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	required := make(map[string]bool, len(s.Required))
+	for _, name := range s.Required {
+		required[name] = true
+	}
+	params := make([]Parameter, 0, len(s.Properties))
+	for name, prop := range s.Properties {
+		params = append(params, Parameter{
+			Name:     name,
+			In:       in,
+			Required: in == "path" || required[name],
+			Schema:   prop,
+		})
+	}
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+	return params, nil
+}
+
+// OpenAPIRequestBody generates the OpenAPI 3 request body object of a JSON-encoded struct
+// from the field rules used to validate it. The request body is marked as required.
+func OpenAPIRequestBody(structPtr interface{}, fields ...validation.FieldRules) (*RequestBody, error) {
+	s, err := OpenAPI(structPtr, fields...)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestBody{
+		Required: true,
+		Content:  map[string]MediaType{"application/json": {Schema: s}},
+	}, nil
+}
+
+// toOpenAPI returns a copy of the schema converted to the OpenAPI 3.0 dialect.
+func (s *Schema) toOpenAPI() *Schema {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	c.Schema = ""
+	if c.ExclusiveMinimum != nil {
+		c.Minimum, c.ExclusiveMinimum = c.ExclusiveMinimum, true
+	}
+	if c.ExclusiveMaximum != nil {
+		c.Maximum, c.ExclusiveMaximum = c.ExclusiveMaximum, true
+	}
+	if s.Properties != nil {
+		c.Properties = make(map[string]*Schema, len(s.Properties))
+		for name, prop := range s.Properties {
+			c.Properties[name] = prop.toOpenAPI()
+		}
+	}
+	c.Items = s.Items.toOpenAPI()
+	c.AdditionalProperties = s.AdditionalProperties.toOpenAPI()
+	return &c
+}
@@ -0,0 +1,92 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type query struct {
+	Page  int     `json:"page"`
+	Sort  string  `json:"sort"`
+	Price float64 `json:"price"`
+	IDs   []int   `json:"ids"`
+}
+
+func TestOpenAPI(t *testing.T) {
+	q := &query{}
+	s, err := OpenAPI(q,
+		validation.Field(&q.Price, validation.Min(0.0).Exclusive(), validation.Max(100.0).Exclusive()),
+		validation.Field(&q.IDs, validation.Each(validation.Min(1).Exclusive())),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"price": {"type": "number", "minimum": 0, "exclusiveMinimum": true, "maximum": 100, "exclusiveMaximum": true},
+			"ids": {"type": "array", "items": {"type": "integer", "minimum": 1, "exclusiveMinimum": true}}
+		}
+	}`, string(b))
+
+	_, err = OpenAPI("abc")
+	assert.EqualError(t, err, validation.ErrStructPointer.Error())
+}
+
+func TestOpenAPIParameters(t *testing.T) {
+	q := &query{}
+	fields := []validation.FieldRules{
+		validation.Field(&q.Sort, validation.In("asc", "desc")),
+		validation.Field(&q.Page, validation.Required, validation.Min(1)),
+	}
+
+	params, err := OpenAPIParameters("query", q, fields...)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Parameter{
+			{Name: "page", In: "query", Required: true, Schema: &Schema{Type: "integer", Minimum: 1}},
+			{Name: "sort", In: "query", Schema: &Schema{Type: "string", Enum: []interface{}{"asc", "desc"}}},
+		}, params)
+	}
+
+	params, err = OpenAPIParameters("path", q, fields[0])
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Parameter{
+			{Name: "sort", In: "path", Required: true, Schema: &Schema{Type: "string", Enum: []interface{}{"asc", "desc"}}},
+		}, params)
+	}
+
+	_, err = OpenAPIParameters("query", "abc")
+	assert.EqualError(t, err, validation.ErrStructPointer.Error())
+}
+
+func TestOpenAPIRequestBody(t *testing.T) {
+	q := &query{}
+	body, err := OpenAPIRequestBody(q, validation.Field(&q.Sort, validation.Required))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"required": true,
+		"content": {
+			"application/json": {
+				"schema": {
+					"type": "object",
+					"properties": {"sort": {"type": "string", "minLength": 1}},
+					"required": ["sort"]
+				}
+			}
+		}
+	}`, string(b))
+
+	_, err = OpenAPIRequestBody("abc")
+	assert.EqualError(t, err, validation.ErrStructPointer.Error())
+}
@@ -0,0 +1,334 @@
+// Package schema generates JSON Schema documents from validation rules.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/rockcookies/go-validation"
+)
+
+// Draft is the JSON Schema dialect of the generated documents.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document.
+// Only the keywords that can be derived from the built-in validation rules are supported.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	MinProperties        *int               `json:"minProperties,omitempty"`
+	MaxProperties        *int               `json:"maxProperties,omitempty"`
+	Minimum              interface{}        `json:"minimum,omitempty"`
+	ExclusiveMinimum     interface{}        `json:"exclusiveMinimum,omitempty"`
+	Maximum              interface{}        `json:"maximum,omitempty"`
+	ExclusiveMaximum     interface{}        `json:"exclusiveMaximum,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+}
+
+// Generate generates the JSON Schema of a struct from the field rules used to validate it.
+// The struct must be given as the same pointer that the field rules refer to, or as a struct
+// value if the field rules do not refer to field pointers (e.g. FieldSpec).
+// The property names are determined in the same way as the validation error keys.
+//
+// The rules are mapped as follows:
+//   - Required: required
+//   - Length, RuneLength: minLength/maxLength, minItems/maxItems or minProperties/maxProperties
+//   - In: enum
+//   - Min, Max: minimum/exclusiveMinimum, maximum/exclusiveMaximum
+//   - Match: pattern
+//   - Unique: uniqueItems
+//   - SubsetOf: items.enum
+//   - Each: items or additionalProperties
+//   - FieldStruct, NamedStructField and Schema: nested properties
+//
+// Other rules are ignored.
+func Generate(structPtr interface{}, fields ...validation.FieldRules) (*Schema, error) {
+	s, err := generate(structPtr, fields)
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = Draft
+	return s, nil
+}
+
+// FromSchema generates the JSON Schema of a struct from a validation Schema.
+// The value can be a struct, a pointer to a struct, or a nil pointer to a struct.
+func FromSchema(value interface{}, s *validation.Schema) (*Schema, error) {
+	specs := s.Fields()
+	fields := make([]validation.FieldRules, len(specs))
+	for i, spec := range specs {
+		fields[i] = spec
+	}
+	return Generate(value, fields...)
+}
+
+func generate(value interface{}, fields []validation.FieldRules) (*Schema, error) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv = reflect.New(rv.Type().Elem())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, validation.NewInternalError(validation.ErrStructPointer)
+	}
+
+	nameFunc := validation.DefaultOptions().GetErrorFieldNameFunc()
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i, fr := range fields {
+		ft, fv, err := fr.FindStructField(rv, i)
+		if err != nil {
+			if errors.Is(err, validation.ErrSkipFieldNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if ft.Tag.Get("json") == "-" {
+			// the field is not part of the JSON document
+			continue
+		}
+
+		name := nameFunc(ft)
+		prop := TypeOf(ft.Type)
+		required, err := applyRules(prop, fv, fr.Rules())
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", name, err)
+		}
+		s.Properties[name] = prop
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s, nil
+}
+
+// TypeOf returns the JSON Schema describing the JSON encoding of the given Go type.
+// Struct types are described as objects without properties.
+func TypeOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte is encoded as a base64 string
+			return &Schema{Type: "string"}
+		}
+		return &Schema{Type: "array", Items: TypeOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: TypeOf(t.Elem())}
+	case reflect.Struct:
+		return &Schema{Type: "object"}
+	}
+	return &Schema{}
+}
+
+// applyRules applies the constraints of the rules to s and reports whether the value is required.
+func applyRules(s *Schema, value interface{}, rules []validation.Rule) (bool, error) {
+	required, hasDefault := false, false
+	for _, rule := range rules {
+		info, ok := validation.Describe(rule)
+		if !ok {
+			continue
+		}
+		switch info.Kind {
+		case "required":
+			required = true
+			applyLength(s, 1, 0)
+		case "nil_or_not_empty":
+			applyLength(s, 1, 0)
+		case "length":
+			min, max := info.Params["min"].(int), info.Params["max"].(int)
+			applyLength(s, min, max)
+			if min == 0 && max == 0 {
+				// the value must be empty
+				if _, maxKw := s.lengthKeywords(); maxKw != nil {
+					setMax(maxKw, 0)
+				}
+			}
+		case "default":
+			// a value with a default can be left out
+			s.Default, hasDefault = info.Params["value"], true
+		case "in", "enum":
+			// the values compared ignoring case or normalized, or checked by a function, cannot be described
+			values, ok := info.Params["values"].([]interface{})
+			if ok && info.Params["fold_case"] == nil && info.Params["normalize"] == nil {
+				s.Enum = values
+			}
+		case "min", "max":
+			applyThreshold(s, info)
+		case "between":
+			applyThreshold(s, validation.RuleInfo{Kind: "min", Params: map[string]interface{}{"threshold": info.Params["min"]}})
+			applyThreshold(s, validation.RuleInfo{Kind: "max", Params: map[string]interface{}{"threshold": info.Params["max"]}})
+		case "match":
+			s.Pattern = info.Params["pattern"].(string)
+		case "subset_of":
+			if s.Type == "array" && s.Items != nil {
+				s.Items.Enum = info.Params["values"].([]interface{})
+			}
+		case "items":
+			// the size of a collection, regardless of the string length
+			if s.Type == "array" || s.Type == "object" && s.AdditionalProperties != nil {
+				min, _ := info.Params["min"].(int)
+				applyLength(s, min, 0)
+				if max, ok := info.Params["max"].(int); ok {
+					_, maxKw := s.lengthKeywords()
+					setMax(maxKw, max)
+				}
+			}
+		case "unique":
+			// the keys compared by UniqueBy cannot be described
+			if s.Type == "array" && info.Params["by"] == nil {
+				s.UniqueItems = true
+			}
+		case "each":
+			if err := applyEach(s, value, info.Params["rules"].([]validation.Rule)); err != nil {
+				return false, err
+			}
+		case "struct":
+			if err := applyStruct(s, value, info.Params["fields"].([]validation.FieldRules)); err != nil {
+				return false, err
+			}
+		case "dive":
+			if elem, ev := elemSchema(s, value); elem != nil {
+				if err := applyStruct(elem, ev, info.Params["fields"].([]validation.FieldRules)); err != nil {
+					return false, err
+				}
+			}
+		}
+	}
+	return required && !hasDefault, nil
+}
+
+// lengthKeywords returns the minimum and maximum length keywords that apply to the schema type.
+// Nil is returned if the length of the type cannot be constrained, e.g. for numbers and structs.
+func (s *Schema) lengthKeywords() (min, max **int) {
+	switch {
+	case s.Type == "string":
+		return &s.MinLength, &s.MaxLength
+	case s.Type == "array":
+		return &s.MinItems, &s.MaxItems
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		return &s.MinProperties, &s.MaxProperties
+	}
+	return nil, nil
+}
+
+// applyLength tightens the length bounds of the schema. A zero bound means no bound.
+func applyLength(s *Schema, min, max int) {
+	minKw, maxKw := s.lengthKeywords()
+	if minKw == nil {
+		return
+	}
+	if min > 0 && (*minKw == nil || **minKw < min) {
+		*minKw = &min
+	}
+	if max > 0 {
+		setMax(maxKw, max)
+	}
+}
+
+func setMax(kw **int, max int) {
+	if *kw == nil || **kw > max {
+		*kw = &max
+	}
+}
+
+// applyThreshold sets the numeric bounds of the schema. Non-numeric thresholds are ignored.
+func applyThreshold(s *Schema, info validation.RuleInfo) {
+	if s.Type != "integer" && s.Type != "number" {
+		return
+	}
+	threshold := info.Params["threshold"]
+	switch reflect.ValueOf(threshold).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+	default:
+		return
+	}
+
+	exclusive, _ := info.Params["exclusive"].(bool)
+	switch {
+	case info.Kind == "min" && exclusive:
+		s.ExclusiveMinimum = threshold
+	case info.Kind == "min":
+		s.Minimum = threshold
+	case exclusive:
+		s.ExclusiveMaximum = threshold
+	default:
+		s.Maximum = threshold
+	}
+}
+
+// applyEach applies the element rules to the items of an array or the values of a map.
+func applyEach(s *Schema, value interface{}, rules []validation.Rule) error {
+	elem, ev := elemSchema(s, value)
+	if elem == nil {
+		return nil
+	}
+	_, err := applyRules(elem, ev, rules)
+	return err
+}
+
+// applyStruct applies the nested struct field rules to s.
+func applyStruct(s *Schema, value interface{}, fields []validation.FieldRules) error {
+	nested, err := generate(value, fields)
+	if err != nil {
+		return err
+	}
+	s.Properties, s.Required = nested.Properties, nested.Required
+	return nil
+}
+
+// elemSchema returns the schema of the items of an array or the values of a map, together with
+// a pointer to a zero element, which is needed to resolve the fields of nested struct rules.
+func elemSchema(s *Schema, value interface{}) (*Schema, interface{}) {
+	elem := s.Items
+	if s.Type == "object" {
+		elem = s.AdditionalProperties
+	}
+	if elem == nil {
+		return nil, nil
+	}
+
+	var ev interface{}
+	if t := reflect.TypeOf(value); t != nil {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			ev = reflect.New(t.Elem()).Interface()
+		}
+	}
+	return elem, ev
+}
@@ -0,0 +1,376 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip"`
+}
+
+type user struct {
+	Name     string            `json:"name"`
+	Email    *string           `json:"email"`
+	Role     string            `json:"role"`
+	Age      int               `json:"age"`
+	Score    float64           `json:"score"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels"`
+	Birthday time.Time         `json:"birthday"`
+	Address  address           `json:"address"`
+	Note     string            `json:"-"`
+}
+
+func TestGenerate(t *testing.T) {
+	u := &user{}
+	s, err := Generate(u,
+		validation.Field(&u.Name, validation.Required, validation.RuneLength(2, 50)),
+		validation.Field(&u.Email, validation.NilOrNotEmpty, validation.Match(regexp.MustCompile(`^\S+@\S+$`))),
+		validation.Field(&u.Role, validation.In("admin", "user")),
+		validation.Field(&u.Age, validation.Min(18), validation.Max(150).Exclusive()),
+		validation.Field(&u.Score, validation.Min(0.0).Exclusive(), validation.Max(1.0)),
+		validation.Field(&u.Tags, validation.Length(0, 10), validation.Each(validation.Length(1, 20))),
+		validation.Field(&u.Labels, validation.Required, validation.Each(validation.In("a", "b"))),
+		validation.Field(&u.Birthday, validation.Min(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC))),
+		validation.FieldStruct(&u.Address,
+			validation.Field(&u.Address.Street, validation.Required),
+			validation.Field(&u.Address.Zip, validation.Length(5, 5)),
+		),
+		validation.Field(&u.Note, validation.By(func(context.Context, interface{}) error { return nil })),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 2, "maxLength": 50},
+			"email": {"type": "string", "minLength": 1, "pattern": "^\\S+@\\S+$"},
+			"role": {"type": "string", "enum": ["admin", "user"]},
+			"age": {"type": "integer", "minimum": 18, "exclusiveMaximum": 150},
+			"score": {"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+			"tags": {"type": "array", "maxItems": 10, "items": {"type": "string", "minLength": 1, "maxLength": 20}},
+			"labels": {"type": "object", "minProperties": 1, "additionalProperties": {"type": "string", "enum": ["a", "b"]}},
+			"birthday": {"type": "string", "format": "date-time"},
+			"address": {
+				"type": "object",
+				"properties": {
+					"street": {"type": "string", "minLength": 1},
+					"zip": {"type": "string", "minLength": 5, "maxLength": 5}
+				},
+				"required": ["street"]
+			}
+		},
+		"required": ["name", "labels"]
+	}`, string(b))
+}
+
+func TestFromSchema(t *testing.T) {
+	addressSchema := validation.NewSchema(
+		validation.Spec("Street", validation.Required),
+		validation.Spec("zip", validation.Length(5, 5)),
+	)
+	userSchema := validation.NewSchema(
+		validation.Spec("Name", validation.Required.When(false), validation.Length(0, 0)),
+		validation.Spec("Address", addressSchema),
+	)
+
+	tests := []struct {
+		tag   string
+		value interface{}
+	}{
+		{"t1", user{}},
+		{"t2", &user{}},
+		{"t3", (*user)(nil)},
+	}
+	for _, test := range tests {
+		s, err := FromSchema(test.value, userSchema)
+		if !assert.NoError(t, err, test.tag) {
+			continue
+		}
+		b, err := json.Marshal(s)
+		assert.NoError(t, err, test.tag)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "maxLength": 0},
+				"address": {
+					"type": "object",
+					"properties": {
+						"street": {"type": "string", "minLength": 1},
+						"zip": {"type": "string", "minLength": 5, "maxLength": 5}
+					},
+					"required": ["street"]
+				}
+			}
+		}`, string(b), test.tag)
+	}
+}
+
+func TestGenerate_Dive(t *testing.T) {
+	var v struct {
+		Addresses []*address         `json:"addresses"`
+		Homes     map[string]address `json:"homes"`
+	}
+	street := validation.NamedField("Street", validation.Required)
+	s, err := Generate(&v,
+		validation.Field(&v.Addresses, validation.Dive(street)),
+		validation.Field(&v.Homes, validation.Dive(street)),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"addresses": {
+				"type": "array",
+				"items": {
+					"type": "object",
+					"properties": {"street": {"type": "string", "minLength": 1}},
+					"required": ["street"]
+				}
+			},
+			"homes": {
+				"type": "object",
+				"additionalProperties": {
+					"type": "object",
+					"properties": {"street": {"type": "string", "minLength": 1}},
+					"required": ["street"]
+				}
+			}
+		}
+	}`, string(b))
+}
+
+func TestGenerate_Ordered(t *testing.T) {
+	var v struct {
+		Age   int     `json:"age"`
+		Score float64 `json:"score"`
+	}
+	s, err := Generate(&v,
+		validation.Field(&v.Age, validation.Between(18, 150)),
+		validation.Field(&v.Score, validation.GT(0.0), validation.LTE(1.0)),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"age": {"type": "integer", "minimum": 18, "maximum": 150},
+			"score": {"type": "number", "exclusiveMinimum": 0, "maximum": 1}
+		}
+	}`, string(b))
+}
+
+func TestGenerate_Default(t *testing.T) {
+	var v struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	s, err := Generate(&v,
+		validation.Field(&v.Host, validation.Required),
+		validation.Field(&v.Port, validation.Default(8080), validation.Required),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"host": {"type": "string", "minLength": 1},
+			"port": {"type": "integer", "default": 8080}
+		},
+		"required": ["host"]
+	}`, string(b))
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	u := &user{}
+	other := ""
+
+	_, err := Generate("abc")
+	assert.EqualError(t, err, validation.ErrStructPointer.Error())
+
+	_, err = Generate(u, validation.Field(&other, validation.Required))
+	assert.EqualError(t, err, validation.ErrFieldNotFound(0).Error())
+
+	_, err = FromSchema(u, validation.NewSchema(
+		validation.Spec("Address", validation.NewSchema(validation.Spec("City"))),
+	))
+	assert.EqualError(t, err, "address: "+validation.ErrFieldNotFound(0).Error())
+}
+
+func TestTypeOf(t *testing.T) {
+	var p **int
+	tests := []struct {
+		tag      string
+		value    interface{}
+		expected *Schema
+	}{
+		{"t1", "", &Schema{Type: "string"}},
+		{"t2", true, &Schema{Type: "boolean"}},
+		{"t3", uint8(1), &Schema{Type: "integer"}},
+		{"t4", float32(1), &Schema{Type: "number"}},
+		{"t5", []byte("abc"), &Schema{Type: "string"}},
+		{"t6", [2]int{}, &Schema{Type: "array", Items: &Schema{Type: "integer"}}},
+		{"t7", map[string]bool{}, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "boolean"}}},
+		{"t8", time.Time{}, &Schema{Type: "string", Format: "date-time"}},
+		{"t9", address{}, &Schema{Type: "object"}},
+		{"t10", p, &Schema{Type: "integer"}},
+		{"t11", func() {}, &Schema{}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, TypeOf(reflect.TypeOf(test.value)), test.tag)
+	}
+}
+
+func TestGenerate_Unique(t *testing.T) {
+	var v struct {
+		Tags  []string `json:"tags"`
+		Items []string `json:"items"`
+	}
+	s, err := Generate(&v,
+		validation.Field(&v.Tags, validation.Unique()),
+		validation.Field(&v.Items, validation.UniqueBy(func(e interface{}) interface{} { return e })),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true},
+			"items": {"type": "array", "items": {"type": "string"}}
+		}
+	}`, string(b))
+}
+
+func TestGenerate_InFold(t *testing.T) {
+	var v struct {
+		Status string `json:"status"`
+		Role   string `json:"role"`
+	}
+	s, err := Generate(&v,
+		validation.Field(&v.Status, validation.InFold("active", "inactive")),
+		validation.Field(&v.Role, validation.In("admin", "user")),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"status": {"type": "string"},
+			"role": {"type": "string", "enum": ["admin", "user"]}
+		}
+	}`, string(b))
+}
+
+func TestGenerate_Enum(t *testing.T) {
+	var v struct {
+		Status string `json:"status"`
+		Level  int    `json:"level"`
+	}
+	s, err := Generate(&v,
+		validation.Field(&v.Status, validation.EnumOf("active", "inactive")),
+		validation.Field(&v.Level, validation.EnumFunc(func(l int) bool { return l < 3 })),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"status": {"type": "string", "enum": ["active", "inactive"]},
+			"level": {"type": "integer"}
+		}
+	}`, string(b))
+}
+
+func TestGenerate_SubsetOf(t *testing.T) {
+	var v struct {
+		Scopes []string `json:"scopes"`
+	}
+	s, err := Generate(&v, validation.Field(&v.Scopes, validation.SubsetOf("read", "write")))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"scopes": {"type": "array", "items": {"type": "string", "enum": ["read", "write"]}}
+		}
+	}`, string(b))
+}
+
+func TestGenerate_Items(t *testing.T) {
+	var v struct {
+		Tags   []string          `json:"tags"`
+		Labels map[string]string `json:"labels"`
+		Name   string            `json:"name"`
+	}
+	s, err := Generate(&v,
+		validation.Field(&v.Tags, validation.ItemsBetween(1, 5)),
+		validation.Field(&v.Labels, validation.MaxItems(0)),
+		validation.Field(&v.Name, validation.MinItems(2)),
+	)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	b, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 5},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}, "maxProperties": 0},
+			"name": {"type": "string"}
+		}
+	}`, string(b))
+}
@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type schemaUser struct {
+	Name    string `json:"name"`
+	Email   string
+	Age     int
+	Address schemaAddress `json:"address"`
+	*Struct2
+}
+
+func TestSchema(t *testing.T) {
+	addressSchema := NewSchema(
+		Spec("street", Required),
+		Spec("City", Required, Length(2, 10)),
+	)
+	userSchema := NewSchema(
+		Spec("name", Required),
+		Spec("Age", Min(18)),
+		Spec("Address", addressSchema),
+		Spec("Field21", Required),
+	)
+
+	var nilUser *schemaUser
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{"t1", &schemaUser{Name: "John", Age: 20, Address: schemaAddress{Street: "Main", City: "Paris"}}, ""},
+		{"t2", &schemaUser{Age: 10, Address: schemaAddress{City: "X"}}, "Age: must be no less than 18; address: (city: the length must be between 2 and 10; street: cannot be blank.); name: cannot be blank."},
+		{"t3", schemaUser{Name: "John", Address: schemaAddress{Street: "Main"}}, "address: (city: cannot be blank.)."},
+		{"t4", &schemaUser{Name: "John", Address: schemaAddress{Street: "Main", City: "Paris"}, Struct2: &Struct2{}}, "Field21: cannot be blank."},
+		{"t5", nilUser, ""},
+		{"t6", "abc", ErrStructPointer.Error()},
+		{"t7", &Struct2{}, ErrFieldNotFound(0).Error()},
+	}
+
+	for _, test := range tests {
+		// validate twice to exercise the cached fields
+		assertError(t, test.err, userSchema.Validate(context.Background(), test.value), test.tag)
+		assertError(t, test.err, userSchema.Validate(context.Background(), test.value), test.tag)
+	}
+
+	assert.Len(t, userSchema.Fields(), 4)
+	assert.Equal(t, "name", userSchema.Fields()[0].Name())
+	assert.Len(t, userSchema.Fields()[0].Rules(), 1)
+}
+
+func TestSchemaConcurrent(t *testing.T) {
+	schema := NewSchema(Spec("Name", Required))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			u := schemaUser{}
+			if i%2 == 0 {
+				u.Name = "John"
+				assert.Nil(t, schema.Validate(context.Background(), &u))
+			} else {
+				assert.EqualError(t, schema.Validate(context.Background(), &u), "name: cannot be blank.")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestFieldSpecAsFieldRules(t *testing.T) {
+	u := schemaUser{}
+	err := ValidateStruct(&u, Spec("name", Required), Spec("Email", Required))
+	assert.EqualError(t, err, "Email: cannot be blank; name: cannot be blank.")
+
+	err = ValidateStruct(&u, Spec("Unknown", Required))
+	assert.EqualError(t, err, ErrFieldNotFound(0).Error())
+
+	// unexported fields are never found
+	type secret struct {
+		_token string
+	}
+	s := secret{}
+	err = ValidateStruct(&s, Spec("_token", Required))
+	assert.EqualError(t, err, ErrFieldNotFound(0).Error())
+	err = NewSchema(Spec("_token", Required)).Validate(context.Background(), &s)
+	assert.EqualError(t, err, ErrFieldNotFound(0).Error())
+
+	// fields promoted through a nil embedded pointer are skipped
+	err = ValidateStruct(&u, Spec("Field21", Required))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,85 @@
+// Package schemaconfig builds validation schemas from declarative documents, so that validation rules can be
+// changed without changing code. The rules are resolved by name with the rule registry of the validation package.
+package schemaconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/rockcookies/go-validation"
+)
+
+// Config maps the names of struct fields to the rules of the fields.
+// For example, the following JSON document
+//
+//	{
+//	  "Name": [{"rule": "required"}, {"rule": "length", "params": {"min": 1, "max": 50}}],
+//	  "Age": [{"rule": "min", "params": {"threshold": 18}}]
+//	}
+//
+// validates the fields Name and Age. The field names are resolved in the same way as validation.Spec.
+// Config can also be decoded from YAML with any YAML package that supports the yaml struct tags.
+type Config map[string][]RuleConfig
+
+// RuleConfig specifies a rule by name.
+type RuleConfig struct {
+	// Rule is the name of the rule. It is resolved with validation.LookupFactory, or with
+	// validation.Lookup if no factory is registered with the name.
+	Rule string `json:"rule" yaml:"rule"`
+	// Params holds the params passed to the rule factory.
+	Params map[string]interface{} `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+// Parse builds a schema from a JSON document. See Config for the format of the document.
+func Parse(data []byte) (*validation.Schema, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var config Config
+	if err := dec.Decode(&config); err != nil {
+		return nil, fmt.Errorf("schemaconfig: %w", err)
+	}
+	return Build(config)
+}
+
+// Build builds a schema from the config. The fields are sorted by name.
+// An error is returned if a rule is not registered or cannot be created from its params.
+func Build(config Config) (*validation.Schema, error) {
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	specs := make([]*validation.FieldSpec, len(names))
+	for i, name := range names {
+		rules := make([]validation.Rule, len(config[name]))
+		for j, rc := range config[name] {
+			rule, err := buildRule(rc)
+			if err != nil {
+				return nil, fmt.Errorf("schemaconfig: field %q: %w", name, err)
+			}
+			rules[j] = rule
+		}
+		specs[i] = validation.Spec(name, rules...)
+	}
+	return validation.NewSchema(specs...), nil
+}
+
+func buildRule(rc RuleConfig) (validation.Rule, error) {
+	if factory, ok := validation.LookupFactory(rc.Rule); ok {
+		rule, err := factory(rc.Params)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rc.Rule, err)
+		}
+		return rule, nil
+	}
+	if rule, ok := validation.Lookup(rc.Rule); ok {
+		if len(rc.Params) > 0 {
+			return nil, fmt.Errorf("rule %q does not accept params", rc.Rule)
+		}
+		return rule, nil
+	}
+	return nil, fmt.Errorf("unknown rule %q", rc.Rule)
+}
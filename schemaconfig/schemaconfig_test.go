@@ -0,0 +1,79 @@
+package schemaconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	Name  string
+	Age   int
+	Score float64
+	Role  string
+}
+
+const userConfig = `{
+	"Name": [{"rule": "required"}, {"rule": "length", "params": {"min": 2, "max": 5}}],
+	"Age": [{"rule": "min", "params": {"threshold": 18}}],
+	"Score": [{"rule": "max", "params": {"threshold": 9.5, "exclusive": true}}],
+	"Role": [{"rule": "in", "params": {"values": ["admin", "user"]}}, {"rule": "match", "params": {"pattern": "^[a-z]+$"}}]
+}`
+
+func TestParse(t *testing.T) {
+	schema, err := Parse([]byte(userConfig))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		tag  string
+		user user
+		err  string
+	}{
+		{"t1", user{Name: "abc", Age: 20, Score: 9, Role: "admin"}, ""},
+		{"t2", user{}, "Name: cannot be blank."},
+		{"t3", user{Name: "abcdef", Age: 17, Score: 9.5, Role: "guest"}, "Age: must be no less than 18; Name: the length must be between 2 and 5; Role: must be a valid value; Score: must be less than 9.5."},
+	}
+	for _, test := range tests {
+		err := schema.Validate(context.Background(), &test.user)
+		if test.err == "" {
+			assert.NoError(t, err, test.tag)
+		} else if assert.Error(t, err, test.tag) {
+			assert.Equal(t, test.err, err.Error(), test.tag)
+		}
+	}
+}
+
+func TestParse_Error(t *testing.T) {
+	tests := []struct {
+		tag    string
+		config string
+		err    string
+	}{
+		{"t1", `[]`, "schemaconfig: json: cannot unmarshal array into Go value of type schemaconfig.Config"},
+		{"t2", `{"Name": [{"rule": "unknown"}]}`, `schemaconfig: field "Name": unknown rule "unknown"`},
+		{"t3", `{"Name": [{"rule": "required", "params": {"min": 1}}]}`, `schemaconfig: field "Name": rule "required" does not accept params`},
+		{"t4", `{"Name": [{"rule": "length", "params": {"min": 1.5}}]}`, `schemaconfig: field "Name": rule "length": the param "min" must be an integer`},
+		{"t5", `{"Name": [{"rule": "min", "params": {}}]}`, `schemaconfig: field "Name": rule "min": the param "threshold" must be a number`},
+		{"t6", `{"Name": [{"rule": "match", "params": {"pattern": "("}}]}`, "schemaconfig: field \"Name\": rule \"match\": error parsing regexp: missing closing ): `(`"},
+		{"t7", `{"Name": [{"rule": "in", "params": {"values": "a"}}]}`, `schemaconfig: field "Name": rule "in": the param "values" must be a list`},
+	}
+	for _, test := range tests {
+		_, err := Parse([]byte(test.config))
+		if assert.Error(t, err, test.tag) {
+			assert.Equal(t, test.err, err.Error(), test.tag)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	// a config decoded from YAML holds numbers of Go types rather than json.Number
+	schema, err := Build(Config{
+		"Age": {{Rule: "min", Params: map[string]interface{}{"threshold": 18}}},
+	})
+	if assert.NoError(t, err) {
+		assert.EqualError(t, schema.Validate(context.Background(), &user{Age: 10}), "Age: must be no less than 18.")
+	}
+}
@@ -0,0 +1,142 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// positionParam is the error param holding the SourcePosition of an error located by WithSourceMap.
+const positionParam = "position"
+
+// SourcePosition is the location of a value in a source file, e.g. a key in a config file.
+// Line and Column are 1-based, and zero if unknown.
+type SourcePosition struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String returns the position as "file:line:column". The unknown parts are omitted.
+func (p SourcePosition) String() string {
+	switch {
+	case p.Line <= 0:
+		return p.File
+	case p.File == "" && p.Column <= 0:
+		return fmt.Sprintf("line %d", p.Line)
+	case p.File == "":
+		return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+	case p.Column <= 0:
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// SourceMap maps error paths (see ErrorPath.String), e.g. "servers.0.port", to the positions of their values
+// in a source file. It can be built by SourceMapFromJSON, or by walking the nodes of other formats, e.g. the
+// Line and Column of the key nodes of a yaml.Node.
+type SourceMap map[string]SourcePosition
+
+// ErrorPosition returns the position of a validation error located by WithSourceMap.
+// The boolean result is false if the error has no position.
+func ErrorPosition(err error) (SourcePosition, bool) {
+	var e Error
+	if !errors.As(err, &e) {
+		return SourcePosition{}, false
+	}
+	pos, ok := e.Params()[positionParam].(SourcePosition)
+	return pos, ok
+}
+
+// locateErrors sets the positions found in the SourceMap set by WithSourceMap on the leaf errors of errs,
+// whose paths are relative to the field path of ctx. The errors not implementing Error and the errors
+// already located by a nested call are left unchanged.
+func locateErrors(ctx context.Context, errs Errors) {
+	sm := getOpts(ctx).sourceMap
+	if len(sm) == 0 {
+		return
+	}
+	errs.locate(sm, FieldPath(ctx))
+}
+
+func (es Errors) locate(sm SourceMap, prefix ErrorPath) {
+	for key, err := range es {
+		path := make(ErrorPath, len(prefix)+1)
+		copy(path, prefix)
+		path[len(prefix)] = key
+
+		switch err := err.(type) {
+		case nil:
+		case Errors:
+			err.locate(sm, path)
+		case Error:
+			pos, ok := sm[path.String()]
+			if _, located := err.Params()[positionParam]; !ok || located {
+				continue
+			}
+			// copy the params, so that the params of a shared error such as ErrRequired are not modified
+			params := make(map[string]interface{}, len(err.Params())+1)
+			for k, v := range err.Params() {
+				params[k] = v
+			}
+			params[positionParam] = pos
+			es[key] = err.SetParams(params)
+		}
+	}
+}
+
+// SourceMapFromJSON returns the positions of the object keys and array elements of a JSON document,
+// e.g. the position of the key "port" in {"servers": [{"port": 0}]} is mapped from "servers.0.port".
+// The positions are reported in file, and the columns are counted in bytes.
+func SourceMapFromJSON(file string, data []byte) (SourceMap, error) {
+	sm := SourceMap{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	position := func() SourcePosition {
+		// the offset of the decoder is at the end of the previous token, so skip to the next one
+		offset := int(dec.InputOffset())
+		for offset < len(data) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) >= 0 {
+			offset++
+		}
+		line := bytes.Count(data[:offset], []byte("\n")) + 1
+		return SourcePosition{File: file, Line: line, Column: offset - bytes.LastIndexByte(data[:offset], '\n')}
+	}
+
+	var walk func(path ErrorPath) error
+	walk = func(path ErrorPath) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		delim, ok := tok.(json.Delim)
+		if !ok {
+			return nil
+		}
+		for i := 0; dec.More(); i++ {
+			pos := position()
+			key := strconv.Itoa(i)
+			if delim == '{' {
+				if tok, err = dec.Token(); err != nil {
+					return err
+				}
+				key = tok.(string)
+			}
+			child := append(path[:len(path):len(path)], key)
+			sm[child.String()] = pos
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		// read the closing delimiter
+		_, err = dec.Token()
+		return err
+	}
+
+	if err := walk(nil); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sourceServer struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+func (s sourceServer) Validate(ctx context.Context) error {
+	return ValidateStructWithContext(ctx, &s,
+		Field(&s.Host, Required),
+		Field(&s.Port, Max(65535)),
+	)
+}
+
+func TestSourcePosition_String(t *testing.T) {
+	tests := []struct {
+		tag string
+		pos SourcePosition
+		out string
+	}{
+		{"t1", SourcePosition{File: "config.json", Line: 3, Column: 5}, "config.json:3:5"},
+		{"t2", SourcePosition{File: "config.json", Line: 3}, "config.json:3"},
+		{"t3", SourcePosition{File: "config.json"}, "config.json"},
+		{"t4", SourcePosition{Line: 3}, "line 3"},
+		{"t5", SourcePosition{Line: 3, Column: 5}, "line 3, column 5"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.out, test.pos.String(), test.tag)
+	}
+}
+
+func TestSourceMapFromJSON(t *testing.T) {
+	data := []byte("{\n  \"name\": \"app\",\n  \"servers\": [\n    {\"host\": \"\", \"port\": 70000},\n    []\n  ]\n}\n")
+	sm, err := SourceMapFromJSON("config.json", data)
+	assert.NoError(t, err)
+	assert.Equal(t, SourceMap{
+		"name":           {File: "config.json", Line: 2, Column: 3},
+		"servers":        {File: "config.json", Line: 3, Column: 3},
+		"servers.0":      {File: "config.json", Line: 4, Column: 5},
+		"servers.0.host": {File: "config.json", Line: 4, Column: 6},
+		"servers.0.port": {File: "config.json", Line: 4, Column: 18},
+		"servers.1":      {File: "config.json", Line: 5, Column: 5},
+	}, sm)
+
+	sm, err = SourceMapFromJSON("", []byte(`"abc"`))
+	assert.NoError(t, err)
+	assert.Empty(t, sm)
+
+	_, err = SourceMapFromJSON("", []byte(`{"name": }`))
+	assert.Error(t, err)
+	_, err = SourceMapFromJSON("", nil)
+	assert.Error(t, err)
+}
+
+func TestWithSourceMap(t *testing.T) {
+	type config struct {
+		Name    string         `json:"name"`
+		Servers []sourceServer `json:"servers"`
+	}
+	data := []byte(`{"name": "", "servers": [{"host": "", "port": 70000}]}`)
+	sm, err := SourceMapFromJSON("config.json", data)
+	assert.NoError(t, err)
+
+	c := config{Servers: []sourceServer{{Port: 70000}}}
+	ctx := WithOptions(context.Background(), WithSourceMap(sm))
+	err = ValidateStructWithContext(ctx, &c,
+		Field(&c.Name, Required),
+		Field(&c.Servers),
+	)
+	assertError(t, "name: cannot be blank; servers: (0: (host: cannot be blank; port: must be no greater than 65535.).).", err, "t1")
+
+	tests := []struct {
+		tag  string
+		path string
+		pos  SourcePosition
+	}{
+		{"t2", "name", SourcePosition{File: "config.json", Line: 1, Column: 2}},
+		{"t3", "servers.0.host", SourcePosition{File: "config.json", Line: 1, Column: 27}},
+		{"t4", "servers.0.port", SourcePosition{File: "config.json", Line: 1, Column: 39}},
+	}
+	for _, fe := range err.(Errors).Flatten() {
+		for _, test := range tests {
+			if fe.Path.String() == test.path {
+				pos, ok := ErrorPosition(fe.Err)
+				assert.True(t, ok, test.tag)
+				assert.Equal(t, test.pos, pos, test.tag)
+				assert.True(t, errors.Is(fe.Err, ErrRequired) || test.path == "servers.0.port", test.tag)
+			}
+		}
+	}
+
+	// the shared errors are not modified
+	_, ok := ErrorPosition(ErrRequired)
+	assert.False(t, ok)
+
+	var buf bytes.Buffer
+	assert.NoError(t, FprintErrors(&buf, err, PrintOptions{}))
+	assert.Equal(t, "name: cannot be blank (config.json:1:2)\nservers:\n  0:\n    host: cannot be blank (config.json:1:27)\n"+
+		"    port: must be no greater than 65535 (config.json:1:39)\n", buf.String())
+
+	// without a source map, the errors have no position
+	err = ValidateStructWithContext(context.Background(), &c, Field(&c.Name, Required))
+	_, ok = ErrorPosition(err.(Errors)["name"])
+	assert.False(t, ok)
+	_, ok = ErrorPosition(errors.New("abc"))
+	assert.False(t, ok)
+}
@@ -16,6 +16,7 @@ type stringValidatorWithContext func(context.Context, string) bool
 type StringRule struct {
 	validate stringValidatorWithContext
 	err      Error
+	trim     bool
 }
 
 // NewStringRule creates a new validation rule using a function that takes a string value and returns a bool.
@@ -58,6 +59,13 @@ func NewStringRuleWithContextError(validator stringValidatorWithContext, message
 	}
 }
 
+// TrimSpace makes the rule check the value without its leading and trailing white space.
+// It is useful for free-form input, where a value of spaces only must not pass. See also WithTrimSpace.
+func (r StringRule) TrimSpace() StringRule {
+	r.trim = true
+	return r
+}
+
 // Error sets the error message for the rule.
 func (r StringRule) Error(message string) StringRule {
 	r.err = r.err.SetMessage(message)
@@ -70,18 +78,32 @@ func (r StringRule) ErrorObject(err Error) StringRule {
 	return r
 }
 
+// Metadata returns the description of the rule.
+// The kind is "string", with the param "code" holding the error code, which identifies
+// the rule, e.g. "validation_is_email" for is.Email.
+func (r StringRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "string",
+		Params: map[string]interface{}{"code": r.err.Code()},
+	}
+}
+
 // Validate checks if the given value is valid or not.
 func (r StringRule) Validate(ctx context.Context, value interface{}) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	value, isNil := indirectWithOptions(value, GetOptions(ctx))
-	if isNil || IsEmpty(value) {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if r.trim {
+		value = trimSpace(value)
+	}
+	if isNil || isEmptyWithOptions(value, opts) {
 		return nil
 	}
 
-	str, err := EnsureString(value)
+	str, err := stringWithOptions(value, opts)
 	if err != nil {
 		return err
 	}
@@ -7,8 +7,11 @@ package validation
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -319,3 +322,81 @@ func TestNewStringRuleWithErrorCustom(t *testing.T) {
 		assert.Equal(t, "custom message", err.Error())
 	}
 }
+
+type stringerID struct {
+	v string
+}
+
+func (id stringerID) String() string {
+	return id.v
+}
+
+type textID struct {
+	v string
+}
+
+func (id *textID) MarshalText() ([]byte, error) {
+	if id.v == "error" {
+		return nil, errors.New("marshal error")
+	}
+	return []byte("text-" + id.v), nil
+}
+
+func (id *textID) String() string {
+	return "string-" + id.v
+}
+
+type stringerName string
+
+func (n stringerName) String() string {
+	return "ignored"
+}
+
+func TestStringRule_StringerConversion(t *testing.T) {
+	isMe := NewStringRule(validateMe, "must be me")
+	isTextMe := NewStringRule(func(s string) bool { return s == "text-me" }, "must be text-me")
+	ctx := WithOptions(context.Background(), WithStringerConversion(true))
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		rule  Rule
+		value interface{}
+		err   string
+	}{
+		{"t1", context.Background(), isMe, stringerID{"me"}, "must be either a string or byte slice"},
+		{"t2", ctx, isMe, stringerID{"me"}, ""},
+		{"t3", ctx, isMe, stringerID{"you"}, "must be me"},
+		{"t4", ctx, isMe, &stringerID{"me"}, ""},
+		{"t5", ctx, isMe, stringerID{}, ""},
+		{"t6", ctx, isMe, (*stringerID)(nil), ""},
+		{"t7", ctx, isTextMe, &textID{"me"}, ""},
+		{"t8", ctx, isTextMe, &textID{"error"}, "marshal error"},
+		{"t9", ctx, isTextMe, textID{"me"}, ""},
+		{"t10", ctx, isMe, stringerName("me"), ""},
+		{"t11", ctx, isMe, 123, "must be either a string or byte slice"},
+		{"t12", ctx, Match(regexp.MustCompile("^me$")), stringerID{"me"}, ""},
+		{"t13", ctx, Match(regexp.MustCompile("^me$")), &textID{"error"}, "marshal error"},
+		{"t14", ctx, Date("2006-01-02"), stringerID{"2024-01-02"}, ""},
+		{"t15", ctx, Date("2006-01-02"), &textID{"error"}, "marshal error"},
+		{"t16", ctx, MaxDigits(2), stringerID{"123"}, "must have no more than 2 digits"},
+		{"t17", ctx, DurationBetween(time.Second, time.Minute), stringerID{"1h"}, "must be between 1s and 1m0s"},
+		{"t18", ctx, BeforeTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), stringerID{"2025-01-01T00:00:00Z"}, "must be before 2024-01-01T00:00:00Z"},
+		{"t19", ctx, AsInt(Min(10)), stringerID{"5"}, "must be no less than 10"},
+		{"t20", context.Background(), AsInt(Min(10)), stringerID{"5"}, "must be either a string or byte slice"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(test.ctx, test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// the errors of MarshalText are internal errors
+	for _, rule := range []Rule{isMe, Match(regexp.MustCompile("^me$")), Date("2006-01-02"), MaxDigits(2),
+		DurationBetween(time.Second, time.Minute), BeforeTime(time.Now()), AsInt()} {
+		err := rule.Validate(ctx, &textID{"error"})
+		var ie InternalError
+		if assert.True(t, errors.As(err, &ie), "%T", rule) {
+			assert.EqualError(t, ie.InternalError(), "marshal error")
+		}
+	}
+}
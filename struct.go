@@ -7,15 +7,28 @@ package validation
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"runtime"
 	"strings"
+	"sync"
 )
 
-// ErrStructPointer is the error that a struct being validated is not specified as a pointer.
-var ErrStructPointer = errors.New("only a pointer to a struct can be validated")
+var (
+	// ErrStructPointer is the error that a struct being validated is not specified as a pointer.
+	ErrStructPointer = errors.New("only a pointer to a struct can be validated")
+	// ErrCycle is the error that a struct being validated references itself, directly or through nested values.
+	ErrCycle = errors.New("reference cycle detected")
+	// ErrMaxDepth is the error that a struct being validated is nested deeper than the limit set by WithMaxDepth.
+	ErrMaxDepth = errors.New("maximum depth exceeded")
+)
 
 // ValidateStruct validates a struct.
-// The structPtr parameter must be a pointer to a struct. If structPtr is nil, it is considered valid.
+// The structPtr parameter must be a pointer to a struct. If structPtr is nil, it is considered valid,
+// unless WithNilStructError is enabled.
+// A struct value is accepted as well if all fields are specified by NamedField or NamedFieldByTag, which look up
+// the fields by name and do not need their addresses. The struct value is validated read-only, i.e. the values
+// normalized by the rules are not written back.
 // The fields parameter specifies which struct fields to be validated and the validation rules for each field.
 // Each element in fields corresponds to one struct field. The order of the elements in fields does not
 // have to be the same as the order of the struct fields.
@@ -61,51 +74,673 @@ func ValidateStructWithContext(ctx context.Context, structPtr interface{}, field
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx = startRuleCache(ctx)
+	ctx, reporting := startReporting(ctx)
 
-	value := reflect.ValueOf(structPtr)
-	if value.Kind() != reflect.Ptr || !value.IsNil() && value.Elem().Kind() != reflect.Struct {
-		// must be a pointer to a struct
-		return NewInternalError(ErrStructPointer)
+	structPtr = readOnlyStruct(structPtr, fields)
+	value, err := structValue(ctx, structPtr)
+	if err != nil || !value.IsValid() {
+		return err
 	}
-	if value.IsNil() {
-		// treat a nil struct pointer as valid
-		return nil
+	if err := checkNesting(ctx, structPtr); err != nil {
+		return err
 	}
-	value = value.Elem()
 
-	errs := Errors{}
+	var (
+		errs        Errors
+		structRules []structLevelRules
+	)
 
 	for i, fr := range fields {
-		ft, validateValue, err := fr.FindStructField(value, i)
-		if err == ErrSkipFieldNotFound {
+		if sr, ok := fr.(structLevelRules); ok {
+			structRules = append(structRules, sr)
 			continue
-		} else if err != nil {
+		}
+		fe, err := validateStructField(ctx, structPtr, value, i, fr)
+		if err != nil {
+			return err
+		}
+		errs.addFieldError(fe)
+	}
+
+	if err := validateStructRules(ctx, structPtr, structRules, &errs); err != nil {
+		return err
+	}
+	if err := addUnknownFields(ctx, value, fields, &errs); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		locateErrors(ctx, errs)
+		if reporting {
+			reportErrors(ctx, errs)
+		}
+		return errs
+	}
+	return nil
+}
+
+// ValidateStructOpts validates a struct like ValidateStructWithContext, with the options applied on top of those
+// of the context for this validation only, so that a derived context does not need to be built with WithOptions.
+// For example,
+//
+//	err := validation.ValidateStructOpts(ctx, &u,
+//	    []validation.Option{validation.WithGetErrorFieldNameFunc(jsonFieldName)},
+//	    validation.Field(&u.Name, validation.Required),
+//	)
+func ValidateStructOpts(ctx context.Context, structPtr interface{}, opts []Option, fields ...FieldRules) error {
+	return ValidateStructWithContext(WithOptions(ctx, opts...), structPtr, fields...)
+}
+
+// ValidateStructParallel validates a struct like ValidateStructWithContext, but validates the fields concurrently.
+// It is useful when some rules are slow, e.g. rules that look up values in a database or call external services.
+// The number of fields validated at the same time is bounded by the WithMaxWorkers option, which defaults to
+// runtime.GOMAXPROCS(0). The rules of the fields must be safe for concurrent use.
+//
+// The returned Errors is the same as the one returned by ValidateStructWithContext. If several fields return
+// an internal error, the one belonging to the first field in the fields list is returned.
+// If the context is canceled before all fields are validated, the context error is returned as an InternalError.
+func ValidateStructParallel(ctx context.Context, structPtr interface{}, fields ...FieldRules) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = startRuleCache(ctx)
+	ctx, reporting := startReporting(ctx)
+
+	structPtr = readOnlyStruct(structPtr, fields)
+	value, err := structValue(ctx, structPtr)
+	if err != nil || !value.IsValid() {
+		return err
+	}
+	if err := checkNesting(ctx, structPtr); err != nil {
+		return err
+	}
+
+	workers := getOpts(ctx).maxWorkers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	var (
+		wg          sync.WaitGroup
+		sem         = make(chan struct{}, workers)
+		results     = make([]*fieldError, len(fields))
+		fatals      = make([]error, len(fields))
+		structRules []structLevelRules
+	)
+
+schedule:
+	for i, fr := range fields {
+		if sr, ok := fr.(structLevelRules); ok {
+			structRules = append(structRules, sr)
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break schedule
+		}
+
+		wg.Add(1)
+		go func(i int, fr FieldRules) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i], fatals[i] = validateStructField(ctx, structPtr, value, i, fr)
+		}(i, fr)
+	}
+	wg.Wait()
+
+	for _, err := range fatals {
+		if err != nil {
 			return err
 		}
+	}
+	if err := ctx.Err(); err != nil {
+		return NewInternalError(err)
+	}
+
+	var errs Errors
+	for _, fe := range results {
+		errs.addFieldError(fe)
+	}
+
+	if err := validateStructRules(ctx, structPtr, structRules, &errs); err != nil {
+		return err
+	}
+	if err := addUnknownFields(ctx, value, fields, &errs); err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		locateErrors(ctx, errs)
+		if reporting {
+			reportErrors(ctx, errs)
+		}
+		return errs
+	}
+	return nil
+}
 
-		if err := ValidateWithContext(ctx, validateValue, fr.Rules()...); err != nil {
+// validateStructRules runs the struct-level rules in order. The presence rules always run, and their errors
+// are added to errs under the combined keys of their fields. The other rules run only if the struct is valid
+// otherwise, and the first validation error is added to errs under the struct error key.
+func validateStructRules(ctx context.Context, structPtr interface{}, rules []structLevelRules, errs *Errors) error {
+	for _, rule := range rules {
+		if !isPresenceRule(rule) {
+			continue
+		}
+		err := rule.validateStruct(ctx, structPtr)
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return err
+		}
+		if pe, ok := err.(Errors); ok {
+			if *errs == nil {
+				*errs = Errors{}
+			}
+			for key, e := range pe {
+				(*errs)[key] = e
+			}
+		}
+	}
+
+	if len(*errs) > 0 {
+		return nil
+	}
+	for _, rule := range rules {
+		if isPresenceRule(rule) {
+			continue
+		}
+		if err := rule.validateStruct(ctx, structPtr); err != nil {
 			if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
 				return err
 			}
-			if ft.Anonymous {
-				// merge errors from anonymous struct field
-				if es, ok := err.(Errors); ok {
-					for name, value := range es {
-						errs[name] = value
-					}
-					continue
-				}
+			*errs = Errors{getOpts(ctx).structErrorKey: err}
+			return nil
+		}
+	}
+	return nil
+}
+
+// isPresenceRule reports whether the struct-level rule is a PresenceRule, possibly scoped by WithScopedOptions.
+func isPresenceRule(rule structLevelRules) bool {
+	if s, ok := rule.(*scopedStructRules); ok {
+		rule = s.structLevelRules
+	}
+	_, ok := rule.(*PresenceRule)
+	return ok
+}
+
+// fieldName returns the name in FieldPath of the struct field ft specified by fr, and its key in the validation
+// errors, which differs from the name if transformed by WithFieldNameTransformer.
+func (o *options) fieldName(fr FieldRules, ft *reflect.StructField) (name, key string) {
+	if fo, ok := fr.(fieldOverrider); ok && fo.overrides().key != "" {
+		return fo.overrides().key, fo.overrides().key
+	}
+	name = o.getErrorFieldNameFunc(ft)
+	return name, o.transformFieldName(name)
+}
+
+// addUnknownFields adds the fields present in the payload set by WithRejectUnknownFields that are not covered
+// by the field rules of the struct value to errs.
+func addUnknownFields(ctx context.Context, value reflect.Value, fields []FieldRules, errs *Errors) error {
+	opts := getOpts(ctx)
+	if opts.payload == nil {
+		return nil
+	}
+	if n := fieldNodeOf(ctx); n != nil && n.hasParent && !n.hasSegment {
+		// the fields of an anonymous struct field are checked with the enclosing struct
+		return nil
+	}
+
+	prefix := FieldPath(ctx).String()
+	if prefix != "" {
+		prefix += "."
+	}
+	var covered map[string]bool
+	var unknown Errors
+	for path := range opts.payload {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		name := path[len(prefix):]
+		if name == "" || strings.Contains(name, ".") {
+			// not a field of the struct
+			continue
+		}
+		if covered == nil {
+			var err error
+			if covered, err = coveredFields(ctx, value, fields); err != nil {
+				return err
 			}
-			errs[getOpts(ctx).getErrorFieldNameFunc(ft)] = err
+		}
+		if !covered[name] {
+			if unknown == nil {
+				unknown = Errors{}
+			}
+			unknown[name] = ErrKeyUnexpected
 		}
 	}
 
-	if len(errs) > 0 {
-		return errs
+	if unknown != nil {
+		if *errs == nil {
+			*errs = Errors{}
+		}
+		(*errs)[opts.unknownErrorKey] = unknown
 	}
 	return nil
 }
 
+// coveredFields returns the names of the struct fields of value specified by the field rules.
+func coveredFields(ctx context.Context, value reflect.Value, fields []FieldRules) (map[string]bool, error) {
+	opts := getOpts(ctx)
+	covered := make(map[string]bool, len(fields))
+	for i, fr := range fields {
+		if _, ok := fr.(structLevelRules); ok {
+			continue
+		}
+		var (
+			ft  *reflect.StructField
+			err error
+		)
+		if cf, ok := fr.(contextFieldFinder); ok {
+			ft, _, err = cf.findStructFieldWithContext(ctx, value, i)
+		} else {
+			ft, _, err = fr.FindStructField(value, i)
+		}
+		if err == ErrSkipFieldNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if ft.Anonymous {
+			addEmbeddedFields(opts, ft.Type, covered)
+		} else {
+			name, _ := opts.fieldName(fr, ft)
+			covered[name] = true
+		}
+	}
+	return covered, nil
+}
+
+// addEmbeddedFields adds the names of the exported fields of the anonymous struct field of type t to names.
+func addEmbeddedFields(opts *options, t reflect.Type, names map[string]bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous {
+			addEmbeddedFields(opts, sf.Type, names)
+		} else if sf.IsExported() {
+			names[opts.getErrorFieldNameFunc(&sf)] = true
+		}
+	}
+}
+
+// fieldError is the validation error of a single struct field.
+type fieldError struct {
+	field *reflect.StructField
+	name  string
+	err   error
+}
+
+// checkNesting returns an InternalError wrapping ErrCycle if the struct pointed to by structPtr is already
+// being validated as the parent of an outer field, or if the field leading to the struct holds the same pointer,
+// map or slice as an outer field, which happens when Validate methods with value receivers validate copies of
+// the structs. It returns an InternalError wrapping ErrMaxDepth if the path of the struct is longer than the limit
+// set by WithMaxDepth. The wrapped error is prefixed with the path of the struct.
+func checkNesting(ctx context.Context, structPtr interface{}) error {
+	n := fieldNodeOf(ctx)
+	if n == nil {
+		return nil
+	}
+	if max := getOpts(ctx).maxDepth; max > 0 && n.depth > max {
+		return NewInternalError(fmt.Errorf("%v: %w", FieldPath(ctx), ErrMaxDepth))
+	}
+	ref := n.ref
+	for ; n != nil; n = n.prev {
+		if n.hasParent && n.parent == structPtr || ref.ptr != 0 && n.prev != nil && n.prev.ref == ref {
+			return NewInternalError(fmt.Errorf("%v: %w", FieldPath(ctx), ErrCycle))
+		}
+	}
+	return nil
+}
+
+// readOnlyStruct returns a pointer to a copy of structPtr if it is a struct value and all fields are
+// NamedFieldRules, which look up the fields by name. Otherwise, structPtr is returned as is.
+func readOnlyStruct(structPtr interface{}, fields []FieldRules) interface{} {
+	value := reflect.ValueOf(structPtr)
+	if value.Kind() != reflect.Struct || len(fields) == 0 {
+		return structPtr
+	}
+	for _, fr := range fields {
+		if _, ok := fr.(*NamedFieldRules); !ok {
+			return structPtr
+		}
+	}
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+	return ptr.Interface()
+}
+
+// structValue returns the struct referenced by structPtr.
+// An invalid reflect.Value is returned if structPtr is a nil pointer, which is considered valid
+// unless WithNilStructError is enabled, in which case ErrNotNilRequired is returned.
+func structValue(ctx context.Context, structPtr interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(structPtr)
+	if value.Kind() != reflect.Ptr || !value.IsNil() && value.Elem().Kind() != reflect.Struct {
+		// must be a pointer to a struct
+		return reflect.Value{}, NewInternalError(ErrStructPointer)
+	}
+	if value.IsNil() {
+		if getOpts(ctx).nilStructError {
+			return reflect.Value{}, ErrNotNilRequired
+		}
+		// treat a nil struct pointer as valid
+		return reflect.Value{}, nil
+	}
+	return value.Elem(), nil
+}
+
+// contextFieldFinder is implemented by field rules whose struct field lookup depends on the options of the context.
+type contextFieldFinder interface {
+	findStructFieldWithContext(ctx context.Context, structValue reflect.Value, idx int) (*reflect.StructField, any, error)
+}
+
+// validateStructField validates the struct field specified by fr.
+// A nil fieldError is returned if the field is valid or skipped. The returned error is non-nil
+// only if the validation must be aborted, e.g. when an internal error occurs.
+func validateStructField(ctx context.Context, structPtr interface{}, value reflect.Value, idx int, fr FieldRules) (*fieldError, error) {
+	var (
+		ft            *reflect.StructField
+		validateValue any
+		err           error
+	)
+	if cf, ok := fr.(contextFieldFinder); ok {
+		ft, validateValue, err = cf.findStructFieldWithContext(ctx, value, idx)
+	} else {
+		ft, validateValue, err = fr.FindStructField(value, idx)
+	}
+	if err == ErrSkipFieldNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	opts := getOpts(ctx)
+	name, key := opts.fieldName(fr, ft)
+	ctx = withField(ctx, structPtr, ft, name, validateValue)
+	if fo, ok := fr.(fieldOverrider); ok && len(fo.overrides().options) > 0 {
+		ctx = WithOptions(ctx, fo.overrides().options...)
+	}
+	if opts.partial && !ft.Anonymous && !opts.present[FieldPath(ctx).String()] {
+		// the field is absent from the partial update
+		return nil, nil
+	}
+	rules := fr.Rules()
+	if af, ok := fr.(addressableField); ok && hasNormalizer(rules) {
+		if ptr, ok := af.fieldAddr(value, ft); ok {
+			// write the normalized value back to the field
+			_, rules = normalize(ptr.Interface(), rules)
+			if reflect.TypeOf(validateValue) != ptr.Type() {
+				validateValue = ptr.Elem().Interface()
+			}
+		}
+	}
+	if err := ValidateWithContext(ctx, validateValue, rules...); err != nil {
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return nil, err
+		}
+		if fo, ok := fr.(fieldOverrider); ok {
+			if err = fo.overrides().overrideError(err); err == nil {
+				return nil, nil
+			}
+		}
+		return &fieldError{field: ft, name: key, err: err}, nil
+	}
+	return nil, nil
+}
+
+// addFieldError adds the error of a struct field to es.
+// The errors of an anonymous struct field are merged into es. If es already holds nested Errors
+// for the field, e.g. reported by NamedField paths sharing the same prefix, the errors are merged.
+// The map is allocated by the first error, so that validating a valid struct does not allocate it.
+func (es *Errors) addFieldError(fe *fieldError) {
+	if fe == nil {
+		return
+	}
+	if *es == nil {
+		*es = Errors{}
+	}
+	if fe.field.Anonymous {
+		// merge errors from anonymous struct field
+		if errs, ok := fe.err.(Errors); ok {
+			for name, value := range errs {
+				(*es)[name] = value
+			}
+			return
+		}
+	}
+	es.merge(fe.name, fe.err)
+}
+
+// merge adds err to es under key. If both err and the existing error are Errors, they are merged recursively.
+func (es Errors) merge(key string, err error) {
+	existing, ok1 := es[key].(Errors)
+	errs, ok2 := err.(Errors)
+	if !ok1 || !ok2 {
+		es[key] = err
+		return
+	}
+	merged := make(Errors, len(existing)+len(errs))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range errs {
+		merged.merge(k, v)
+	}
+	es[key] = merged
+}
+
+type (
+	fieldNodeCtxKeyType struct{}
+	reportingCtxKeyType struct{}
+)
+
+var (
+	fieldNodeCtxKey = fieldNodeCtxKeyType{}
+	reportingCtxKey = reportingCtxKeyType{}
+)
+
+// fieldNode describes the struct field or collection element being validated. The nodes of nested
+// fields and elements are linked, so that a context carries the whole path in a single value
+// and validating a field does not copy the path of its parent.
+type fieldNode struct {
+	prev *fieldNode
+	// segment is the path segment added by the node, unless the node is an anonymous struct field.
+	segment    string
+	hasSegment bool
+	// parent is the struct pointer of a struct field.
+	parent    interface{}
+	hasParent bool
+	// key is the index or map key of a collection element.
+	key       interface{}
+	isElement bool
+	// ref is the pointer, map or slice held by a struct field, which is followed to validate the field.
+	ref reference
+	// depth is the number of path segments up to and including the node.
+	depth int
+}
+
+// reference identifies the value referenced by a pointer, map or slice. Its zero value references nothing.
+type reference struct {
+	t   reflect.Type
+	ptr uintptr
+	len int
+}
+
+// referenceOf returns the reference held by value, which is a non-nil pointer, map or non-empty slice.
+// Pointers to zero-sized values are ignored, as they may share the same address.
+func referenceOf(value interface{}) reference {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() && v.Type().Elem().Size() > 0 {
+			return reference{t: v.Type(), ptr: v.Pointer()}
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			return reference{t: v.Type(), ptr: v.Pointer()}
+		}
+	case reflect.Slice:
+		if v.Len() > 0 {
+			return reference{t: v.Type(), ptr: v.Pointer(), len: v.Len()}
+		}
+	}
+	return reference{}
+}
+
+// fieldContext is the context of a struct field or collection element being validated.
+// It holds the fieldNode itself instead of using context.WithValue, so that deriving it takes one allocation.
+type fieldContext struct {
+	context.Context
+	node fieldNode
+}
+
+// Value returns the fieldNode for fieldNodeCtxKey, and the value of the parent context otherwise.
+func (c *fieldContext) Value(key interface{}) interface{} {
+	if key == fieldNodeCtxKey {
+		return &c.node
+	}
+	return c.Context.Value(key)
+}
+
+// withFieldNode returns a context carrying the node linked to the node of the given context.
+func withFieldNode(ctx context.Context, n fieldNode) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	n.prev = fieldNodeOf(ctx)
+	if n.prev != nil {
+		n.depth = n.prev.depth
+	}
+	if n.hasSegment {
+		n.depth++
+	}
+	return &fieldContext{Context: ctx, node: n}
+}
+
+func fieldNodeOf(ctx context.Context) *fieldNode {
+	if ctx == nil {
+		return nil
+	}
+	n, _ := ctx.Value(fieldNodeCtxKey).(*fieldNode)
+	return n
+}
+
+// Parent returns the pointer to the struct whose field is being validated by ValidateStructWithContext.
+// Rules can use it to read sibling fields for cross-field checks, such as comparing a password
+// with its confirmation. The boolean result is false when the value is not validated as a struct field.
+func Parent(ctx context.Context) (interface{}, bool) {
+	for n := fieldNodeOf(ctx); n != nil; n = n.prev {
+		if n.hasParent {
+			return n.parent, n.parent != nil
+		}
+	}
+	return nil, false
+}
+
+// FieldPath returns the error field names leading from the outermost validated struct
+// to the field being validated, e.g. ["address", "street"] for a nested struct field.
+// An empty path is returned when the value is not validated as a struct field.
+func FieldPath(ctx context.Context) ErrorPath {
+	size := 0
+	for n := fieldNodeOf(ctx); n != nil; n = n.prev {
+		if n.hasSegment {
+			size++
+		}
+	}
+	if size == 0 {
+		return nil
+	}
+
+	path := make(ErrorPath, size)
+	for n := fieldNodeOf(ctx); n != nil; n = n.prev {
+		if n.hasSegment {
+			size--
+			path[size] = n.segment
+		}
+	}
+	return path
+}
+
+// withField returns a context carrying the parent struct and the path of the given struct field,
+// and the reference held by the field value for cycle detection.
+// Anonymous fields do not add a path segment because their errors are merged into the parent.
+func withField(ctx context.Context, structPtr interface{}, ft *reflect.StructField, name string, value interface{}) context.Context {
+	return withFieldNode(ctx, fieldNode{
+		segment:    name,
+		hasSegment: !ft.Anonymous,
+		parent:     structPtr,
+		hasParent:  true,
+		ref:        referenceOf(value),
+	})
+}
+
+// withPathSegment returns a context whose field path is extended with the given segment.
+func withPathSegment(ctx context.Context, segment string) context.Context {
+	return withFieldNode(ctx, fieldNode{segment: segment, hasSegment: true})
+}
+
+// startReporting returns a context marking that the errors are reported by the current call, and true,
+// if an ErrorReporter is set and the errors are not already reported by an outer call.
+func startReporting(ctx context.Context) (context.Context, bool) {
+	if getOpts(ctx).errorReporter == nil || ctx.Value(reportingCtxKey) != nil {
+		return ctx, false
+	}
+	return context.WithValue(ctx, reportingCtxKey, true), true
+}
+
+// reportErrors calls the ErrorReporter for every leaf error.
+func reportErrors(ctx context.Context, errs Errors) {
+	report := getOpts(ctx).errorReporter
+	prefix := FieldPath(ctx)
+	for _, fe := range errs.Flatten() {
+		var e Error
+		if !errors.As(fe.Err, &e) {
+			e = NewError("", fe.Err.Error())
+		}
+		path := make(ErrorPath, 0, len(prefix)+len(fe.Path))
+		report(append(append(path, prefix...), fe.Path...).String(), e)
+	}
+}
+
+// ElementKey returns the index or key of the slice, array or map element being validated by Each, Dive,
+// or the Validatable elements of a collection. The index of a slice or array element is an int, and the key
+// of a map element is the map key itself. Rules can use it to refer to the position of the element in error
+// messages, or to treat elements differently by position, e.g. the first one. Within nested elements,
+// the key of the innermost element is returned. The boolean result is false when no element is being validated.
+func ElementKey(ctx context.Context) (interface{}, bool) {
+	for n := fieldNodeOf(ctx); n != nil; n = n.prev {
+		if n.isElement {
+			return n.key, true
+		}
+	}
+	return nil, false
+}
+
+// withElement returns a context for validating a collection element. The field path is extended with
+// the segment, and the key is returned by ElementKey.
+func withElement(ctx context.Context, segment string, key interface{}) context.Context {
+	return withFieldNode(ctx, fieldNode{segment: segment, hasSegment: true, key: key, isElement: true})
+}
+
 // ErrorFieldName returns the name resolved from tagName for the provided struct field pointer.
 func ErrorFieldName(structPtr interface{}, fieldPtr interface{}, tagName string) (string, error) {
 	value := reflect.ValueOf(structPtr)
@@ -133,12 +768,34 @@ func ErrorFieldName(structPtr interface{}, fieldPtr interface{}, tagName string)
 
 // getErrorFieldName returns the name that should be used to represent the validation error of a struct field.
 func getErrorFieldName(f *reflect.StructField, tagName string) string {
+	if name, ok := tagFieldName(f, tagName); ok {
+		return name
+	}
+	return f.Name
+}
+
+// tagFieldName returns the name of a struct field given by the tag, which is unset if it is empty or "-".
+func tagFieldName(f *reflect.StructField, tagName string) (string, bool) {
 	if tag := f.Tag.Get(tagName); tag != "" && tag != "-" {
 		if cps := strings.SplitN(tag, ",", 2); cps[0] != "" {
-			return cps[0]
+			return cps[0], true
 		}
 	}
-	return f.Name
+	return "", false
+}
+
+// ErrorFieldNameFromTags returns a GetErrorFieldNameFunc that names a struct field by the first of the tags set on it,
+// e.g. ErrorFieldNameFromTags("form", "json") tries the form tag, then the json tag, then the Go field name.
+// A tag whose name is empty or "-" is considered unset. See also WithErrorFieldTag.
+func ErrorFieldNameFromTags(tags ...string) GetErrorFieldNameFunc {
+	return func(f *reflect.StructField) string {
+		for _, tag := range tags {
+			if name, ok := tagFieldName(f, tag); ok {
+				return name
+			}
+		}
+		return f.Name
+	}
 }
 
 func DefaultGetErrorFieldName(f *reflect.StructField) string {
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// structLevelRules is implemented by the FieldRules that validate the whole struct instead of a single field.
+type structLevelRules interface {
+	FieldRules
+	validateStruct(ctx context.Context, structPtr interface{}) error
+}
+
+var _ structLevelRules = (*structRule[struct{}])(nil)
+
+// StructRule returns a rule that validates the whole struct, which can be passed to ValidateStruct
+// together with the field rules. It is useful for invariants spanning multiple fields.
+// The rule runs only after all field rules pass, and its error is reported under the key set by
+// WithStructErrorKey ("_struct" by default). For example,
+//
+//	err := validation.ValidateStruct(&r,
+//	    validation.Field(&r.Start, validation.Required),
+//	    validation.Field(&r.End, validation.Required),
+//	    validation.StructRule(func(ctx context.Context, r *Range) error {
+//	        if r.End.Before(r.Start) {
+//	            return errors.New("end must be after start")
+//	        }
+//	        return nil
+//	    }),
+//	)
+//
+// If several struct rules are given, they run in order and the first error is reported.
+// An InternalError is returned if the struct being validated is not of type T.
+func StructRule[T any](f func(ctx context.Context, s *T) error) FieldRules {
+	return &structRule[T]{f: f}
+}
+
+type structRule[T any] struct {
+	f func(ctx context.Context, s *T) error
+}
+
+func (r *structRule[T]) Rules() []Rule {
+	return nil
+}
+
+func (r *structRule[T]) FindStructField(reflect.Value, int) (*reflect.StructField, any, error) {
+	return nil, nil, ErrSkipFieldNotFound
+}
+
+func (r *structRule[T]) validateStruct(ctx context.Context, structPtr interface{}) error {
+	s, ok := structPtr.(*T)
+	if !ok {
+		return NewInternalError(fmt.Errorf("struct rule expects %v, got %T", reflect.TypeOf((*T)(nil)), structPtr))
+	}
+	return r.f(ctx, s)
+}
@@ -0,0 +1,60 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructRule(t *testing.T) {
+	type Range struct {
+		Start int `json:"start"`
+		End   int `json:"end"`
+	}
+
+	ordered := StructRule(func(ctx context.Context, r *Range) error {
+		if r.End < r.Start {
+			return errors.New("end must not be less than start")
+		}
+		return nil
+	})
+	internal := StructRule(func(ctx context.Context, r *Range) error {
+		return NewInternalError(errors.New("internal"))
+	})
+	wrongType := StructRule(func(ctx context.Context, s *Struct2) error {
+		return nil
+	})
+
+	tests := []struct {
+		tag   string
+		value Range
+		rules func(r *Range) []FieldRules
+		err   string
+	}{
+		{"t1", Range{1, 2}, func(r *Range) []FieldRules { return []FieldRules{Field(&r.Start, Required), ordered} }, ""},
+		{"t2", Range{2, 1}, func(r *Range) []FieldRules { return []FieldRules{Field(&r.Start, Required), ordered} }, "_struct: end must not be less than start."},
+		{"t3", Range{0, -1}, func(r *Range) []FieldRules { return []FieldRules{ordered, Field(&r.Start, Required)} }, "start: cannot be blank."},
+		{"t4", Range{1, 2}, func(r *Range) []FieldRules { return []FieldRules{ordered, internal} }, "internal"},
+		{"t5", Range{2, 1}, func(r *Range) []FieldRules { return []FieldRules{ordered, internal} }, "_struct: end must not be less than start."},
+		{"t6", Range{1, 2}, func(r *Range) []FieldRules { return []FieldRules{wrongType} }, "struct rule expects *validation.Struct2, got *validation.Range"},
+	}
+
+	for _, test := range tests {
+		r := test.value
+		err := ValidateStruct(&r, test.rules(&r)...)
+		assertError(t, test.err, err, test.tag)
+
+		r = test.value
+		err = ValidateStructParallel(context.Background(), &r, test.rules(&r)...)
+		assertError(t, test.err, err, test.tag+" (parallel)")
+	}
+
+	r := Range{2, 1}
+	ctx := WithOptions(context.Background(), WithStructErrorKey("range"))
+	err := For(&r).With(ordered).Validate(ctx)
+	assert.EqualError(t, err, "range: end must not be less than start.")
+	assert.Equal(t, "range", GetOptions(ctx).StructErrorKey())
+	assert.Equal(t, DefaultStructErrorKey, DefaultOptions().StructErrorKey())
+}
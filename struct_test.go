@@ -6,8 +6,10 @@ package validation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -104,6 +106,9 @@ func TestValidateStruct(t *testing.T) {
 		{"t3.2", nil, []FieldRules{}, ErrStructPointer.Error()},
 		{"t3.3", m0, []FieldRules{}, ""},
 		{"t3.4", &m0, []FieldRules{}, ErrStructPointer.Error()},
+		{"t3.5", m1, []FieldRules{NamedField("A", &validateXyz{}), NamedFieldByTag("json", "g", &validateXyz{})}, "A: error xyz."},
+		{"t3.6", m1, []FieldRules{NamedField("A", &validateXyz{}), Field(&m1.B, &validateAbc{})}, ErrStructPointer.Error()},
+		{"t3.7", m6, []FieldRules{NamedField("B.C.D", Required)}, "B: (C: (D: cannot be blank.).)."},
 		// invalid field spec
 		{"t4.1", &m1, []FieldRules{Field(m1)}, ErrFieldPointer(0).Error()},
 		{"t4.2", &m1, []FieldRules{Field(&m1)}, ErrFieldNotFound(0).Error()},
@@ -230,6 +235,84 @@ func Test_getErrorFieldName(t *testing.T) {
 	assert.Equal(t, "JSONIgnoredField", getErrorFieldName(jsonIgnoredField, tagName))
 }
 
+func TestErrorFieldNameFromTags(t *testing.T) {
+	type form struct {
+		A string `form:"a_form" json:"a_json"`
+		B string `json:"b_json"`
+		C string `form:"-" json:"c_json"`
+		D string
+	}
+	tests := []struct {
+		tag      string
+		tags     []string
+		expected []string
+	}{
+		{"t1", []string{"form"}, []string{"a_form", "B", "C", "D"}},
+		{"t2", []string{"form", "json"}, []string{"a_form", "b_json", "c_json", "D"}},
+		{"t3", []string{"json", "form"}, []string{"a_json", "b_json", "c_json", "D"}},
+		{"t4", nil, []string{"A", "B", "C", "D"}},
+	}
+	ft := reflect.TypeOf(form{})
+	for _, test := range tests {
+		f := ErrorFieldNameFromTags(test.tags...)
+		for i, expected := range test.expected {
+			sf := ft.Field(i)
+			assert.Equal(t, expected, f(&sf), test.tag)
+		}
+	}
+
+	v := form{}
+	ctx := WithOptions(context.Background(), WithErrorFieldTag("form", "json"))
+	err := ValidateStructWithContext(ctx, &v, Field(&v.A, Required), Field(&v.B, Required))
+	assert.EqualError(t, err, "a_form: cannot be blank; b_json: cannot be blank.")
+}
+
+func TestWithFieldNameTransformer(t *testing.T) {
+	type user struct {
+		UserID    string
+		Email     string `json:"email_address"`
+		CreatedAt string
+		Name      string
+	}
+	v := user{}
+	fields := func() []FieldRules {
+		return []FieldRules{
+			Field(&v.UserID, Required),
+			Field(&v.Email, Required),
+			Field(&v.CreatedAt, Required),
+			Field(&v.Name, Required).Key("Full Name"),
+		}
+	}
+	tests := []struct {
+		tag         string
+		transformer func(string) string
+		expected    string
+	}{
+		{"t1", nil, "CreatedAt: cannot be blank; Full Name: cannot be blank; UserID: cannot be blank; email_address: cannot be blank."},
+		{"t2", SnakeCase, "Full Name: cannot be blank; created_at: cannot be blank; email_address: cannot be blank; user_id: cannot be blank."},
+		{"t3", CamelCase, "Full Name: cannot be blank; createdAt: cannot be blank; emailAddress: cannot be blank; userId: cannot be blank."},
+	}
+	for _, test := range tests {
+		ctx := WithOptions(context.Background(), WithFieldNameTransformer(test.transformer))
+		err := ValidateStructWithContext(ctx, &v, fields()...)
+		assert.EqualError(t, err, test.expected, test.tag)
+	}
+
+	opts := GetOptions(WithOptions(context.Background(), WithFieldNameTransformer(SnakeCase)))
+	sf, _ := reflect.TypeOf(v).FieldByName("UserID")
+	assert.Equal(t, "user_id", opts.GetErrorFieldNameFunc()(&sf))
+
+	// the fields are matched by their names before the transformation
+	ctx := WithOptions(context.Background(), WithFieldNameTransformer(SnakeCase), WithPartial(map[string]bool{"UserID": true}))
+	err := ValidateStructWithContext(ctx, &v, Field(&v.UserID, Required), Field(&v.CreatedAt, Required))
+	assert.EqualError(t, err, "user_id: cannot be blank.")
+
+	ctx = WithOptions(context.Background(), WithFieldNameTransformer(SnakeCase),
+		WithRejectUnknownFields(map[string]bool{"UserID": true, "Extra": true}))
+	err = ValidateStructWithContext(ctx, &v, Field(&v.UserID))
+	assert.EqualError(t, err, "_unknown: (Extra: key not expected.).")
+}
+
 func TestErrorFieldName(t *testing.T) {
 	type args struct {
 		structPtr interface{}
@@ -320,3 +403,571 @@ func TestErrorFieldName(t *testing.T) {
 		})
 	}
 }
+
+func TestParentAndFieldPath(t *testing.T) {
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type Account struct {
+		Password        string
+		ConfirmPassword string  `json:"confirm_password"`
+		Address         Address `json:"address"`
+	}
+
+	var (
+		gotParent interface{}
+		gotPath   ErrorPath
+	)
+	capture := By(func(ctx context.Context, value interface{}) error {
+		gotParent, _ = Parent(ctx)
+		gotPath = FieldPath(ctx)
+		return nil
+	})
+	confirm := By(func(ctx context.Context, value interface{}) error {
+		parent, ok := Parent(ctx)
+		if !ok {
+			return errors.New("no parent")
+		}
+		if parent.(*Account).Password != value.(string) {
+			return errors.New("passwords do not match")
+		}
+		return nil
+	})
+
+	a := Account{Password: "secret", ConfirmPassword: "secret"}
+	assert.Nil(t, ValidateStruct(&a, Field(&a.ConfirmPassword, capture, confirm)))
+	assert.Same(t, &a, gotParent)
+	assert.Equal(t, ErrorPath{"confirm_password"}, gotPath)
+
+	assert.Nil(t, ValidateStruct(&a, FieldStruct(&a.Address, Field(&a.Address.Street, capture))))
+	assert.Same(t, &a.Address, gotParent)
+	assert.Equal(t, ErrorPath{"address", "street"}, gotPath)
+	assert.Equal(t, "address.street", gotPath.String())
+
+	a.ConfirmPassword = "other"
+	err := ValidateStruct(&a, Field(&a.ConfirmPassword, confirm))
+	assert.EqualError(t, err, "confirm_password: passwords do not match.")
+
+	_, ok := Parent(context.Background())
+	assert.False(t, ok)
+	assert.Empty(t, FieldPath(context.Background()))
+	assert.Empty(t, FieldPath(nil))
+}
+
+func TestValidateStructParallel(t *testing.T) {
+	m1 := Model1{A: "abc", B: "xyz", c: "abc", G: "xyz"}
+	m2 := Model2{Model3: Model3{A: "internal"}}
+	m3 := Model2{}
+	var m4 *Model1
+
+	tests := []struct {
+		tag   string
+		model interface{}
+		rules []FieldRules
+		err   string
+	}{
+		{"t1.1", &m1, []FieldRules{Field(&m1.A, &validateAbc{}), Field(&m1.B, &validateXyz{})}, ""},
+		{"t1.2", &m1, []FieldRules{Field(&m1.A, &validateXyz{}), Field(&m1.B, &validateAbc{}), Field(&m1.G, &validateAbc{})}, "A: error xyz; B: error abc; g: error abc."},
+		{"t1.3", &m3, []FieldRules{Field(&m3.Model3), Field(&m3.M3)}, "A: error abc; M3: (A: error abc.)."},
+		{"t2.1", &m2, []FieldRules{Field(&m2.A, &validateAbc{}), Field(&m2.B, Required), Field(&m2.A, &validateInternalError{})}, "error internal"},
+		{"t2.2", m1, []FieldRules{}, ErrStructPointer.Error()},
+		{"t2.3", m4, []FieldRules{Field(&m1.A, Required)}, ""},
+		{"t2.4", m1, []FieldRules{NamedField("A", &validateXyz{}), NamedField("B", &validateXyz{})}, "A: error xyz."},
+	}
+
+	for _, workers := range []int{0, 1, 3} {
+		ctx := WithOptions(context.Background(), WithMaxWorkers(workers))
+		for _, test := range tests {
+			err := ValidateStructParallel(ctx, test.model, test.rules...)
+			assertError(t, test.err, err, fmt.Sprintf("%v (workers=%v)", test.tag, workers))
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := ValidateStructParallel(ctx, &m1, Field(&m1.A, Required))
+	if assert.NotNil(t, err) {
+		_, ok := err.(InternalError)
+		assert.True(t, ok)
+	}
+}
+
+type shape interface {
+	Area() float64
+}
+
+type circle struct {
+	Radius float64
+}
+
+func (c *circle) Area() float64 { return c.Radius * c.Radius * 3.14 }
+
+func (c *circle) Validate(ctx context.Context) error {
+	return ValidateStructWithContext(ctx, c, Field(&c.Radius, Required))
+}
+
+type square struct {
+	Side float64
+}
+
+func (s square) Area() float64 { return s.Side * s.Side }
+
+type drawing struct {
+	Shape shape
+	Extra interface{}
+}
+
+func TestValidateStructInterfaceField(t *testing.T) {
+	squareSchema := NewSchema(Spec("Side", Required))
+	ctx := WithOptions(context.Background(), WithSchema(&square{}, squareSchema))
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		value drawing
+		err   string
+	}{
+		{"t1", context.Background(), drawing{}, ""},
+		{"t2", context.Background(), drawing{Shape: &circle{Radius: 1}}, ""},
+		{"t3", context.Background(), drawing{Shape: &circle{}}, "Shape: (Radius: cannot be blank.)."},
+		{"t4", context.Background(), drawing{Shape: (*circle)(nil)}, ""},
+		{"t5", context.Background(), drawing{Shape: square{}}, ""},
+		{"t6", ctx, drawing{Shape: square{}}, "Shape: (Side: cannot be blank.)."},
+		{"t7", ctx, drawing{Shape: &square{Side: 1}}, ""},
+		{"t8", ctx, drawing{Shape: &square{}, Extra: &circle{}}, "Extra: (Radius: cannot be blank.); Shape: (Side: cannot be blank.)."},
+		{"t9", ctx, drawing{Extra: 1}, ""},
+	}
+	for _, test := range tests {
+		d := test.value
+		err := ValidateStructWithContext(test.ctx, &d,
+			Field(&d.Shape),
+			NamedField("Extra"),
+		)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// rules of the interface field are applied before the registered schema
+	d := drawing{Shape: &square{}}
+	err := ValidateStructWithContext(ctx, &d, Field(&d.Shape, Skip))
+	assert.NoError(t, err)
+
+	// a schema for a non-struct type is ignored
+	ctx = WithOptions(ctx, WithSchema(1, squareSchema), WithSchema(nil, squareSchema), WithSchema(&circle{}, nil))
+	d = drawing{Shape: &circle{}}
+	err = ValidateStructWithContext(ctx, &d, Field(&d.Shape))
+	assertError(t, "Shape: (Radius: cannot be blank.).", err, "t10")
+}
+
+type partialAddress struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+func (a partialAddress) Validate(ctx context.Context) error {
+	return ValidateStructWithContext(ctx, &a,
+		Field(&a.Street, Required),
+		Field(&a.City, Required),
+	)
+}
+
+type partialUser struct {
+	Name      string           `json:"name"`
+	Email     string           `json:"email"`
+	Address   partialAddress   `json:"address"`
+	Addresses []partialAddress `json:"addresses"`
+}
+
+func TestWithPartial(t *testing.T) {
+	validate := func(ctx context.Context, u *partialUser) error {
+		return ValidateStructWithContext(ctx, u,
+			Field(&u.Name, Required),
+			Field(&u.Email, Required, Length(3, 10)),
+			Field(&u.Address),
+			Field(&u.Addresses),
+		)
+	}
+
+	tests := []struct {
+		tag     string
+		present map[string]bool
+		value   partialUser
+		err     string
+	}{
+		{"t1", nil, partialUser{}, ""},
+		{"t2", map[string]bool{"name": true}, partialUser{}, "name: cannot be blank."},
+		{"t3", map[string]bool{"email": true}, partialUser{Email: "ab"}, "email: the length must be between 3 and 10."},
+		{"t4", map[string]bool{"name": true, "email": true}, partialUser{Name: "a", Email: "abc"}, ""},
+		{"t5", map[string]bool{"address": true}, partialUser{}, ""},
+		{"t6", map[string]bool{"address": true, "address.city": true}, partialUser{}, "address: (city: cannot be blank.)."},
+		{"t7", map[string]bool{"addresses": true, "addresses.1": true, "addresses.1.street": true},
+			partialUser{Addresses: []partialAddress{{}, {}}}, "addresses: (1: (street: cannot be blank.).)."},
+	}
+	for _, test := range tests {
+		u := test.value
+		ctx := WithOptions(context.Background(), WithPartial(test.present))
+		err := validate(ctx, &u)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// all fields are validated without the partial mode
+	u := partialUser{}
+	err := validate(context.Background(), &u)
+	assertError(t, "address: (city: cannot be blank; street: cannot be blank.); email: cannot be blank; name: cannot be blank.", err, "t8")
+}
+
+func TestWithRejectUnknownFields(t *testing.T) {
+	type base struct {
+		ID string `json:"id"`
+	}
+	type user struct {
+		base
+		partialUser
+		Nickname string `json:"nickname"`
+	}
+	validate := func(ctx context.Context, u *user) error {
+		return ValidateStructWithContext(ctx, u,
+			Field(&u.base),
+			FieldStruct(&u.partialUser,
+				Field(&u.Name, Required),
+				Field(&u.Email, Required),
+				Field(&u.Address),
+				Field(&u.Addresses),
+			),
+			Field(&u.Nickname).Key("nick"),
+			StructRule(func(context.Context, *user) error { return nil }),
+		)
+	}
+	valid := partialUser{Name: "a", Email: "abc", Address: partialAddress{Street: "s", City: "c"}}
+
+	tests := []struct {
+		tag     string
+		present map[string]bool
+		value   partialUser
+		err     string
+	}{
+		{"t1", map[string]bool{}, valid, ""},
+		{"t2", map[string]bool{"id": true, "name": true, "nick": true, "address": true, "address.city": true}, valid, ""},
+		{"t3", map[string]bool{"nickname": true, "extra": true}, valid, "_unknown: (extra: key not expected; nickname: key not expected.)."},
+		{"t4", map[string]bool{"address": true, "address.zip": true, "addresses.0.zip": true}, valid, "address: (_unknown: (zip: key not expected.).)."},
+		{"t5", map[string]bool{"extra": true}, partialUser{}, "_unknown: (extra: key not expected.); address: (city: cannot be blank; street: cannot be blank.); email: cannot be blank; name: cannot be blank."},
+	}
+	for _, test := range tests {
+		u := user{partialUser: test.value}
+		ctx := WithOptions(context.Background(), WithRejectUnknownFields(test.present))
+		err := validate(ctx, &u)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	u := user{partialUser: valid}
+	u.Addresses = []partialAddress{{Street: "s", City: "c"}}
+	ctx := WithOptions(context.Background(),
+		WithRejectUnknownFields(map[string]bool{"addresses.0.zip": true}),
+		WithUnknownErrorKey("$unknown"),
+	)
+	err := ValidateStructParallel(ctx, &u, Field(&u.Addresses))
+	assertError(t, "addresses: (0: ($unknown: (zip: key not expected.).).).", err, "t6")
+}
+
+func TestFieldPath_Elements(t *testing.T) {
+	var paths []string
+	record := By(func(ctx context.Context, value interface{}) error {
+		paths = append(paths, FieldPath(ctx).String())
+		return nil
+	})
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	type order struct {
+		Tags  []string          `json:"tags"`
+		Items []item            `json:"items"`
+		Attrs map[string]string `json:"attrs"`
+	}
+	o := order{Tags: []string{"a"}, Items: []item{{}, {}}, Attrs: map[string]string{"k": "v"}}
+	err := ValidateStructWithContext(context.Background(), &o,
+		Field(&o.Tags, Each(record)),
+		Field(&o.Items, Dive(NamedField("SKU", record))),
+		Field(&o.Attrs, Each(record)),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tags.0", "items.0.sku", "items.1.sku", "attrs.k"}, paths)
+}
+
+func TestElementKey(t *testing.T) {
+	var keys []interface{}
+	record := By(func(ctx context.Context, value interface{}) error {
+		key, ok := ElementKey(ctx)
+		if !ok {
+			key = "none"
+		}
+		keys = append(keys, key)
+		return nil
+	})
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	type order struct {
+		ID    string         `json:"id"`
+		Tags  []string       `json:"tags"`
+		Items []item         `json:"items"`
+		Qty   map[int]string `json:"qty"`
+	}
+	o := order{Tags: []string{"a", "b"}, Items: []item{{}}, Qty: map[int]string{7: "v"}}
+	err := ValidateStructWithContext(context.Background(), &o,
+		Field(&o.ID, record),
+		Field(&o.Tags, Each(record)),
+		Field(&o.Items, Dive(NamedField("SKU", record))),
+		Field(&o.Qty, Each(record)),
+		NamedField("Items[*].SKU", record),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"none", 0, 1, 0, 7, 0}, keys)
+
+	_, ok := ElementKey(context.Background())
+	assert.False(t, ok)
+
+	// the first element is special-cased
+	first := By(func(ctx context.Context, value interface{}) error {
+		if key, _ := ElementKey(ctx); key == 0 && value != "header" {
+			return errors.New("the first line must be the header")
+		}
+		return nil
+	})
+	err = Validate([]string{"row", "header"}, Each(first))
+	assert.EqualError(t, err, "0: the first line must be the header.")
+}
+
+func TestWithErrorReporter(t *testing.T) {
+	type address struct {
+		Street string `json:"street"`
+		Zip    string `json:"zip"`
+	}
+	type user struct {
+		Name    string   `json:"name"`
+		Address address  `json:"address"`
+		Tags    []string `json:"tags"`
+		Other   *address `json:"other"`
+	}
+	u := user{Address: address{Zip: "1"}, Tags: []string{"ok", ""}}
+	rules := func(u *user) []FieldRules {
+		return []FieldRules{
+			Field(&u.Name, Required),
+			FieldStruct(&u.Address,
+				Field(&u.Address.Street, Required),
+				Field(&u.Address.Zip, By(func(context.Context, interface{}) error { return errors.New("invalid zip") })),
+			),
+			Field(&u.Tags, Each(Required)),
+		}
+	}
+
+	for _, validate := range []func(context.Context, interface{}, ...FieldRules) error{ValidateStructWithContext, ValidateStructParallel} {
+		reported := map[string]string{}
+		ctx := WithOptions(context.Background(), WithErrorReporter(func(path string, err Error) {
+			reported[path] = err.Code()
+		}))
+		err := validate(ctx, &u, rules(&u)...)
+		assert.Error(t, err)
+		assert.Equal(t, map[string]string{
+			"name":           "validation_required",
+			"address.street": "validation_required",
+			"address.zip":    "",
+			"tags.1":         "validation_required",
+		}, reported)
+	}
+
+	// internal errors are not reported
+	reported := 0
+	ctx := WithOptions(context.Background(), WithErrorReporter(func(string, Error) { reported++ }))
+	err := ValidateStructWithContext(ctx, &u,
+		Field(&u.Name, Required),
+		Field(&u.Tags, By(func(context.Context, interface{}) error { return NewInternalError(errors.New("boom")) })),
+	)
+	assert.Error(t, err)
+	assert.Equal(t, 0, reported)
+}
+
+type treeNode struct {
+	Name     string      `json:"name"`
+	Next     *treeNode   `json:"next"`
+	Children []*treeNode `json:"children"`
+}
+
+func (n *treeNode) Validate(ctx context.Context) error {
+	return ValidateStructWithContext(ctx, n,
+		Field(&n.Name, Required),
+		Field(&n.Next),
+		Field(&n.Children),
+	)
+}
+
+// valueTreeNode validates a copy of itself, like the Validate methods with value receivers in the README.
+type valueTreeNode struct {
+	Name     string           `json:"name"`
+	Next     *valueTreeNode   `json:"next"`
+	Children []*valueTreeNode `json:"children"`
+}
+
+func (n valueTreeNode) Validate(ctx context.Context) error {
+	return ValidateStructWithContext(ctx, &n,
+		Field(&n.Name, Required),
+		Field(&n.Next),
+		Field(&n.Children),
+	)
+}
+
+func TestValidateStruct_Nesting(t *testing.T) {
+	self := &treeNode{Name: "a"}
+	self.Next = self
+	a, b := &treeNode{Name: "a"}, &treeNode{Name: "b"}
+	a.Children = []*treeNode{b}
+	b.Next = a
+	tree := &treeNode{Name: "a", Children: []*treeNode{{Name: "b", Children: []*treeNode{{}}}}}
+	shared := &treeNode{Name: "s"}
+
+	tests := []struct {
+		tag      string
+		opts     []Option
+		value    *treeNode
+		err      string
+		internal error
+	}{
+		{"t1", nil, tree, "children: (0: (children: (0: (name: cannot be blank.).).).).", nil},
+		{"t2", nil, self, "next: reference cycle detected", ErrCycle},
+		{"t3", nil, a, "children.0.next: reference cycle detected", ErrCycle},
+		{"t4", nil, &treeNode{Name: "a", Next: shared, Children: []*treeNode{shared}}, "", nil},
+		{"t5", []Option{WithMaxDepth(4)}, tree, "children: (0: (children: (0: (name: cannot be blank.).).).).", nil},
+		{"t6", []Option{WithMaxDepth(3)}, tree, "children.0.children.0: maximum depth exceeded", ErrMaxDepth},
+		{"t7", []Option{WithDeepValidation(true)}, self, "next: reference cycle detected", ErrCycle},
+	}
+	for _, test := range tests {
+		ctx := WithOptions(context.Background(), test.opts...)
+		err := ValidateWithContext(ctx, test.value)
+		if test.internal == nil {
+			assertError(t, test.err, err, test.tag)
+			continue
+		}
+		if assert.Error(t, err, test.tag) {
+			assert.Equal(t, test.err, err.Error(), test.tag)
+			assert.ErrorIs(t, err, test.internal, test.tag)
+			_, ok := err.(InternalError)
+			assert.True(t, ok, test.tag)
+		}
+	}
+
+	type deepNode struct {
+		Name string
+		Next *deepNode
+	}
+	dn := &deepNode{Name: "a"}
+	dn.Next = &deepNode{Next: dn}
+	err := ValidateWithContext(WithOptions(context.Background(), WithDeepValidation(true)), dn)
+	assert.ErrorIs(t, err, ErrCycle)
+	assert.Equal(t, "Next.Next: reference cycle detected", err.Error())
+
+	// the cycles are detected when the Validate methods with value receivers validate copies of the structs
+	vself := &valueTreeNode{Name: "a"}
+	vself.Next = vself
+	va, vb := &valueTreeNode{Name: "a"}, &valueTreeNode{Name: "b"}
+	va.Children = []*valueTreeNode{vb}
+	vb.Next = va
+	vshared := &valueTreeNode{Name: "s"}
+	valueTests := []struct {
+		tag   string
+		value *valueTreeNode
+		err   string
+	}{
+		{"v1", vself, "next.next: reference cycle detected"},
+		{"v2", va, "children.0.next.children.0.next: reference cycle detected"},
+		{"v3", &valueTreeNode{Name: "a", Next: vshared, Children: []*valueTreeNode{vshared}}, ""},
+		{"v4", &valueTreeNode{Name: "a", Next: &valueTreeNode{}}, "next: (name: cannot be blank.)."},
+	}
+	for _, test := range valueTests {
+		err := Validate(test.value)
+		if test.err == "" || !strings.Contains(test.err, "cycle") {
+			assertError(t, test.err, err, test.tag)
+			continue
+		}
+		if assert.Error(t, err, test.tag) {
+			assert.Equal(t, test.err, err.Error(), test.tag)
+			assert.ErrorIs(t, err, ErrCycle, test.tag)
+		}
+	}
+}
+
+func TestValidateStruct_Value(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	u := user{Name: "  "}
+	err := ValidateStruct(u, NamedField("Name", Trim, Required))
+	assertError(t, "name: cannot be blank.", err, "t1")
+	// the struct value is validated read-only
+	assert.Equal(t, "  ", u.Name)
+
+	u = user{Name: " abc "}
+	err = ValidateStruct(u, NamedField("Name", Trim, Length(1, 3)))
+	assert.NoError(t, err)
+	assert.Equal(t, " abc ", u.Name)
+}
+
+func TestWithNilStructError(t *testing.T) {
+	type address struct {
+		Street string
+	}
+	type user struct {
+		Name    string
+		Address *address
+	}
+	var (
+		nilUser *user
+		u       = user{Name: "abc"}
+	)
+	ctx := WithOptions(context.Background(), WithNilStructError(true))
+
+	tests := []struct {
+		tag string
+		err error
+		out string
+	}{
+		{"t1", ValidateStructWithContext(context.Background(), nilUser, NamedField("Name", Required)), ""},
+		{"t2", ValidateStructWithContext(ctx, nilUser, NamedField("Name", Required)), "is required"},
+		{"t3", ValidateStructParallel(ctx, nilUser, NamedField("Name", Required)), "is required"},
+		{"t4", ValidateStructWithContext(ctx, &u, Field(&u.Name, Required), Field(&u.Address)), ""},
+		{"t5", ValidateStructWithContext(ctx, &u, Field(&u.Address, NotNil)), "Address: is required."},
+		{"t6", NewSchema(Spec("Name", Required)).Validate(ctx, nilUser), "is required"},
+	}
+	for _, test := range tests {
+		assertError(t, test.out, test.err, test.tag)
+	}
+	assert.True(t, errors.Is(tests[1].err, ErrNotNilRequired))
+}
+
+func TestValidateStructOpts(t *testing.T) {
+	type user struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	upperName := func(f *reflect.StructField) string { return strings.ToUpper(f.Name) }
+	u := user{Email: " "}
+
+	tests := []struct {
+		tag  string
+		ctx  context.Context
+		opts []Option
+		err  string
+	}{
+		{"t1", context.Background(), nil, "name: cannot be blank."},
+		{"t2", context.Background(), []Option{WithGetErrorFieldNameFunc(upperName)}, "NAME: cannot be blank."},
+		{"t3", nil, []Option{WithTrimSpace(true)}, "email: cannot be blank; name: cannot be blank."},
+		{"t4", WithOptions(context.Background(), WithTrimSpace(true)), []Option{WithGetErrorFieldNameFunc(upperName)}, "EMAIL: cannot be blank; NAME: cannot be blank."},
+	}
+	for _, test := range tests {
+		err := ValidateStructOpts(test.ctx, &u, test.opts,
+			Field(&u.Name, Required),
+			Field(&u.Email, Required),
+		)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// the options apply to this validation only
+	assertError(t, "name: cannot be blank.", ValidateStruct(&u, Field(&u.Name, Required), Field(&u.Email, Required)), "t5")
+}
@@ -0,0 +1,175 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	_ Rule = (*SubsetOfRule[any])(nil)
+	_ Rule = (*ContainsAllRule[any])(nil)
+)
+
+var (
+	// ErrSubsetOfInvalid is the error that returns when an element of a slice or array is not one of the allowed values.
+	ErrSubsetOfInvalid = NewError("validation_subset_of_invalid", "must be a valid value")
+	// ErrContainsAllInvalid is the error that returns when a slice or array does not contain all the required values.
+	ErrContainsAllInvalid = NewError("validation_contains_all_invalid", "must contain {{.values}}")
+)
+
+// SubsetOf returns a validation rule that checks if every element of a slice or array can be found
+// in the given list of allowed values, e.g. to check that the requested scopes are within the granted ones.
+// Every element that is not allowed is reported under its index, e.g. "scopes: (1: must be a valid value.).".
+// Like with In(), reflect.DeepEqual() will be used to determine if two values are equal.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func SubsetOf[T any](allowed ...T) SubsetOfRule[T] {
+	return SubsetOfRule[T]{
+		elements: allowed,
+		err:      ErrSubsetOfInvalid,
+	}
+}
+
+// SubsetOfRule is a validation rule that checks if every element of a slice or array is one of the allowed values.
+type SubsetOfRule[T any] struct {
+	elements []T
+	err      Error
+}
+
+// Validate checks if every element of the given slice or array is one of the allowed values.
+func (r SubsetOfRule[T]) Validate(ctx context.Context, value interface{}) error {
+	v, isNil, err := iterableValue(value)
+	if isNil || err != nil {
+		return err
+	}
+
+	errs := Errors{}
+	for i := 0; i < v.Len(); i++ {
+		if !containsValue(r.elements, v.Index(i).Interface()) {
+			errs[strconv.Itoa(i)] = r.err
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r SubsetOfRule[T]) Error(message string) SubsetOfRule[T] {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r SubsetOfRule[T]) ErrorObject(err Error) SubsetOfRule[T] {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "subset_of", with the param "values" holding the allowed values as []interface{}.
+func (r SubsetOfRule[T]) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "subset_of",
+		Params: map[string]interface{}{"values": toInterfaces(r.elements)},
+	}
+}
+
+// ContainsAll returns a validation rule that checks if a slice or array contains all the given values,
+// e.g. to check that the granted scopes include a mandatory one. The missing values are reported in one error,
+// with the param "values" holding them joined by ", ", e.g. "must contain admin, read".
+// Like with In(), reflect.DeepEqual() will be used to determine if two values are equal.
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func ContainsAll[T any](required ...T) ContainsAllRule[T] {
+	return ContainsAllRule[T]{
+		elements: required,
+		err:      ErrContainsAllInvalid,
+	}
+}
+
+// ContainsAllRule is a validation rule that checks if a slice or array contains all the given values.
+type ContainsAllRule[T any] struct {
+	elements []T
+	err      Error
+}
+
+// Validate checks if the given slice or array contains all the required values.
+func (r ContainsAllRule[T]) Validate(ctx context.Context, value interface{}) error {
+	v, isNil, err := iterableValue(value)
+	if isNil || err != nil {
+		return err
+	}
+
+	elems := make([]interface{}, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+	var missing []string
+	for _, e := range r.elements {
+		if !containsValue(elems, e) {
+			missing = append(missing, fmt.Sprint(e))
+		}
+	}
+	if len(missing) > 0 {
+		return r.err.SetParams(map[string]interface{}{"values": strings.Join(missing, ", ")})
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r ContainsAllRule[T]) Error(message string) ContainsAllRule[T] {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r ContainsAllRule[T]) ErrorObject(err Error) ContainsAllRule[T] {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "contains_all", with the param "values" holding the required values as []interface{}.
+func (r ContainsAllRule[T]) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "contains_all",
+		Params: map[string]interface{}{"values": toInterfaces(r.elements)},
+	}
+}
+
+// iterableValue returns the slice or array that value holds or points to. True is returned
+// if the value is nil or empty, and an error if it is not a slice or an array.
+func iterableValue(value interface{}) (reflect.Value, bool, error) {
+	value, isNil := Indirect(value)
+	if isNil || IsEmpty(value) {
+		return reflect.Value{}, true, nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return reflect.Value{}, false, errors.New("must be a slice or an array")
+	}
+	return v, false, nil
+}
+
+// containsValue reports whether value is deeply equal to one of the elements.
+func containsValue[T any](elements []T, value interface{}) bool {
+	for _, e := range elements {
+		if reflect.DeepEqual(e, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// toInterfaces converts the elements to []interface{}.
+func toInterfaces[T any](elements []T) []interface{} {
+	values := make([]interface{}, len(elements))
+	for i, e := range elements {
+		values[i] = e
+	}
+	return values
+}
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubsetOf(t *testing.T) {
+	r := SubsetOf("read", "write", "admin")
+
+	tests := []struct {
+		tag   string
+		rule  Rule
+		value interface{}
+		err   string
+	}{
+		{"t1", r, nil, ""},
+		{"t2", r, []string{}, ""},
+		{"t3", r, []string{"read", "admin"}, ""},
+		{"t4", r, []string{"read", "delete", "root"}, "1: must be a valid value; 2: must be a valid value."},
+		{"t5", r, &[]string{"write", "write"}, ""},
+		{"t6", r, [2]string{"read", "x"}, "1: must be a valid value."},
+		{"t7", r, "read", "must be a slice or an array"},
+		{"t8", SubsetOf(1, 2), []int{1, 3}, "1: must be a valid value."},
+		{"t9", r.Error("is not granted"), []string{"x"}, "0: is not granted."},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	assert.Equal(t, RuleInfo{Kind: "subset_of", Params: map[string]interface{}{"values": []interface{}{"read", "write", "admin"}}}, r.Metadata())
+}
+
+func TestContainsAll(t *testing.T) {
+	r := ContainsAll("admin", "read")
+
+	tests := []struct {
+		tag   string
+		rule  Rule
+		value interface{}
+		err   string
+	}{
+		{"t1", r, nil, ""},
+		{"t2", r, []string{}, ""},
+		{"t3", r, []string{"read", "write", "admin"}, ""},
+		{"t4", r, []string{"read"}, "must contain admin"},
+		{"t5", r, []string{"write"}, "must contain admin, read"},
+		{"t6", r, &[1]string{"admin"}, "must contain read"},
+		{"t7", r, map[string]string{"a": "admin"}, "must be a slice or an array"},
+		{"t8", ContainsAll(1), []int{2}, "must contain 1"},
+		{"t9", r.Error("must include the mandatory scopes"), []string{"x"}, "must include the mandatory scopes"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := r.Validate(context.Background(), []string{"write"})
+	if e, ok := err.(Error); assert.True(t, ok) {
+		assert.Equal(t, ErrContainsAllInvalid.Code(), e.Code())
+		assert.Equal(t, map[string]interface{}{"values": "admin, read"}, e.Params())
+	}
+}
@@ -0,0 +1,171 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var _ Rule = (*TimeRule)(nil)
+
+var (
+	// ErrTimeInvalid is the error that returns when a string cannot be parsed into a time.
+	ErrTimeInvalid = NewError("validation_time_invalid", "must be a valid time")
+	// ErrTimeBeforeRequired is the error that returns when a time is not before a specified time.
+	ErrTimeBeforeRequired = NewError("validation_time_before_required", "must be before {{.time}}")
+	// ErrTimeAfterRequired is the error that returns when a time is not after a specified time.
+	ErrTimeAfterRequired = NewError("validation_time_after_required", "must be after {{.time}}")
+	// ErrTimeWithinRequired is the error that returns when a time is too far from a specified time.
+	ErrTimeWithinRequired = NewError("validation_time_within_required", "must be within {{.duration}} of {{.time}}")
+	// ErrTimeLocationRequired is the error that returns when a time is not in a specified time zone.
+	ErrTimeLocationRequired = NewError("validation_time_location_required", "must be in the {{.location}} time zone")
+)
+
+// TimeRule is a validation rule that checks a time.Time value or a time string.
+type TimeRule struct {
+	kind   string
+	ref    time.Time
+	now    bool
+	d      time.Duration
+	loc    *time.Location
+	layout string
+	err    Error
+}
+
+// BeforeTime returns a validation rule that checks if a time is strictly before t.
+// Times are compared as instants, so times in different time zones can be compared.
+// The value can be a time.Time or a string, which is parsed with the layout set by Layout (time.RFC3339 by default).
+// Call Now to compare with the current time at validation time instead of t, e.g. BeforeTime(time.Time{}).Now().
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func BeforeTime(t time.Time) TimeRule {
+	return TimeRule{kind: "before", ref: t, err: ErrTimeBeforeRequired}
+}
+
+// AfterTime returns a validation rule that checks if a time is strictly after t.
+// The value is handled in the same way as BeforeTime.
+func AfterTime(t time.Time) TimeRule {
+	return TimeRule{kind: "after", ref: t, err: ErrTimeAfterRequired}
+}
+
+// WithinDuration returns a validation rule that checks if a time is within d of ref, in either direction.
+// For example, WithinDuration(time.Time{}, 5*time.Minute).Now() checks that a client timestamp is not skewed
+// by more than five minutes. The value is handled in the same way as BeforeTime.
+func WithinDuration(ref time.Time, d time.Duration) TimeRule {
+	return TimeRule{kind: "within", ref: ref, d: d, err: ErrTimeWithinRequired}
+}
+
+// InLocation returns a validation rule that checks if a time is in the time zone loc,
+// i.e. the UTC offset of the time is the offset of loc at that instant.
+// The value is handled in the same way as BeforeTime.
+func InLocation(loc *time.Location) TimeRule {
+	return TimeRule{kind: "location", loc: loc, err: ErrTimeLocationRequired}
+}
+
+// Now sets the rule to use the current time, resolved at validation time, as the reference time.
+// It has no effect on InLocation.
+func (r TimeRule) Now() TimeRule {
+	r.now = true
+	return r
+}
+
+// Layout sets the layout used to parse string values, which accepts the same value as that for time.Parse.
+// The layout is also used to format the reference time in the error message.
+func (r TimeRule) Layout(layout string) TimeRule {
+	r.layout = layout
+	return r
+}
+
+// Error sets the error message for the rule.
+func (r TimeRule) Error(message string) TimeRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r TimeRule) ErrorObject(err Error) TimeRule {
+	r.err = err
+	return r
+}
+
+// Validate checks if the given time is valid.
+func (r TimeRule) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	t, ok := value.(time.Time)
+	if !ok {
+		str, err := stringWithOptions(value, opts)
+		if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+			return err
+		} else if err != nil {
+			return errors.New("must be either a time.Time or a string")
+		}
+		if t, err = time.Parse(r.getLayout(), str); err != nil {
+			return ErrTimeInvalid
+		}
+	}
+
+	ref := r.ref
+	if r.now {
+		ref = time.Now()
+	}
+
+	switch r.kind {
+	case "before":
+		if t.Before(ref) {
+			return nil
+		}
+	case "after":
+		if t.After(ref) {
+			return nil
+		}
+	case "within":
+		if diff := t.Sub(ref); diff >= -r.d && diff <= r.d {
+			return nil
+		}
+		return r.err.SetParams(map[string]interface{}{"time": ref.Format(r.getLayout()), "duration": r.d.String()})
+	default:
+		_, offset := t.Zone()
+		if _, locOffset := t.In(r.loc).Zone(); offset == locOffset {
+			return nil
+		}
+		return r.err.SetParams(map[string]interface{}{"location": r.loc.String()})
+	}
+	return r.err.SetParams(map[string]interface{}{"time": ref.Format(r.getLayout())})
+}
+
+func (r TimeRule) getLayout() string {
+	if r.layout == "" {
+		return time.RFC3339
+	}
+	return r.layout
+}
+
+// Metadata returns the description of the rule.
+// The kind is "before" or "after" with the param "time", "within" with the params "time" and "duration",
+// or "location" with the param "location" holding the name of the time zone. If the reference time is
+// the current time, the param "time" is omitted and the param "now" is true. The param "layout" is set if
+// a layout is set by Layout.
+func (r TimeRule) Metadata() RuleInfo {
+	params := map[string]interface{}{}
+	switch r.kind {
+	case "location":
+		params["location"] = r.loc.String()
+	case "within":
+		params["duration"] = r.d
+		fallthrough
+	default:
+		if r.now {
+			params["now"] = true
+		} else {
+			params["time"] = r.ref
+		}
+	}
+	if r.layout != "" {
+		params["layout"] = r.layout
+	}
+	return RuleInfo{Kind: r.kind, Params: params}
+}
@@ -0,0 +1,81 @@
+package validation
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRules(t *testing.T) {
+	ref := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	paris, err := time.LoadLocation("Europe/Paris")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var nilTime *time.Time
+	later := ref.Add(time.Hour)
+
+	tests := []struct {
+		tag   string
+		rule  TimeRule
+		value interface{}
+		err   string
+	}{
+		{"t1", BeforeTime(ref), ref.Add(-time.Second), ""},
+		{"t2", BeforeTime(ref), ref, "must be before 2024-03-01T12:00:00Z"},
+		{"t3", BeforeTime(ref), time.Time{}, ""},
+		{"t4", BeforeTime(ref), nilTime, ""},
+		{"t5", BeforeTime(ref), &later, "must be before 2024-03-01T12:00:00Z"},
+		{"t6", BeforeTime(ref), ref.In(paris).Add(-time.Minute), ""},
+		{"t7", AfterTime(ref), later, ""},
+		{"t8", AfterTime(ref), ref, "must be after 2024-03-01T12:00:00Z"},
+		{"t9", AfterTime(ref), "2024-03-01T12:30:00+01:00", "must be after 2024-03-01T12:00:00Z"},
+		{"t10", AfterTime(ref), "2024-03-01T13:30:00Z", ""},
+		{"t11", AfterTime(ref).Layout("2006-01-02"), "2024-03-02", ""},
+		{"t12", AfterTime(ref).Layout("2006-01-02"), "2024-03-01", "must be after 2024-03-01"},
+		{"t13", AfterTime(ref), "03/02/2024", "must be a valid time"},
+		{"t14", AfterTime(ref), "", ""},
+		{"t15", AfterTime(ref), sql.NullTime{Time: later, Valid: true}, ""},
+		{"t16", AfterTime(ref), 123, "must be either a time.Time or a string"},
+		{"t17", WithinDuration(ref, time.Hour), later, ""},
+		{"t18", WithinDuration(ref, time.Hour), ref.Add(-time.Hour), ""},
+		{"t19", WithinDuration(ref, time.Hour), later.Add(time.Second), "must be within 1h0m0s of 2024-03-01T12:00:00Z"},
+		{"t20", InLocation(paris), ref.In(paris), ""},
+		{"t21", InLocation(paris), ref, "must be in the Europe/Paris time zone"},
+		{"t22", InLocation(paris), "2024-03-01T13:00:00+01:00", ""},
+		{"t23", InLocation(paris), "2024-07-01T13:00:00+01:00", "must be in the Europe/Paris time zone"},
+		{"t24", InLocation(time.UTC), ref, ""},
+		{"t25", BeforeTime(time.Time{}).Now(), time.Now().Add(-time.Minute), ""},
+		{"t27", AfterTime(time.Time{}).Now(), time.Now().Add(time.Minute), ""},
+		{"t28", WithinDuration(time.Time{}, time.Minute).Now(), time.Now().Add(-time.Second), ""},
+		{"t30", BeforeTime(ref).Error("too late"), later, "too late"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	// the current time is in the error message
+	err = BeforeTime(time.Time{}).Now().Validate(context.Background(), time.Now().Add(time.Minute))
+	assert.ErrorIs(t, err, ErrTimeBeforeRequired)
+	err = WithinDuration(time.Time{}, time.Minute).Now().Validate(context.Background(), time.Now().Add(-time.Hour))
+	assert.ErrorIs(t, err, ErrTimeWithinRequired)
+
+	e := NewError("code", "abc")
+	r := BeforeTime(ref).ErrorObject(e)
+	assert.True(t, errors.Is(r.Validate(context.Background(), later), e))
+}
+
+func TestTimeRule_Metadata(t *testing.T) {
+	ref := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, RuleInfo{Kind: "before", Params: map[string]interface{}{"time": ref}}, BeforeTime(ref).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "after", Params: map[string]interface{}{"now": true, "layout": "2006-01-02"}},
+		AfterTime(time.Time{}).Now().Layout("2006-01-02").Metadata())
+	assert.Equal(t, RuleInfo{Kind: "within", Params: map[string]interface{}{"time": ref, "duration": time.Hour}},
+		WithinDuration(ref, time.Hour).Metadata())
+	assert.Equal(t, RuleInfo{Kind: "location", Params: map[string]interface{}{"location": "UTC"}}, InLocation(time.UTC).Metadata())
+}
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var _ Rule = (*TimeoutRule)(nil)
+
+// ErrRuleTimeout is the error that returns when a rule does not finish within its timeout.
+var ErrRuleTimeout = NewError("validation_rule_timeout", "the validation timed out")
+
+// TimeoutRule is a validation rule that runs another rule with a deadline.
+type TimeoutRule struct {
+	timeout  time.Duration
+	rule     Rule
+	err      Error
+	internal bool
+}
+
+// Timeout returns a validation rule that runs the given rule with a context whose deadline is d from now.
+// If the rule does not return before the deadline, or returns an error wrapping context.DeadlineExceeded,
+// the ErrRuleTimeout validation error is returned. Call Internal() to report an InternalError instead.
+// If the parent context is canceled, the cancellation is returned as an InternalError.
+//
+// The rule keeps running in the background after the deadline is exceeded,
+// so it should honor the cancellation of the context it receives.
+// A non-positive d means no timeout.
+func Timeout(d time.Duration, rule Rule) TimeoutRule {
+	return TimeoutRule{
+		timeout: d,
+		rule:    rule,
+		err:     ErrRuleTimeout,
+	}
+}
+
+// Error sets the error message that is used when the rule times out.
+func (r TimeoutRule) Error(message string) TimeoutRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct that is used when the rule times out.
+func (r TimeoutRule) ErrorObject(err Error) TimeoutRule {
+	r.err = err
+	return r
+}
+
+// Internal configures the rule to return an InternalError wrapping context.DeadlineExceeded
+// instead of a validation error when the rule times out.
+func (r TimeoutRule) Internal() TimeoutRule {
+	r.internal = true
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "timeout", with the params "timeout" and "rules" holding the wrapped rule as []Rule.
+func (r TimeoutRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "timeout",
+		Params: map[string]interface{}{"timeout": r.timeout, "rules": []Rule{r.rule}},
+	}
+}
+
+// Validate runs the wrapped rule and checks if it finishes in time.
+func (r TimeoutRule) Validate(ctx context.Context, value interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if r.timeout <= 0 {
+		return r.rule.Validate(ctx, value)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.rule.Validate(ctx, value)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+		if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		return NewInternalError(err)
+	}
+	if r.internal {
+		return NewInternalError(err)
+	}
+	return r.err.SetParams(map[string]interface{}{"timeout": r.timeout})
+}
+
+// withRuleTimeout wraps rule into a TimeoutRule unless the rule only delegates to other rules,
+// which are subject to the timeout themselves.
+func withRuleTimeout(rule Rule, d time.Duration) Rule {
+	switch rule.(type) {
+	case TimeoutRule, EachRule, DiveRule, MapRule, WhenRule, CoerceRule, AndRule, OrRule, NotRule, pathRule, *structFieldsRule, *Schema:
+		return rule
+	}
+	return Timeout(d, rule)
+}
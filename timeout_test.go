@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sleepRule(d time.Duration) Rule {
+	return By(func(ctx context.Context, value interface{}) error {
+		select {
+		case <-time.After(d):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		tag      string
+		rule     TimeoutRule
+		err      string
+		internal bool
+	}{
+		{"t1", Timeout(time.Second, sleepRule(0)), "", false},
+		{"t2", Timeout(0, sleepRule(time.Millisecond)), "", false},
+		{"t3", Timeout(time.Millisecond, sleepRule(time.Second)), "the validation timed out", false},
+		{"t4", Timeout(time.Millisecond, sleepRule(time.Second)).Error("too slow"), "too slow", false},
+		{"t5", Timeout(time.Millisecond, sleepRule(time.Second)).Internal(), context.DeadlineExceeded.Error(), true},
+		{"t6", Timeout(time.Second, &validateAbc{}), "error abc", false},
+		{"t7", Timeout(time.Second, By(func(context.Context, interface{}) error {
+			return context.DeadlineExceeded
+		})), "the validation timed out", false},
+	}
+
+	for _, test := range tests {
+		err := ValidateWithContext(context.Background(), "xyz", test.rule)
+		assertError(t, test.err, err, test.tag)
+		_, isInternal := err.(InternalError)
+		assert.Equal(t, test.internal, isInternal, test.tag)
+	}
+
+	err := Timeout(time.Millisecond, sleepRule(time.Second)).ErrorObject(NewError("code", "slow")).Validate(context.Background(), "xyz")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "code", err.(Error).Code())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Timeout(time.Second, sleepRule(time.Second)).Validate(ctx, "xyz")
+	if assert.NotNil(t, err) {
+		_, ok := err.(InternalError)
+		assert.True(t, ok)
+		assert.True(t, errors.Is(err.(InternalError).InternalError(), context.Canceled))
+	}
+}
+
+func TestWithRuleTimeout(t *testing.T) {
+	ctx := WithOptions(context.Background(), WithRuleTimeout(50*time.Millisecond))
+	assert.Equal(t, 50*time.Millisecond, GetOptions(ctx).RuleTimeout())
+
+	err := ValidateWithContext(ctx, "xyz", sleepRule(time.Second))
+	assert.EqualError(t, err, "the validation timed out")
+
+	err = ValidateWithContext(ctx, []string{"a", "b"}, Each(sleepRule(time.Second)))
+	assert.EqualError(t, err, "0: the validation timed out; 1: the validation timed out.")
+
+	// nested struct rules are not limited as a whole
+	s := struct {
+		A struct {
+			B, C string
+		}
+	}{}
+	err = ValidateStructWithContext(ctx, &s, FieldStruct(&s.A,
+		Field(&s.A.B, sleepRule(30*time.Millisecond)),
+		Field(&s.A.C, sleepRule(30*time.Millisecond)),
+	))
+	assert.Nil(t, err)
+}
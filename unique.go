@@ -0,0 +1,91 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var _ Rule = (*UniqueRule)(nil)
+
+// ErrUniqueInvalid is the error that returns when an element of a slice or array duplicates a previous element.
+var ErrUniqueInvalid = NewError("validation_unique_invalid", "must be unique")
+
+// Unique returns a validation rule that checks if the elements of a slice or array are distinct.
+// Every element that equals a previous element is reported under its index, with the param "index" holding
+// the index of the first equal element, e.g. "tags: (2: must be unique.)." for []string{"a", "b", "a"}.
+// The elements are compared with ==, so they must be of comparable types, and pointers are compared by address.
+// Use UniqueBy to compare the elements by a key instead. An empty value is considered valid.
+func Unique() UniqueRule {
+	return UniqueRule{err: ErrUniqueInvalid}
+}
+
+// UniqueBy returns a validation rule that checks if the elements of a slice or array have distinct keys,
+// as returned by the key function, e.g. to check that the items of an order have distinct SKUs:
+//
+//	validation.Field(&order.Items, validation.UniqueBy(func(e interface{}) interface{} {
+//	    return e.(Item).SKU
+//	}))
+//
+// The keys must be of comparable types. The duplicates are reported in the same way as Unique.
+func UniqueBy(key func(elem interface{}) interface{}) UniqueRule {
+	return UniqueRule{key: key, err: ErrUniqueInvalid}
+}
+
+// UniqueRule is a validation rule that checks if the elements of a slice or array are distinct.
+type UniqueRule struct {
+	key func(elem interface{}) interface{}
+	err Error
+}
+
+// Error sets the error message for the rule.
+func (r UniqueRule) Error(message string) UniqueRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r UniqueRule) ErrorObject(err Error) UniqueRule {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "unique". If the elements are compared by a key function set by UniqueBy, the param "by" is true.
+func (r UniqueRule) Metadata() RuleInfo {
+	if r.key != nil {
+		return RuleInfo{Kind: "unique", Params: map[string]interface{}{"by": true}}
+	}
+	return RuleInfo{Kind: "unique"}
+}
+
+// Validate checks if the elements of the given slice or array are distinct.
+func (r UniqueRule) Validate(ctx context.Context, value interface{}) error {
+	v, isNil, err := iterableValue(value)
+	if isNil || err != nil {
+		return err
+	}
+
+	errs := Errors{}
+	seen := make(map[interface{}]int, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		k := v.Index(i).Interface()
+		if r.key != nil {
+			k = r.key(k)
+		}
+		if t := reflect.TypeOf(k); t != nil && !t.Comparable() {
+			return fmt.Errorf("cannot compare the elements of type %v", t)
+		}
+		if first, ok := seen[k]; ok {
+			errs[strconv.Itoa(i)] = r.err.SetParams(map[string]interface{}{"index": first})
+			continue
+		}
+		seen[k] = i
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnique(t *testing.T) {
+	type item struct {
+		SKU string
+		Qty int
+	}
+	bySKU := UniqueBy(func(e interface{}) interface{} { return e.(item).SKU })
+	a, b := 1, 1
+
+	tests := []struct {
+		tag   string
+		rule  UniqueRule
+		value interface{}
+		err   string
+	}{
+		{"t1", Unique(), nil, ""},
+		{"t2", Unique(), []string{}, ""},
+		{"t3", Unique(), []string{"a", "b", "c"}, ""},
+		{"t4", Unique(), []string{"a", "b", "a", "a"}, "2: must be unique; 3: must be unique."},
+		{"t5", Unique(), [3]int{1, 2, 2}, "2: must be unique."},
+		{"t6", Unique(), &[]int{1, 1}, "1: must be unique."},
+		{"t7", Unique(), []*int{&a, &b}, ""},
+		{"t8", Unique(), []interface{}{1, "1", 1}, "2: must be unique."},
+		{"t9", Unique(), []item{{"a", 1}, {"a", 2}}, ""},
+		{"t10", bySKU, []item{{"a", 1}, {"b", 1}, {"a", 2}}, "2: must be unique."},
+		{"t11", Unique(), "abc", "must be a slice or an array"},
+		{"t12", Unique(), [][]int{{1}, {1}}, "cannot compare the elements of type []int"},
+		{"t13", Unique().Error("is duplicated"), []int{1, 1}, "1: is duplicated."},
+	}
+
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := Unique().Validate(context.Background(), []string{"x", "y", "x"})
+	if assert.IsType(t, Errors{}, err) {
+		e := err.(Errors)["2"].(Error)
+		assert.Equal(t, ErrUniqueInvalid.Code(), e.Code())
+		assert.Equal(t, map[string]interface{}{"index": 0}, e.Params())
+	}
+
+	var s struct {
+		Tags []string `json:"tags"`
+	}
+	s.Tags = []string{"go", "go"}
+	err = ValidateStruct(&s, Field(&s.Tags, Unique()))
+	assertError(t, "tags: (1: must be unique.).", err, "t14")
+}
@@ -0,0 +1,248 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+var _ Rule = (*UploadRule)(nil)
+
+var (
+	// ErrFileTooLarge is the error that returns when an uploaded file is too large.
+	ErrFileTooLarge = NewError("validation_file_too_large", "the file size must be no more than {{.max}} bytes")
+	// ErrFileMIMEInvalid is the error that returns when the content of an uploaded file is not of an allowed type.
+	ErrFileMIMEInvalid = NewError("validation_file_mime_invalid", "must be a file of an allowed type")
+	// ErrFileExtInvalid is the error that returns when an uploaded file does not have an allowed extension.
+	ErrFileExtInvalid = NewError("validation_file_ext_invalid", "must be a file with an allowed extension")
+)
+
+// sniffLen is the number of bytes considered by http.DetectContentType.
+const sniffLen = 512
+
+// FileMaxSize returns a validation rule that checks if the size of an uploaded file is no more than max bytes.
+// See UploadRule for the supported values. An empty value is considered valid.
+func FileMaxSize(max int64) UploadRule {
+	return UploadRule{
+		kind:   "file_max_size",
+		params: map[string]interface{}{"max": max},
+		check: func(f upload) (bool, error) {
+			size, err := f.size()
+			return size <= max, err
+		},
+		err: ErrFileTooLarge.SetParams(map[string]interface{}{"max": max}),
+	}
+}
+
+// FileMIMEIn returns a validation rule that checks if the content type of an uploaded file is one of the given
+// media types, e.g. "image/png", or matches a type with a wildcard subtype, e.g. "image/*". The content type is
+// detected from the first 512 bytes of the content by http.DetectContentType, rather than trusted from the
+// file name or the Content-Type header sent by the client. See UploadRule for the supported values.
+// An empty value is considered valid.
+func FileMIMEIn(types ...string) UploadRule {
+	return UploadRule{
+		kind:   "file_mime_in",
+		params: map[string]interface{}{"types": types},
+		check: func(f upload) (bool, error) {
+			head, err := f.head()
+			if err != nil {
+				return false, err
+			}
+			detected := mediaType(http.DetectContentType(head))
+			for _, t := range types {
+				t = strings.ToLower(t)
+				if t == detected || strings.HasSuffix(t, "/*") && strings.HasPrefix(detected, t[:len(t)-1]) {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		err: ErrFileMIMEInvalid,
+	}
+}
+
+// FileExtIn returns a validation rule that checks if the name of an uploaded file has one of the given extensions,
+// with or without the leading dot, e.g. ".png" or "png". The extensions are compared ignoring case.
+// A file without a name, e.g. an io.Reader other than *os.File, is invalid. See UploadRule for the supported values.
+// An empty value is considered valid.
+func FileExtIn(extensions ...string) UploadRule {
+	return UploadRule{
+		kind:   "file_ext_in",
+		params: map[string]interface{}{"extensions": extensions},
+		check: func(f upload) (bool, error) {
+			ext := strings.TrimPrefix(filepath.Ext(f.name), ".")
+			for _, e := range extensions {
+				if ext != "" && strings.EqualFold(ext, strings.TrimPrefix(e, ".")) {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		err: ErrFileExtInvalid,
+	}
+}
+
+// UploadRule is a validation rule that checks an uploaded file, which can be:
+//   - a *multipart.FileHeader, e.g. from http.Request.FormFile or multipart.Form.File
+//   - an io.Reader, e.g. an *os.File or a multipart.File. The content read to sniff the content type or
+//     to count the size is rewound if the reader is an io.Seeker, and consumed otherwise.
+//
+// An error reading the file is returned as an InternalError.
+type UploadRule struct {
+	kind   string
+	params map[string]interface{}
+	check  func(upload) (bool, error)
+	err    Error
+}
+
+// Validate checks if the given value is valid or not.
+func (r UploadRule) Validate(ctx context.Context, value interface{}) error {
+	var f upload
+	switch v := value.(type) {
+	case *multipart.FileHeader:
+		if v == nil {
+			return nil
+		}
+		f = headerUpload(v)
+	case io.Reader:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil
+		}
+		f = readerUpload(v)
+	default:
+		opts := GetOptions(ctx)
+		value, isNil := indirectWithOptions(value, opts)
+		if isNil || isEmptyWithOptions(value, opts) {
+			return nil
+		}
+		return errors.New("must be a *multipart.FileHeader or an io.Reader")
+	}
+
+	ok, err := r.check(f)
+	if err != nil {
+		return NewInternalError(err)
+	}
+	if !ok {
+		return r.err
+	}
+	return nil
+}
+
+// Error sets the error message for the rule.
+func (r UploadRule) Error(message string) UploadRule {
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule.
+func (r UploadRule) ErrorObject(err Error) UploadRule {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "file_max_size" with the param "max", "file_mime_in" with the param "types",
+// or "file_ext_in" with the param "extensions".
+func (r UploadRule) Metadata() RuleInfo {
+	return RuleInfo{Kind: r.kind, Params: r.params}
+}
+
+// upload is an uploaded file whose name, size and first bytes are checked by UploadRule.
+type upload struct {
+	name string
+	size func() (int64, error)
+	head func() ([]byte, error)
+}
+
+func headerUpload(fh *multipart.FileHeader) upload {
+	return upload{
+		name: fh.Filename,
+		size: func() (int64, error) { return fh.Size, nil },
+		head: func() ([]byte, error) {
+			f, err := fh.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			return readHead(f)
+		},
+	}
+}
+
+func readerUpload(r io.Reader) upload {
+	var name string
+	if n, ok := r.(interface{ Name() string }); ok {
+		name = n.Name()
+	}
+	return upload{
+		name: name,
+		size: func() (int64, error) { return readerSize(r) },
+		head: func() ([]byte, error) {
+			head, err := readHead(r)
+			if err != nil {
+				return nil, err
+			}
+			if s, ok := r.(io.Seeker); ok {
+				_, err = s.Seek(-int64(len(head)), io.SeekCurrent)
+			}
+			return head, err
+		},
+	}
+}
+
+// mediaType returns the media type of a content type without its parameters, in lower case.
+// If the content type cannot be parsed, the part before the first ";" is used.
+func mediaType(contentType string) string {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		if i := strings.IndexByte(contentType, ';'); i >= 0 {
+			contentType = contentType[:i]
+		}
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mt
+}
+
+// readHead reads the bytes used to sniff the content type.
+func readHead(r io.Reader) ([]byte, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return head[:n], err
+}
+
+// readerSize returns the size of the content of r, from its Size or Stat method if any, such as those of
+// *bytes.Reader and *os.File, by seeking the end of an io.Seeker, or by reading the content otherwise.
+func readerSize(r io.Reader) (int64, error) {
+	switch v := r.(type) {
+	case interface{ Size() int64 }:
+		return v.Size(), nil
+	case interface{ Stat() (fs.FileInfo, error) }:
+		info, err := v.Stat()
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	case io.Seeker:
+		cur, err := v.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return 0, err
+		}
+		end, err := v.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, err
+		}
+		_, err = v.Seek(cur, io.SeekStart)
+		return end - cur, err
+	}
+	return io.Copy(io.Discard, r)
+}
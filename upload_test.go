@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+// newFileHeader builds a *multipart.FileHeader by parsing a multipart form holding a file.
+func newFileHeader(t *testing.T, name string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	fw, err := w.CreateFormFile("file", name)
+	assert.NoError(t, err)
+	_, _ = fw.Write(content)
+	assert.NoError(t, w.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	_, fh, err := r.FormFile("file")
+	assert.NoError(t, err)
+	return fh
+}
+
+func TestUploadRule(t *testing.T) {
+	png := newFileHeader(t, "photo.PNG", pngHeader)
+	text := newFileHeader(t, "photo.png", []byte("hello world"))
+	var nilHeader *multipart.FileHeader
+
+	tests := []struct {
+		tag   string
+		rule  UploadRule
+		value interface{}
+		err   string
+	}{
+		{"t1", FileMaxSize(100), png, ""},
+		{"t2", FileMaxSize(10), png, "the file size must be no more than 10 bytes"},
+		{"t3", FileMaxSize(10), nilHeader, ""},
+		{"t4", FileMaxSize(10), nil, ""},
+		{"t5", FileMaxSize(10), "photo.png", "must be a *multipart.FileHeader or an io.Reader"},
+		{"t6", FileMIMEIn("image/png"), png, ""},
+		{"t7", FileMIMEIn("image/jpeg", "image/*"), png, ""},
+		{"t8", FileMIMEIn("image/png"), text, "must be a file of an allowed type"},
+		{"t9", FileMIMEIn("text/plain"), text, ""},
+		{"t10", FileExtIn(".png", "jpg"), png, ""},
+		{"t11", FileExtIn("jpg"), png, "must be a file with an allowed extension"},
+		{"t12", FileMaxSize(100), bytes.NewReader(pngHeader), ""},
+		{"t13", FileMaxSize(10), bytes.NewReader(pngHeader), "the file size must be no more than 10 bytes"},
+		{"t14", FileMaxSize(10), io.LimitReader(strings.NewReader("hello world"), 100), "the file size must be no more than 10 bytes"},
+		{"t15", FileMIMEIn("image/png"), bytes.NewReader(pngHeader), ""},
+		{"t16", FileMIMEIn("image/png"), strings.NewReader("hello"), "must be a file of an allowed type"},
+		{"t17", FileExtIn("png"), bytes.NewReader(pngHeader), "must be a file with an allowed extension"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestUploadRule_Rewind(t *testing.T) {
+	r := bytes.NewReader(pngHeader)
+	assert.NoError(t, Validate(r, FileMIMEIn("image/png"), FileMaxSize(100)))
+	content, _ := io.ReadAll(r)
+	assert.Equal(t, pngHeader, content)
+
+	path := filepath.Join(t.TempDir(), "photo.png")
+	assert.NoError(t, os.WriteFile(path, pngHeader, 0o600))
+	f, err := os.Open(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer f.Close()
+	assert.NoError(t, Validate(f, FileExtIn("png"), FileMIMEIn("image/png"), FileMaxSize(100)))
+	assertError(t, "the file size must be no more than 10 bytes", Validate(f, FileMaxSize(10)), "file")
+}
+
+func TestMediaType(t *testing.T) {
+	tests := []struct {
+		tag         string
+		contentType string
+		expected    string
+	}{
+		{"t1", "image/png", "image/png"},
+		{"t2", "text/plain; charset=utf-8", "text/plain"},
+		{"t3", "Text/HTML; charset=utf-8", "text/html"},
+		{"t4", "Text/Plain ; =utf-8", "text/plain"},
+		{"t5", "", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, mediaType(test.contentType), test.tag)
+	}
+}
+
+func TestUploadRule_Error(t *testing.T) {
+	r := FileExtIn("png").Error("123")
+	assert.Equal(t, "123", r.err.Message())
+	assert.Equal(t, ErrFileExtInvalid.Code(), r.err.Code())
+
+	err := NewError("code", "abc")
+	r = r.ErrorObject(err)
+	assert.Equal(t, err, r.err)
+}
@@ -0,0 +1,151 @@
+package validation
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+var _ Rule = (*URLRule)(nil)
+
+var (
+	// ErrURLInvalid is the error that returns when a value is not a valid URL.
+	ErrURLInvalid = NewError("validation_url_invalid", "must be a valid URL")
+	// ErrURLNotAbsolute is the error that returns when a URL is not absolute while it is required by RequireAbsolute.
+	ErrURLNotAbsolute = NewError("validation_url_not_absolute", "must be an absolute URL")
+	// ErrURLSchemeNotAllowed is the error that returns when the scheme of a URL is not allowed by Schemes.
+	ErrURLSchemeNotAllowed = NewError("validation_url_scheme_not_allowed", "must use one of the schemes: {{.schemes}}")
+	// ErrURLHostNotAllowed is the error that returns when the host of a URL is not allowed by Hosts.
+	ErrURLHostNotAllowed = NewError("validation_url_host_not_allowed", "must use an allowed host")
+)
+
+// URLRule is a validation rule that checks if a string is a URL that complies with a policy,
+// such as the allowed schemes and hosts.
+type URLRule struct {
+	schemes  []string
+	hosts    []string
+	absolute bool
+	err      Error
+}
+
+// URL returns a validation rule that checks if a string is a valid URL, as parsed by url.Parse.
+// The URLs accepted can be restricted by calling Schemes, Hosts and RequireAbsolute, e.g. for webhook URLs:
+//
+//	validation.URL().Schemes("https").Hosts("*.example.com").RequireAbsolute()
+//
+// An empty value is considered valid. Use the Required rule to make sure a value is not empty.
+func URL() URLRule {
+	return URLRule{}
+}
+
+// Schemes sets the allowed schemes, e.g. "https". The schemes are compared case-insensitively.
+// A URL without a scheme is not allowed if the schemes are set.
+func (r URLRule) Schemes(schemes ...string) URLRule {
+	r.schemes = schemes
+	return r
+}
+
+// Hosts sets the allowed hosts. A host can be a domain name or an IP address, which must be equal to the host
+// of the URL, or a pattern such as "*.example.com", which matches the subdomains of example.com but not
+// example.com itself. The hosts are compared case-insensitively, and the ports of the URLs are ignored.
+// A URL without a host is not allowed if the hosts are set.
+func (r URLRule) Hosts(hosts ...string) URLRule {
+	r.hosts = hosts
+	return r
+}
+
+// RequireAbsolute requires the URL to be absolute, i.e. to have both a scheme and a host.
+func (r URLRule) RequireAbsolute() URLRule {
+	r.absolute = true
+	return r
+}
+
+// Error sets the error message for the rule, which is used for all the failures.
+func (r URLRule) Error(message string) URLRule {
+	if r.err == nil {
+		r.err = ErrURLInvalid
+	}
+	r.err = r.err.SetMessage(message)
+	return r
+}
+
+// ErrorObject sets the error struct for the rule, which is used for all the failures.
+func (r URLRule) ErrorObject(err Error) URLRule {
+	r.err = err
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "url", with the params "schemes" and "hosts" holding the allowed schemes and hosts as []string,
+// and the param "absolute".
+func (r URLRule) Metadata() RuleInfo {
+	return RuleInfo{
+		Kind:   "url",
+		Params: map[string]interface{}{"schemes": r.schemes, "hosts": r.hosts, "absolute": r.absolute},
+	}
+}
+
+// Validate checks if the given value is a valid URL that complies with the policy of the rule.
+func (r URLRule) Validate(ctx context.Context, value interface{}) error {
+	opts := GetOptions(ctx)
+	value, isNil := indirectWithOptions(value, opts)
+	if isNil || isEmptyWithOptions(value, opts) {
+		return nil
+	}
+
+	str, err := stringWithOptions(value, opts)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(str)
+	if err != nil {
+		return r.error(ErrURLInvalid)
+	}
+	if r.absolute && (u.Scheme == "" || u.Host == "") {
+		return r.error(ErrURLNotAbsolute)
+	}
+	if len(r.schemes) > 0 && !r.allowsScheme(u.Scheme) {
+		return r.error(ErrURLSchemeNotAllowed.SetParams(map[string]interface{}{"schemes": strings.Join(r.schemes, ", ")}))
+	}
+	if len(r.hosts) > 0 && !r.allowsHost(u.Hostname()) {
+		return r.error(ErrURLHostNotAllowed)
+	}
+	return nil
+}
+
+// error returns the error set by Error or ErrorObject, or err if none is set.
+func (r URLRule) error(err Error) Error {
+	if r.err != nil {
+		return r.err
+	}
+	return err
+}
+
+func (r URLRule) allowsScheme(scheme string) bool {
+	for _, s := range r.schemes {
+		if strings.EqualFold(s, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r URLRule) allowsHost(host string) bool {
+	if host == "" {
+		return false
+	}
+	host = strings.ToLower(host)
+	for _, h := range r.hosts {
+		h = strings.ToLower(h)
+		if strings.HasPrefix(h, "*.") {
+			// the pattern matches the subdomains, e.g. "*.example.com" matches "api.example.com"
+			if suffix := h[1:]; len(host) > len(suffix) && strings.HasSuffix(host, suffix) {
+				return true
+			}
+		} else if host == h {
+			return true
+		}
+	}
+	return false
+}
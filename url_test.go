@@ -0,0 +1,53 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURL(t *testing.T) {
+	webhook := URL().Schemes("https").Hosts("*.example.com", "example.org").RequireAbsolute()
+
+	tests := []struct {
+		tag   string
+		rule  URLRule
+		value interface{}
+		err   string
+	}{
+		{"t1", URL(), "", ""},
+		{"t2", URL(), "https://example.com/a?b=c", ""},
+		{"t3", URL(), "/callback", ""},
+		{"t4", URL(), "http://[::1", "must be a valid URL"},
+		{"t5", URL().RequireAbsolute(), "/callback", "must be an absolute URL"},
+		{"t6", URL().RequireAbsolute(), "mailto:a@example.com", "must be an absolute URL"},
+		{"t7", URL().Schemes("https", "wss"), "WSS://example.com", ""},
+		{"t8", URL().Schemes("https", "wss"), "http://example.com", "must use one of the schemes: https, wss"},
+		{"t9", URL().Schemes("https"), "//example.com", "must use one of the schemes: https"},
+		{"t10", webhook, "https://hooks.example.com/x", ""},
+		{"t11", webhook, "https://a.b.EXAMPLE.com:8443/x", ""},
+		{"t12", webhook, "https://example.org/x", ""},
+		{"t13", webhook, "https://example.com/x", "must use an allowed host"},
+		{"t14", webhook, "https://evilexample.com/x", "must use an allowed host"},
+		{"t15", webhook, "https://hooks.example.com.evil.net/x", "must use an allowed host"},
+		{"t16", webhook, "https://sub.example.org/x", "must use an allowed host"},
+		{"t17", URL().Hosts("example.com"), "/path", "must use an allowed host"},
+		{"t18", webhook.Error("must be a registered webhook URL"), "http://example.org", "must be a registered webhook URL"},
+		{"t19", webhook.Error("must be a registered webhook URL"), "::", "must be a registered webhook URL"},
+		{"t20", URL(), 123, "must be either a string or byte slice"},
+	}
+	for _, test := range tests {
+		err := test.rule.Validate(context.Background(), test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	err := webhook.Validate(context.Background(), "http://example.org")
+	if e, ok := err.(Error); assert.True(t, ok) {
+		assert.Equal(t, "validation_url_scheme_not_allowed", e.Code())
+	}
+	err = webhook.ErrorObject(NewError("bad_webhook", "is not allowed")).Validate(context.Background(), "https://example.net")
+	if e, ok := err.(Error); assert.True(t, ok) {
+		assert.Equal(t, "bad_webhook", e.Code())
+	}
+}
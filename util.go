@@ -5,11 +5,16 @@
 package validation
 
 import (
+	"bytes"
 	"context"
 	"database/sql/driver"
+	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 )
 
 // CmpOperator is used to define comparison operators.
@@ -26,10 +31,23 @@ const (
 // CmpFunc is used to compare two values.
 type CmpFunc func(op CmpOperator, v1, v2 interface{}) bool
 
-// DefaultValuer is the default implementation of ValuerProxy.
+// DefaultValuer is the default implementation of ValuerFunc.
+// It extracts the value to validate as follows, in order of precedence:
+//   - driver.Valuer (e.g. sql.NullString): the value returned by Value(), where nil means a null value,
+//     e.g. an invalid sql.NullString. The original value is kept if Value() returns an error.
+//   - json.RawMessage: the decoded JSON value, e.g. a string, a float64 or a map[string]interface{},
+//     where "null" means a null value. Empty or invalid JSON is kept as is.
+//
+// A null value is considered nil by the rules, e.g. it is rejected by Required and NotNil.
 func DefaultValuer(orig interface{}) (interface{}, bool) {
-	if valuer, ok := orig.(driver.Valuer); ok {
-		if value, err := valuer.Value(); err == nil {
+	switch v := orig.(type) {
+	case driver.Valuer:
+		if value, err := v.Value(); err == nil {
+			return value, true
+		}
+	case json.RawMessage:
+		var value interface{}
+		if len(v) > 0 && json.Unmarshal(v, &value) == nil {
 			return value, true
 		}
 	}
@@ -52,6 +70,36 @@ func EnsureString(value interface{}) (string, error) {
 	return "", errors.New("must be either a string or byte slice")
 }
 
+// stringWithOptions returns the value as a string like EnsureString. It is used by all rules validating strings.
+// If the conversion is enabled by WithStringerConversion, a value implementing encoding.TextMarshaler or fmt.Stringer
+// is converted into a string, including the values whose methods have pointer receivers, which are lost when the
+// pointers are dereferenced before the validation. An error returned by MarshalText is an InternalError.
+func stringWithOptions(value interface{}, opts Options) (string, error) {
+	str, err := EnsureString(value)
+	if err == nil || value == nil || !opts.StringerConversion() {
+		return str, err
+	}
+
+	switch value.(type) {
+	case encoding.TextMarshaler, fmt.Stringer:
+	default:
+		ptr := reflect.New(reflect.TypeOf(value))
+		ptr.Elem().Set(reflect.ValueOf(value))
+		value = ptr.Interface()
+	}
+	switch v := value.(type) {
+	case encoding.TextMarshaler:
+		text, merr := v.MarshalText()
+		if merr != nil {
+			return "", NewInternalError(merr)
+		}
+		return string(text), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return "", err
+}
+
 // StringOrBytes typecasts a value into a string or byte slice.
 // Boolean flags are returned to indicate if the typecasting succeeds or not.
 func StringOrBytes(value interface{}) (isString bool, str string, isBytes bool, bs []byte) {
@@ -110,6 +158,12 @@ func ToFloat(value interface{}) (float64, error) {
 	return 0, fmt.Errorf("cannot convert %v to float64", v.Kind())
 }
 
+// IsZeroer is implemented by types that report whether they hold their zero value, such as time.Time,
+// decimal types and custom IDs, whose zero value cannot be detected by comparing the fields.
+type IsZeroer interface {
+	IsZero() bool
+}
+
 // IsEmpty checks if a value is empty or not.
 // A value is considered empty if
 // - integer, float: zero
@@ -117,8 +171,32 @@ func ToFloat(value interface{}) (float64, error) {
 // - string, array: len() == 0
 // - slice, map: nil or len() == 0
 // - interface, pointer: nil or the referenced value is empty
+// - time.Time: IsZero() is true, regardless of the location
+// - IsZeroer: IsZero() is true
 func IsEmpty(value interface{}) bool {
+	return isEmpty(value, true)
+}
+
+// isEmptyWithOptions checks if a value is empty with the function set by WithIsEmptyFunc, or like IsEmpty otherwise.
+// IsZeroer is honored unless disabled by WithIsZeroer.
+func isEmptyWithOptions(value interface{}, opts Options) bool {
+	if f := opts.IsEmptyFunc(); f != nil {
+		return f(value)
+	}
+	return isEmpty(value, opts.IsZeroer())
+}
+
+func isEmpty(value interface{}, zeroer bool) bool {
+	if t, ok := value.(time.Time); ok {
+		return t.IsZero()
+	}
+
 	v := reflect.ValueOf(value)
+	if zeroer && (v.Kind() != reflect.Ptr || !v.IsNil()) {
+		if z, ok := value.(IsZeroer); ok {
+			return z.IsZero()
+		}
+	}
 	switch v.Kind() {
 	case reflect.Array, reflect.Map, reflect.Slice:
 		return v.Len() == 0
@@ -128,7 +206,7 @@ func IsEmpty(value interface{}) bool {
 		if v.IsNil() {
 			return true
 		}
-		return IsEmpty(v.Elem().Interface())
+		return isEmpty(v.Elem().Interface(), zeroer)
 	default:
 		return reflect.DeepEqual(value, reflect.Zero(reflect.TypeOf(value)).Interface())
 	}
@@ -153,6 +231,14 @@ func indirectWithOptions(value interface{}, opts Options) (interface{}, bool) {
 		if rv.IsNil() {
 			return nil, true
 		}
+		if kind == reflect.Ptr {
+			// apply the valuer to the pointer first, which supports driver.Valuer with pointer receivers
+			if valuerProxy := opts.ValuerFunc(); valuerProxy != nil {
+				if val, ok := valuerProxy(value); ok {
+					return indirectWithOptions(val, opts)
+				}
+			}
+		}
 		return indirectWithOptions(rv.Elem().Interface(), opts)
 	case reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
 		if rv.IsNil() {
@@ -166,5 +252,24 @@ func indirectWithOptions(value interface{}, opts Options) (interface{}, bool) {
 		}
 	}
 
+	if opts.TrimSpace() {
+		value = trimSpace(value)
+	}
 	return value, false
 }
+
+// trimSpace returns a string or byte slice without its leading and trailing white space, keeping its type.
+// Other values are returned as is.
+func trimSpace(value interface{}) interface{} {
+	if s, ok := value.(string); ok {
+		return strings.TrimSpace(s)
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Kind() == reflect.String:
+		return reflect.ValueOf(strings.TrimSpace(v.String())).Convert(v.Type()).Interface()
+	case v.Kind() == reflect.Slice && v.Type() == bytesType:
+		return bytes.TrimSpace(v.Bytes())
+	}
+	return value
+}
@@ -5,7 +5,13 @@
 package validation
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -275,6 +281,15 @@ func TestIsEmpty(t *testing.T) {
 		{"t10.2", &time1, false},
 		{"t10.3", time2, true},
 		{"t10.4", &time2, true},
+		{"t10.5", time2.In(time.FixedZone("UTC+1", 3600)), true},
+		{"t10.6", time.Unix(0, 0), false},
+		// IsZeroer
+		{"t11.1", zeroID{version: 1}, true},
+		{"t11.2", zeroID{value: "a"}, false},
+		{"t11.3", &zeroID{version: 1}, true},
+		{"t11.4", (*zeroID)(nil), true},
+		{"t11.5", &ptrZeroer{n: 2}, true},
+		{"t11.6", ptrZeroer{n: 2}, false},
 	}
 
 	for _, test := range tests {
@@ -283,6 +298,70 @@ func TestIsEmpty(t *testing.T) {
 	}
 }
 
+// zeroID is a custom ID whose version does not matter for its zero value.
+type zeroID struct {
+	value   string
+	version int
+}
+
+func (id zeroID) IsZero() bool {
+	return id.value == ""
+}
+
+type ptrZeroer struct {
+	n int
+}
+
+func (p *ptrZeroer) IsZero() bool {
+	return p.n%2 == 0
+}
+
+func TestWithIsZeroer(t *testing.T) {
+	id := zeroID{version: 1}
+	disabled := WithOptions(context.Background(), WithIsZeroer(false))
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		value interface{}
+		rules []Rule
+		err   string
+	}{
+		{"t1", context.Background(), id, []Rule{Required}, "cannot be blank"},
+		{"t2", disabled, id, []Rule{Required}, ""},
+		{"t3", context.Background(), &id, []Rule{Required}, "cannot be blank"},
+		{"t4", context.Background(), id, []Rule{NilOrNotEmpty}, "cannot be blank"},
+		{"t5", context.Background(), id, []Rule{In(zeroID{value: "a"})}, ""},
+		{"t6", disabled, id, []Rule{In(zeroID{value: "a"})}, "must be a valid value"},
+		{"t7", context.Background(), zeroID{value: "a"}, []Rule{Required}, ""},
+		{"t8", disabled, time.Time{}, []Rule{Required}, "cannot be blank"},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, test.value, test.rules...)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	assert.True(t, DefaultOptions().IsZeroer())
+	assert.False(t, GetOptions(disabled).IsZeroer())
+}
+
+type ptrValuer struct {
+	v string
+}
+
+func (p *ptrValuer) Value() (driver.Value, error) {
+	if p.v == "" {
+		return nil, nil
+	}
+	return p.v, nil
+}
+
+type errValuer struct{}
+
+func (errValuer) Value() (driver.Value, error) {
+	return nil, errors.New("value error")
+}
+
 func TestIndirect(t *testing.T) {
 	a := 100
 	var b *int
@@ -307,6 +386,17 @@ func TestIndirect(t *testing.T) {
 		{"t11", &sql.NullInt64{Int64: 0, Valid: true}, int64(0), false},
 		{"t12", &sql.NullInt64{Int64: 1, Valid: true}, int64(1), false},
 		{"t13", c, nil, true},
+		{"t14", json.RawMessage(`"abc"`), "abc", false},
+		{"t15", json.RawMessage(`12`), float64(12), false},
+		{"t16", json.RawMessage(`null`), nil, true},
+		{"t17", json.RawMessage(`{"a":1}`), map[string]interface{}{"a": float64(1)}, false},
+		{"t18", json.RawMessage(`{`), json.RawMessage(`{`), false},
+		{"t19", json.RawMessage(nil), nil, true},
+		{"t19.1", json.RawMessage{}, json.RawMessage{}, false},
+		{"t20", &ptrValuer{v: "abc"}, "abc", false},
+		{"t21", &ptrValuer{}, nil, true},
+		{"t22", ptrValuer{v: "abc"}, ptrValuer{v: "abc"}, false},
+		{"t23", errValuer{}, errValuer{}, false},
 	}
 
 	for _, test := range tests {
@@ -315,3 +405,71 @@ func TestIndirect(t *testing.T) {
 		assert.Equal(t, test.isNil, isNil, test.tag)
 	}
 }
+
+func TestWithIsEmptyFunc(t *testing.T) {
+	blank := WithOptions(context.Background(), WithIsEmptyFunc(func(v any) bool {
+		if s, ok := v.(string); ok {
+			return strings.TrimSpace(s) == ""
+		}
+		return IsEmpty(v)
+	}))
+	spaces := "  "
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		value interface{}
+		rules []Rule
+		err   string
+	}{
+		{"t1", context.Background(), spaces, []Rule{Required}, ""},
+		{"t2", blank, spaces, []Rule{Required}, "cannot be blank"},
+		{"t3", blank, &spaces, []Rule{NilOrNotEmpty}, "cannot be blank"},
+		{"t4", blank, (*string)(nil), []Rule{NilOrNotEmpty}, ""},
+		{"t5", blank, spaces, []Rule{Empty}, ""},
+		{"t6", context.Background(), spaces, []Rule{NewStringRule(func(string) bool { return false }, "invalid")}, "invalid"},
+		{"t7", blank, spaces, []Rule{NewStringRule(func(string) bool { return false }, "invalid")}, ""},
+		{"t8", blank, 0, []Rule{Required}, "cannot be blank"},
+		{"t9", WithOptions(blank, WithIsEmptyFunc(nil)), spaces, []Rule{Required}, ""},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, test.value, test.rules...)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	trimmed := WithOptions(context.Background(), WithTrimSpace(true))
+	digits := NewStringRule(func(s string) bool { return strings.Trim(s, "0123456789") == "" }, "must be digits")
+	spaces := "  "
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		value interface{}
+		rule  Rule
+		err   string
+	}{
+		{"t1", context.Background(), spaces, Required, ""},
+		{"t2", context.Background(), spaces, Required.TrimSpace(), "cannot be blank"},
+		{"t3", context.Background(), &spaces, NilOrNotEmpty.TrimSpace(), "cannot be blank"},
+		{"t4", trimmed, spaces, Required, "cannot be blank"},
+		{"t5", trimmed, MyString(" \t"), Required, "cannot be blank"},
+		{"t6", trimmed, []byte(" \n"), Required, "cannot be blank"},
+		{"t7", context.Background(), " ab ", Length(1, 2), "the length must be between 1 and 2"},
+		{"t8", context.Background(), " ab ", Length(1, 2).TrimSpace(), ""},
+		{"t9", trimmed, " ab ", Length(1, 2), ""},
+		{"t10", context.Background(), " 12 ", digits, "must be digits"},
+		{"t11", context.Background(), " 12 ", digits.TrimSpace(), ""},
+		{"t12", context.Background(), " 12 ", Match(regexp.MustCompile("^[0-9]+$")), "must be in a valid format"},
+		{"t13", context.Background(), " 12 ", Match(regexp.MustCompile("^[0-9]+$")).TrimSpace(), ""},
+		{"t14", trimmed, []byte(" 12 "), Match(regexp.MustCompile("^[0-9]+$")), ""},
+		{"t15", trimmed, " b ", In("a", "b"), ""},
+		{"t16", trimmed, 0, Required, "cannot be blank"},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, test.value, test.rule)
+		assertError(t, test.err, err, test.tag)
+	}
+	assert.Equal(t, "  ", spaces)
+}
@@ -51,27 +51,52 @@ func Validate(value interface{}, rules ...Rule) error {
 // ValidateWithContext validates the given value with the given context and returns the validation error, if any.
 //
 // ValidateWithContext performs validation using the following steps:
-//  1. For each rule, call its Validate() to validate the value.
+//  1. Apply the rules implementing Normalizer to the value, then for each of the other rules,
+//     call its Validate() to validate the normalized value.
 //     Otherwise call `Validate()` of the rule. Return if any error is found.
-//  2. If the value being validated implements `ValidatableWithContext`, call the value's `ValidateWithContext()`
-//     and return with the validation result.
-//  3. If the value being validated implements `Validatable`, call the value's `Validate()`
+//  2. If a Schema is registered by WithSchema for the type of the value being validated, validate the value
+//     with the Schema and return with the validation result. This also applies to the concrete values stored
+//     in struct fields declared as interface types.
+//  3. If the value being validated implements `ValidatableWithContext`, call the value's `ValidateWithContext()`
 //     and return with the validation result.
-//  4. If the value being validated is a map/slice/array, and the element type implements `ValidatableWithContext`,
+//  4. If the value being validated implements `Validatable`, call the value's `Validate()`
+//     and return with the validation result. If `Validate()` is defined on the pointer receiver, it is called
+//     on the pointer to the value if the value is addressable, e.g. a slice element, and to a copy otherwise,
+//     e.g. a struct field passed by value.
+//  5. If the value being validated is a map/slice/array, and the element type implements `ValidatableWithContext`,
 //     for each element call the element value's `ValidateWithContext()`. Return with the validation result.
-//  5. If the value being validated is a map/slice/array, and the element type implements `Validatable`,
+//  6. If the value being validated is a map/slice/array, and the element type implements `Validatable`,
 //     for each element call the element value's `Validate()`. Return with the validation result.
+//  7. If WithDeepValidation is enabled and the value being validated is a struct/map/slice/array, validate each
+//     exported struct field or element with ValidateWithContext. Return with the validation result.
 func ValidateWithContext(ctx context.Context, value interface{}, rules ...Rule) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx = startRuleCache(ctx)
+
+	if hasNormalizer(rules) {
+		value, rules = normalize(value, rules)
+	}
 
+	opts := getOpts(ctx)
+	timeout := opts.ruleTimeout
 	for _, rule := range rules {
-		if s, ok := rule.(skipRule); ok && s.skip {
+		if s, ok := rule.(skipRule); ok && s.skips(ctx, value) {
 			return nil
 		}
 
-		if err := rule.Validate(ctx, value); err != nil {
+		if timeout > 0 {
+			rule = withRuleTimeout(rule, timeout)
+		}
+
+		var err error
+		if opts.ruleObserver != nil {
+			err = observeRule(ctx, rule, value, opts.ruleObserver)
+		} else {
+			err = rule.Validate(ctx, value)
+		}
+		if err != nil {
 			return err
 		}
 	}
@@ -81,33 +106,83 @@ func ValidateWithContext(ctx context.Context, value interface{}, rules ...Rule)
 		return nil
 	}
 
+	if s, ok := opts.schema(value); ok {
+		return s.Validate(ctx, value)
+	}
+
 	if v, ok := value.(Validatable); ok {
 		return v.Validate(ctx)
 	}
+	if v, ok := asValidatable(rv); ok {
+		return v.Validate(ctx)
+	}
 
 	switch rv.Kind() {
 	case reflect.Map:
-		if rv.Type().Elem().Implements(validatableType) {
+		if isValidatable(rv.Type().Elem()) {
 			return validateMap(ctx, rv)
 		}
 	case reflect.Slice, reflect.Array:
-		if rv.Type().Elem().Implements(validatableType) {
+		if isValidatable(rv.Type().Elem()) {
 			return validateSlice(ctx, rv)
 		}
-	case reflect.Ptr, reflect.Interface:
+	case reflect.Ptr:
+		if opts.deep && rv.Elem().Kind() == reflect.Struct {
+			// walk the struct in place, so that reference cycles can be detected
+			return validateDeep(ctx, rv.Elem())
+		}
+		return ValidateWithContext(ctx, rv.Elem().Interface())
+	case reflect.Interface:
 		return ValidateWithContext(ctx, rv.Elem().Interface())
 	}
 
+	if opts.deep {
+		return validateDeep(ctx, rv)
+	}
 	return nil
 }
 
+// isValidatable reports whether the values of type t implement Validatable, either directly or by
+// a pointer receiver.
+func isValidatable(t reflect.Type) bool {
+	return t.Implements(validatableType) ||
+		t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && reflect.PtrTo(t).Implements(validatableType)
+}
+
+// asValidatable returns v as a Validatable. If only the pointer to the type of v implements Validatable,
+// the pointer to v is returned if v is addressable, e.g. a slice element, and the pointer to a copy of v otherwise.
+// False is returned if v does not implement Validatable or is a nil interface.
+func asValidatable(v reflect.Value) (Validatable, bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+	if v.Type().Implements(validatableType) {
+		vi, ok := v.Interface().(Validatable)
+		return vi, ok
+	}
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface || !reflect.PtrTo(v.Type()).Implements(validatableType) {
+		return nil, false
+	}
+	if !v.CanAddr() {
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		v = c
+	}
+	return v.Addr().Interface().(Validatable), true
+}
+
 // validateMap validates a map of validatable elements with the given context.
+// An internal error returned by an element is returned as is.
 func validateMap(ctx context.Context, rv reflect.Value) error {
 	errs := Errors{}
 	for _, key := range rv.MapKeys() {
-		if mv := rv.MapIndex(key).Interface(); mv != nil {
-			if err := mv.(Validatable).Validate(ctx); err != nil {
-				errs[fmt.Sprintf("%v", key.Interface())] = err
+		if mv, ok := asValidatable(rv.MapIndex(key)); ok {
+			k := fmt.Sprintf("%v", key.Interface())
+			if err := mv.Validate(withElement(ctx, k, key.Interface())); err != nil {
+				if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+					return err
+				}
+				errs[getOpts(ctx).elementKey(k, key.Interface(), false)] = err
 			}
 		}
 	}
@@ -118,6 +193,7 @@ func validateMap(ctx context.Context, rv reflect.Value) error {
 }
 
 // validateSlice validates a slice/array of validatable elements with the given context.
+// An internal error returned by an element is returned as is.
 func validateSlice(ctx context.Context, rv reflect.Value) error {
 	errs := Errors{}
 	l := rv.Len()
@@ -126,9 +202,13 @@ func validateSlice(ctx context.Context, rv reflect.Value) error {
 		if v.Kind() == reflect.Ptr && v.IsNil() {
 			continue
 		}
-		if ev := v.Interface(); ev != nil {
-			if err := ev.(Validatable).Validate(ctx); err != nil {
-				errs[strconv.Itoa(i)] = err
+		if ev, ok := asValidatable(v); ok {
+			k := strconv.Itoa(i)
+			if err := ev.Validate(withElement(ctx, k, i)); err != nil {
+				if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+					return err
+				}
+				errs[getOpts(ctx).elementKey(k, i, true)] = err
 			}
 		}
 	}
@@ -141,7 +221,8 @@ func validateSlice(ctx context.Context, rv reflect.Value) error {
 var _ Rule = (*skipRule)(nil)
 
 type skipRule struct {
-	skip bool
+	skip          bool
+	conditionFunc ConditionFunc
 }
 
 func (r skipRule) Validate(context.Context, interface{}) error {
@@ -151,18 +232,101 @@ func (r skipRule) Validate(context.Context, interface{}) error {
 // When determines if all rules following it should be skipped.
 func (r skipRule) When(condition bool) skipRule {
 	r.skip = condition
+	r.conditionFunc = nil
+	return r
+}
+
+// WhenFunc determines if all rules following it should be skipped with a condition evaluated
+// at validation time. For example, the following rules are skipped if the field is absent
+// from the request payload:
+//
+//	validation.Skip.WhenFunc(func(ctx context.Context, value interface{}) bool {
+//	    return !isPresent(ctx)
+//	})
+func (r skipRule) WhenFunc(condition ConditionFunc) skipRule {
+	r.conditionFunc = condition
 	return r
 }
 
-type inlineRule struct {
-	f RuleFunc
+// skips reports whether the rules following the rule should be skipped when validating value.
+func (r skipRule) skips(ctx context.Context, value interface{}) bool {
+	if r.conditionFunc != nil {
+		return r.conditionFunc(ctx, value)
+	}
+	return r.skip
 }
 
-func (r *inlineRule) Validate(ctx context.Context, value interface{}) error {
-	return r.f(ctx, value)
+// Metadata returns the description of the rule.
+// The kind is "skip", or empty if the rule is disabled by When. If the condition is evaluated
+// at validation time, the param "dynamic" is true.
+func (r skipRule) Metadata() RuleInfo {
+	if r.conditionFunc != nil {
+		return RuleInfo{Kind: "skip", Params: map[string]interface{}{"dynamic": true}}
+	}
+	if !r.skip {
+		return RuleInfo{}
+	}
+	return RuleInfo{Kind: "skip"}
 }
 
-// By wraps a RuleFunc into a Rule.
-func By(f RuleFunc) Rule {
-	return &inlineRule{f: f}
+// InlineRule is a validation rule created from a RuleFunc by By.
+type InlineRule struct {
+	f       RuleFunc
+	err     Error
+	message string
+	code    string
+}
+
+// By wraps a RuleFunc into a Rule. The error returned by the function can be customized in the same way as
+// the built-in rules, for example,
+//
+//	validation.By(checkUniqueEmail).Error("is already taken").Code("EMAIL_TAKEN")
+func By(f RuleFunc) InlineRule {
+	return InlineRule{f: f}
+}
+
+// Error sets the error message for the rule, which replaces the message of the error returned by the function.
+func (r InlineRule) Error(message string) InlineRule {
+	r.message = message
+	return r
+}
+
+// ErrorObject sets the error struct for the rule, which replaces the error returned by the function.
+func (r InlineRule) ErrorObject(err Error) InlineRule {
+	r.err = err
+	return r
+}
+
+// Code sets the error code for the rule, which replaces the code of the error returned by the function.
+func (r InlineRule) Code(code string) InlineRule {
+	r.code = code
+	return r
+}
+
+// Validate calls the function of the rule and customizes the returned error as set by Error, ErrorObject and Code.
+// If the error is not an Error, it is converted to an Error with the error string as the message.
+// Internal errors are returned as is.
+func (r InlineRule) Validate(ctx context.Context, value interface{}) error {
+	err := r.f(ctx, value)
+	if err == nil || r.err == nil && r.message == "" && r.code == "" {
+		return err
+	}
+	if ie, ok := err.(InternalError); ok && ie.InternalError() != nil {
+		return err
+	}
+
+	e := r.err
+	if e == nil {
+		var ok bool
+		if e, ok = err.(Error); !ok {
+			e = NewError("", err.Error())
+		}
+	}
+	if r.message != "" {
+		e = e.SetMessage(r.message)
+	}
+	if r.code != "" {
+		e = setErrorCode(e, r.code)
+	}
+	return e
 }
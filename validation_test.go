@@ -93,6 +93,41 @@ func TestBy(t *testing.T) {
 	assert.NotNil(t, ValidateWithContext(nil, "abc", xyzRule))
 }
 
+func TestInlineRule_Error(t *testing.T) {
+	plain := By(stringEqual("abc"))
+	coded := By(func(context.Context, interface{}) error {
+		return ErrInInvalid.SetParams(map[string]interface{}{"value": "x"})
+	})
+	internal := By(func(context.Context, interface{}) error { return NewInternalError(errors.New("internal")) })
+
+	tests := []struct {
+		tag     string
+		rule    InlineRule
+		err     string
+		code    string
+		message string
+	}{
+		{"t1", plain, "unexpected string", "", ""},
+		{"t2", plain.Error("is not abc"), "is not abc", "", "is not abc"},
+		{"t3", plain.Code("NOT_ABC"), "unexpected string", "NOT_ABC", "unexpected string"},
+		{"t4", plain.ErrorObject(NewError("custom", "custom error")), "custom error", "custom", "custom error"},
+		{"t5", coded.Error("{{.value}} is invalid"), "x is invalid", "validation_in_invalid", "{{.value}} is invalid"},
+		{"t6", coded.Code("IN").Error("invalid"), "invalid", "IN", "invalid"},
+		{"t7", internal.Error("ignored").Code("ignored"), "internal", "", ""},
+	}
+	for _, test := range tests {
+		err := Validate("xyz", test.rule)
+		assertError(t, test.err, err, test.tag)
+		if test.code != "" || test.message != "" {
+			if e, ok := err.(Error); assert.True(t, ok, test.tag) {
+				assert.Equal(t, test.code, e.Code(), test.tag)
+				assert.Equal(t, test.message, e.Message(), test.tag)
+			}
+		}
+	}
+	assert.NoError(t, Validate("abc", plain.Error("is not abc")))
+}
+
 type key int
 
 func TestByWithContext(t *testing.T) {
@@ -117,6 +152,38 @@ func Test_skipRule_Validate(t *testing.T) {
 	assert.Nil(t, Skip.Validate(nil, 100))
 }
 
+func Test_skipRule_WhenFunc(t *testing.T) {
+	isEmpty := func(ctx context.Context, value interface{}) bool {
+		return value == ""
+	}
+	isSkipped := func(ctx context.Context, value interface{}) bool {
+		return ctx.Value(contains) == "skip"
+	}
+	skipCtx := context.WithValue(context.Background(), contains, "skip")
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		value interface{}
+		rules []Rule
+		err   string
+	}{
+		{"t1", context.Background(), "", []Rule{Skip.WhenFunc(isEmpty), Required}, ""},
+		{"t2", context.Background(), "a", []Rule{Skip.WhenFunc(isEmpty), Length(2, 3)}, "the length must be between 2 and 3"},
+		{"t3", skipCtx, "a", []Rule{Skip.WhenFunc(isSkipped), Length(2, 3)}, ""},
+		{"t4", context.Background(), "a", []Rule{Skip.WhenFunc(isSkipped), Length(2, 3)}, "the length must be between 2 and 3"},
+		{"t5", skipCtx, "a", []Rule{Skip.WhenFunc(isSkipped).When(false), Length(2, 3)}, "the length must be between 2 and 3"},
+		{"t6", skipCtx, String123("abc"), []Rule{Skip.WhenFunc(isSkipped)}, ""},
+		{"t7", context.Background(), String123("abc"), []Rule{Skip.WhenFunc(isSkipped)}, "error 123"},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, test.value, test.rules...)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	assert.Equal(t, RuleInfo{Kind: "skip", Params: map[string]interface{}{"dynamic": true}}, Skip.When(false).WhenFunc(isEmpty).Metadata())
+}
+
 func assertError(t *testing.T, expected string, err error, tag string) {
 	if expected == "" {
 		assert.NoError(t, err, tag)
@@ -393,3 +460,45 @@ func TestValidateEmptyCollections(t *testing.T) {
 	err = ValidateWithContext(nil, emptyArray)
 	assert.Nil(t, err)
 }
+
+type ptrValidatable struct {
+	Name string
+}
+
+func (v *ptrValidatable) Validate(ctx context.Context) error {
+	return ValidateStructWithContext(ctx, v, Field(&v.Name, Required))
+}
+
+func TestValidatePointerReceiver(t *testing.T) {
+	type order struct {
+		Customer ptrValidatable            `json:"customer"`
+		Items    []ptrValidatable          `json:"items"`
+		Labels   map[string]ptrValidatable `json:"labels"`
+		Refs     [1]ptrValidatable         `json:"refs"`
+	}
+
+	tests := []struct {
+		tag   string
+		value interface{}
+		err   string
+	}{
+		{"t1", ptrValidatable{}, "Name: cannot be blank."},
+		{"t2", ptrValidatable{Name: "a"}, ""},
+		{"t3", []ptrValidatable{{Name: "a"}, {}}, "1: (Name: cannot be blank.)."},
+		{"t4", map[string]ptrValidatable{"x": {}}, "x: (Name: cannot be blank.)."},
+		{"t5", [1]ptrValidatable{}, "0: (Name: cannot be blank.)."},
+	}
+	for _, test := range tests {
+		err := Validate(test.value)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	o := order{Items: []ptrValidatable{{}}, Labels: map[string]ptrValidatable{"k": {}}}
+	err := ValidateStruct(&o,
+		Field(&o.Customer),
+		Field(&o.Items),
+		Field(&o.Labels),
+		Field(&o.Refs),
+	)
+	assertError(t, "customer: (Name: cannot be blank.); items: (0: (Name: cannot be blank.).); labels: (k: (Name: cannot be blank.).); refs: (0: (Name: cannot be blank.).).", err, "t6")
+}
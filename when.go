@@ -4,6 +4,10 @@ import "context"
 
 var _ Rule = (*WhenRule)(nil)
 
+// ConditionFunc is a condition evaluated when a value is validated. It receives the context
+// and the value being validated, so the condition can depend on request-scoped data.
+type ConditionFunc func(ctx context.Context, value interface{}) bool
+
 // When returns a validation rule that executes the given list of rules when the condition is true.
 func When(condition bool, rules ...Rule) WhenRule {
 	return WhenRule{
@@ -13,16 +17,35 @@ func When(condition bool, rules ...Rule) WhenRule {
 	}
 }
 
+// WhenFunc returns a validation rule that executes the given list of rules when the condition
+// evaluated at validation time is true. For example,
+//
+//	validation.WhenFunc(func(ctx context.Context, value interface{}) bool {
+//	    return isAdmin(ctx)
+//	}, validation.Required)
+func WhenFunc(condition ConditionFunc, rules ...Rule) WhenRule {
+	r := When(false, rules...)
+	r.conditionFunc = condition
+	return r
+}
+
 // WhenRule is a validation rule that executes the given list of rules when the condition is true.
 type WhenRule struct {
-	condition bool
-	rules     []Rule
-	elseRules []Rule
+	condition     bool
+	conditionFunc ConditionFunc
+	rules         []Rule
+	elseRules     []Rule
+	// chained indicates that elseRules holds the next WhenRule of an ElseIf chain.
+	chained bool
 }
 
 // Validate checks if the condition is true and if so, it validates the value using the specified rules.
 func (r WhenRule) Validate(ctx context.Context, value interface{}) error {
-	if r.condition {
+	condition := r.condition
+	if r.conditionFunc != nil {
+		condition = r.conditionFunc(ctx, value)
+	}
+	if condition {
 		return ValidateWithContext(ctx, value, r.rules...)
 	}
 
@@ -30,7 +53,57 @@ func (r WhenRule) Validate(ctx context.Context, value interface{}) error {
 }
 
 // Else returns a validation rule that executes the given list of rules when the condition is false.
+// If the rule has ElseIf branches, the rules are executed when none of the conditions is true.
 func (r WhenRule) Else(rules ...Rule) WhenRule {
+	if r.chained {
+		r.elseRules = []Rule{r.elseRules[0].(WhenRule).Else(rules...)}
+		return r
+	}
 	r.elseRules = rules
 	return r
 }
+
+// ElseIf returns a validation rule that executes the given list of rules when the previous conditions
+// are false and the given condition is true. The branches are evaluated in order, for example,
+//
+//	validation.When(kind == "email", is.Email).
+//	    ElseIf(kind == "phone", is.E164).
+//	    Else(validation.Empty)
+func (r WhenRule) ElseIf(condition bool, rules ...Rule) WhenRule {
+	return r.elseIf(When(condition, rules...))
+}
+
+// ElseIfFunc is like ElseIf, but the condition is evaluated at validation time.
+func (r WhenRule) ElseIfFunc(condition ConditionFunc, rules ...Rule) WhenRule {
+	return r.elseIf(WhenFunc(condition, rules...))
+}
+
+// elseIf appends branch to the end of the ElseIf chain. The rules set by Else are kept
+// as the else rules of the last branch.
+func (r WhenRule) elseIf(branch WhenRule) WhenRule {
+	if r.chained {
+		r.elseRules = []Rule{r.elseRules[0].(WhenRule).elseIf(branch)}
+		return r
+	}
+	branch.elseRules = r.elseRules
+	r.elseRules = []Rule{branch}
+	r.chained = true
+	return r
+}
+
+// Metadata returns the description of the rule.
+// The kind is "when", with the params "condition", and "rules" and "else_rules" holding the rules as []Rule.
+// ElseIf branches are described as WhenRules in "else_rules". If the condition is evaluated at validation time,
+// the param "condition" is omitted and the param "dynamic" is true.
+func (r WhenRule) Metadata() RuleInfo {
+	params := map[string]interface{}{"rules": r.rules, "else_rules": r.elseRules}
+	if r.conditionFunc != nil {
+		params["dynamic"] = true
+	} else {
+		params["condition"] = r.condition
+	}
+	return RuleInfo{
+		Kind:   "when",
+		Params: params,
+	}
+}
@@ -7,8 +7,11 @@ package validation
 import (
 	"context"
 	"errors"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func abcValidation(val string) bool {
@@ -98,3 +101,82 @@ func TestWhenWithContext(t *testing.T) {
 		assertError(t, test.err, err, test.tag)
 	}
 }
+
+func TestWhenFunc(t *testing.T) {
+	abcRule := NewStringRule(abcValidation, "wrong_abc")
+	isAdmin := func(ctx context.Context, value interface{}) bool {
+		return ctx.Value(contains) == "admin"
+	}
+	isLong := func(ctx context.Context, value interface{}) bool {
+		s, _ := value.(string)
+		return len(s) > 3
+	}
+	admin := context.WithValue(context.Background(), contains, "admin")
+
+	tests := []struct {
+		tag   string
+		ctx   context.Context
+		rule  WhenRule
+		value interface{}
+		err   string
+	}{
+		{"t1", admin, WhenFunc(isAdmin, Required), "", "cannot be blank"},
+		{"t2", context.Background(), WhenFunc(isAdmin, Required), "", ""},
+		{"t3", context.Background(), WhenFunc(isAdmin, Required).Else(abcRule), "x", "wrong_abc"},
+		{"t4", context.Background(), WhenFunc(isLong, abcRule), "abcd", "wrong_abc"},
+		{"t5", context.Background(), WhenFunc(isLong, abcRule), "xyz", ""},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, test.value, test.rule)
+		assertError(t, test.err, err, test.tag)
+	}
+}
+
+func TestWhen_ElseIf(t *testing.T) {
+	ruleA := NewStringRule(func(string) bool { return false }, "a")
+	ruleB := NewStringRule(func(string) bool { return false }, "b")
+	ruleC := NewStringRule(func(string) bool { return false }, "c")
+	ruleD := NewStringRule(func(string) bool { return false }, "d")
+	isAdmin := func(ctx context.Context, value interface{}) bool {
+		return ctx.Value(contains) == "admin"
+	}
+	admin := context.WithValue(context.Background(), contains, "admin")
+
+	tests := []struct {
+		tag  string
+		ctx  context.Context
+		rule WhenRule
+		err  string
+	}{
+		{"t1", context.Background(), When(true, ruleA).ElseIf(true, ruleB).Else(ruleC), "a"},
+		{"t2", context.Background(), When(false, ruleA).ElseIf(true, ruleB).Else(ruleC), "b"},
+		{"t3", context.Background(), When(false, ruleA).ElseIf(false, ruleB).Else(ruleC), "c"},
+		{"t4", context.Background(), When(false, ruleA).ElseIf(false, ruleB), ""},
+		{"t5", context.Background(), When(false, ruleA).ElseIf(false, ruleB).ElseIf(true, ruleC).Else(ruleD), "c"},
+		{"t6", context.Background(), When(false, ruleA).ElseIf(false, ruleB).ElseIf(false, ruleC).Else(ruleD), "d"},
+		{"t7", context.Background(), When(false, ruleA).Else(ruleD).ElseIf(false, ruleB), "d"},
+		{"t8", context.Background(), When(false, ruleA).Else(ruleD).ElseIf(true, ruleB), "b"},
+		{"t9", admin, When(false, ruleA).ElseIfFunc(isAdmin, ruleB).Else(ruleC), "b"},
+		{"t10", context.Background(), When(false, ruleA).ElseIfFunc(isAdmin, ruleB).Else(ruleC), "c"},
+	}
+	for _, test := range tests {
+		err := ValidateWithContext(test.ctx, "x", test.rule)
+		assertError(t, test.err, err, test.tag)
+	}
+
+	assert.Equal(t, RuleInfo{Kind: "when", Params: map[string]interface{}{
+		"dynamic": true, "rules": []Rule{Required}, "else_rules": []Rule{},
+	}}, WhenFunc(isAdmin, Required).Metadata())
+
+	infos, err := inspectRules(reflect.Value{}, nil, []Rule{When(false, Required).ElseIf(true, Length(1, 2)).Else(Nil)})
+	assert.NoError(t, err)
+	assert.Equal(t, []RuleInfo{{Kind: "when", Params: map[string]interface{}{
+		"condition": false,
+		"rules":     []RuleInfo{{Kind: "required"}},
+		"else_rules": []RuleInfo{{Kind: "when", Params: map[string]interface{}{
+			"condition":  true,
+			"rules":      []RuleInfo{{Kind: "length", Params: map[string]interface{}{"min": 1, "max": 2, "rune": false}}},
+			"else_rules": []RuleInfo{{Kind: "nil"}},
+		}}},
+	}}}, infos)
+}
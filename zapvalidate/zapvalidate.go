@@ -0,0 +1,71 @@
+// Package zapvalidate provides zap fields for validation errors, so that validation failures
+// are logged as structured objects rather than a single flattened string.
+//
+// It is a separate module so that the validation package does not depend on zap.
+package zapvalidate
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/rockcookies/go-validation"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Errors returns a zap field that logs the validation errors in err as an object keyed by field.
+// Nested Errors become nested objects, and errors implementing validation.Error become objects
+// with the keys "code" and "message". For example,
+//
+//	logger.Warn("invalid request", zapvalidate.Errors("errors", err))
+//
+// If err does not wrap validation.Errors, the field is the same as zap.NamedError(key, err).
+func Errors(key string, err error) zap.Field {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		return zap.NamedError(key, err)
+	}
+	return zap.Object(key, errorsMarshaler(errs))
+}
+
+// errorsMarshaler marshals validation.Errors as a zap object.
+type errorsMarshaler validation.Errors
+
+// MarshalLogObject adds the errors sorted by key to the encoder.
+func (m errorsMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := make([]string, 0, len(m))
+	for key, err := range m {
+		if err != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		switch err := m[key].(type) {
+		case validation.Errors:
+			if e := enc.AddObject(key, errorsMarshaler(err)); e != nil {
+				return e
+			}
+		case validation.Error:
+			if e := enc.AddObject(key, errorMarshaler{err}); e != nil {
+				return e
+			}
+		default:
+			enc.AddString(key, err.Error())
+		}
+	}
+	return nil
+}
+
+// errorMarshaler marshals a validation.Error as a zap object.
+type errorMarshaler struct {
+	err validation.Error
+}
+
+// MarshalLogObject adds the code and the message of the error to the encoder.
+func (m errorMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("code", m.err.Code())
+	enc.AddString("message", m.err.Error())
+	return nil
+}
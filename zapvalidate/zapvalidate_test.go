@@ -0,0 +1,37 @@
+package zapvalidate
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rockcookies/go-validation"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newLogger(buf *bytes.Buffer) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	return zap.New(zapcore.NewCore(enc, zapcore.AddSync(buf), zapcore.DebugLevel))
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		tag  string
+		err  error
+		want string
+	}{
+		{"t1", validation.Errors{
+			"name":    validation.ErrRequired,
+			"address": validation.Errors{"zip": errors.New("must be 5 digits"), "city": nil},
+		}, `{"msg":"invalid","errors":{"address":{"zip":"must be 5 digits"},"name":{"code":"validation_required","message":"cannot be blank"}}}`},
+		{"t2", errors.New("boom"), `{"msg":"invalid","errors":"boom"}`},
+		{"t3", validation.NewInternalError(errors.New("boom")), `{"msg":"invalid","errors":"boom"}`},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		newLogger(&buf).Warn("invalid", Errors("errors", test.err))
+		assert.JSONEq(t, test.want, buf.String(), test.tag)
+	}
+}